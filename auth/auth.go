@@ -0,0 +1,50 @@
+// Package auth authenticates callers of the gRPC server from bearer JWTs and
+// makes the resulting principal available to handlers through the context.
+package auth
+
+import "context"
+
+// Roles granted through the "roles" claim
+const (
+	// RoleAdmin may call every RPC
+	RoleAdmin = "admin"
+	// RoleLibrarian manages the copies and reservations of the library in the "library_id" claim
+	RoleLibrarian = "librarian"
+	// RolePatron acts on the reservations of the patron in the "patron_id" claim
+	RolePatron = "patron"
+)
+
+// Principal is the authenticated caller of an RPC
+type Principal struct {
+	// Subject is the token's "sub" claim
+	Subject string
+	// Roles are the token's "roles" claim
+	Roles []string
+	// LibraryID is the library a librarian's token is scoped to, from the "library_id" claim
+	LibraryID int64
+	// PatronID is the patron a patron's token acts for, from the "patron_id" claim
+	PatronID int64
+}
+
+// HasRole reports whether the principal was granted role
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, if any
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticatorUnary(t *testing.T) {
+	a := NewAuthenticator(NewHMACVerifier(testSecret))
+	valid := sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"iss": nil, "aud": nil}))
+	method := "/reservations.Reservation/GetBook"
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization []string
+		want          codes.Code
+		wantPrincipal bool
+	}{
+		{"valid token", method, []string{"Bearer " + valid}, codes.OK, true},
+		{"lowercase scheme", method, []string{"bearer " + valid}, codes.OK, true},
+		{"missing header", method, nil, codes.Unauthenticated, false},
+		{"empty header", method, []string{""}, codes.Unauthenticated, false},
+		{"no token", method, []string{"Bearer "}, codes.Unauthenticated, false},
+		{"basic auth", method, []string{"Basic YWRhOnNlY3JldA=="}, codes.Unauthenticated, false},
+		{"token without scheme", method, []string{valid}, codes.Unauthenticated, false},
+		{"invalid token", method, []string{"Bearer not-a-jwt"}, codes.Unauthenticated, false},
+		{"expired token", method, []string{"Bearer " + sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"exp": 1}))},
+			codes.Unauthenticated, false},
+		{"public method without token", "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", nil, codes.OK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != nil {
+				md := metadata.MD{"authorization": tt.authorization}
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+
+			var gotPrincipal bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				_, gotPrincipal = FromContext(ctx)
+				return nil, nil
+			}
+			_, err := a.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.want)
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("the handler got a principal: %t, want %t", gotPrincipal, tt.wantPrincipal)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	hmacMethods       = []string{"HS256", "HS384", "HS512"}
+	asymmetricMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// Verifier validates bearer JWTs and extracts the principal they were issued to
+type Verifier struct {
+	// Issuer and Audience are checked against the "iss" and "aud" claims when set
+	Issuer   string
+	Audience string
+
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
+}
+
+// NewHMACVerifier returns a Verifier for tokens signed with a shared HMAC secret
+func NewHMACVerifier(secret []byte) *Verifier {
+	return &Verifier{
+		parser: &jwt.Parser{ValidMethods: hmacMethods},
+		keyFunc: func(*jwt.Token) (interface{}, error) {
+			return secret, nil
+		},
+	}
+}
+
+// NewJWKSVerifier returns a Verifier for tokens signed with one of the RSA or EC
+// public keys in a JSON Web Key Set file. Tokens pick their key with the "kid" header.
+func NewJWKSVerifier(path string) (*Verifier, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseJWKS(contents)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWKS %s: %v", path, err)
+	}
+
+	return &Verifier{
+		parser: &jwt.Parser{ValidMethods: asymmetricMethods},
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			key, ok := keys[kid]
+			if !ok {
+				return nil, fmt.Errorf("unknown key ID %q", kid)
+			}
+			return key, nil
+		},
+	}, nil
+}
+
+// Verify checks the token's signature and standard claims and returns its principal
+func (v *Verifier) Verify(tokenString string) (Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if v.Issuer != "" && !claims.VerifyIssuer(v.Issuer, true) {
+		return Principal{}, errors.New("token has the wrong issuer")
+	}
+	if v.Audience != "" && !claims.VerifyAudience(v.Audience, true) {
+		return Principal{}, errors.New("token has the wrong audience")
+	}
+
+	var p Principal
+	if p.Subject, _ = claims["sub"].(string); p.Subject == "" {
+		return Principal{}, errors.New("token has no subject")
+	}
+
+	if roles, ok := claims["roles"].([]interface{}); ok {
+		for _, role := range roles {
+			if role, ok := role.(string); ok {
+				p.Roles = append(p.Roles, role)
+			}
+		}
+	}
+
+	// JSON numbers decode as float64
+	if libraryID, ok := claims["library_id"].(float64); ok {
+		p.LibraryID = int64(libraryID)
+	}
+	if patronID, ok := claims["patron_id"].(float64); ok {
+		p.PatronID = int64(patronID)
+	}
+
+	return p, nil
+}
+
+// jwk is the subset of RFC 7517 JSON Web Key fields needed for RSA and EC public keys
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJWKS(contents []byte) (map[string]interface{}, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(contents, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{})
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			key interface{}
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = rsaPublicKey(k)
+		case "EC":
+			key, err = ecPublicKey(k)
+		default:
+			err = fmt.Errorf("unsupported key type %q", k.Kty)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+	return keys, nil
+}
+
+func rsaPublicKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func ecPublicKey(k jwk) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
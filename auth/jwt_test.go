@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var testSecret = []byte("test-secret")
+
+// claims returns valid claims for a librarian with the overrides applied, a nil value removing the claim
+func claims(overrides jwt.MapClaims) jwt.MapClaims {
+	c := jwt.MapClaims{
+		"sub":        "ada",
+		"roles":      []string{RoleLibrarian},
+		"library_id": 3,
+		"iss":        "https://issuer.example.com",
+		"aud":        "scheduling-rpc",
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(c, name)
+			continue
+		}
+		c[name] = value
+	}
+	return c
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, c jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(method, c).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// writeJWKS writes a JWKS file holding the public key of key under kid
+func writeJWKS(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	encode := func(n *big.Int) string { return base64.RawURLEncoding.EncodeToString(n.Bytes()) }
+	jwks := fmt.Sprintf(`{"keys": [{"kid": %q, "kty": "RSA", "use": "sig", "n": %q, "e": %q}]}`,
+		kid, encode(key.N), encode(big.NewInt(int64(key.E))))
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(path, []byte(jwks), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHMACVerifier(t *testing.T) {
+	v := NewHMACVerifier(testSecret)
+	v.Issuer, v.Audience = "https://issuer.example.com", "scheduling-rpc"
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", sign(t, jwt.SigningMethodHS256, testSecret, claims(nil)), false},
+		{"valid HS512", sign(t, jwt.SigningMethodHS512, testSecret, claims(nil)), false},
+		{"expired", sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), true},
+		{"not valid yet", sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()})), true},
+		{"wrong secret", sign(t, jwt.SigningMethodHS256, []byte("other-secret"), claims(nil)), true},
+		{"alg none", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(nil)), true},
+		{"RS256", sign(t, jwt.SigningMethodRS256, rsaKey, claims(nil)), true},
+		{"wrong issuer", sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"iss": "https://other.example.com"})), true},
+		{"wrong audience", sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"aud": "other"})), true},
+		{"no subject", sign(t, jwt.SigningMethodHS256, testSecret, claims(jwt.MapClaims{"sub": nil})), true},
+		{"malformed", "not-a-jwt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := v.Verify(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got principal %+v, want an error", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.Subject != "ada" || !principal.HasRole(RoleLibrarian) || principal.LibraryID != 3 {
+				t.Errorf("got %+v, want librarian ada of library 3", principal)
+			}
+		})
+	}
+}
+
+func TestJWKSVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewJWKSVerifier(writeJWKS(t, "key-1", key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withKID := func(method jwt.SigningMethod, signingKey interface{}, kid string) string {
+		token := jwt.NewWithClaims(method, claims(nil))
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(signingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	// An HS256 token signed with the public key must not pass for one signed with the private key
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", withKID(jwt.SigningMethodRS256, key, "key-1"), false},
+		{"unknown key ID", withKID(jwt.SigningMethodRS256, key, "key-2"), true},
+		{"wrong key", withKID(jwt.SigningMethodRS256, other, "key-1"), true},
+		{"HS256 with the public key", withKID(jwt.SigningMethodHS256, publicPEM, "key-1"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := v.Verify(tt.token)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("got %+v and %v, want an error: %t", principal, err, tt.wantErr)
+			}
+		})
+	}
+}
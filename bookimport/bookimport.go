@@ -0,0 +1,176 @@
+// Package bookimport reads the books to import with ImportBooks from CSV or JSON Lines files.
+package bookimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+)
+
+// Format is the encoding of an import file
+type Format string
+
+const (
+	// CSV files start with a header naming their columns, in any order: isbn, library,
+	// price, lat and lng, plus libraryId to give libraries by ID. Only isbn is required.
+	CSV Format = "csv"
+	// JSONL files hold one Book JSON object per line
+	JSONL Format = "jsonl"
+)
+
+// FormatFromPath returns the format of a file going by its extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".jsonl", ".ndjson":
+		return JSONL, nil
+	}
+	return "", fmt.Errorf("can't tell the format of %s from its extension, pass -format csv or -format jsonl", path)
+}
+
+// Row is a row of an import file. Rows that can't be parsed have Err set.
+type Row struct {
+	// Line is the row's line in the file, starting at 1
+	Line int32
+	Book *pb.Book
+	Err  error
+}
+
+// Reader reads the rows of an import file
+type Reader struct {
+	next func() (Row, error)
+}
+
+// NewReader returns a Reader of r in the given format. A CSV header is read straight away.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r)
+	case JSONL:
+		return newJSONLReader(r), nil
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// Next returns the next row, or io.EOF once every row has been read
+func (r *Reader) Next() (Row, error) {
+	return r.next()
+}
+
+var csvColumns = map[string]bool{"isbn": true, "library": true, "libraryId": true, "price": true, "lat": true, "lng": true}
+
+func newCSVReader(r io.Reader) (*Reader, error) {
+	records := csv.NewReader(r)
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	header, err := records.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the CSV file is empty, it needs a header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["isbn"]; !ok {
+		return nil, fmt.Errorf("the CSV header has no isbn column")
+	}
+
+	return &Reader{next: func() (Row, error) {
+		record, err := records.Read()
+		if err == io.EOF {
+			return Row{}, err
+		}
+		if parseErr, ok := err.(*csv.ParseError); ok {
+			return Row{Line: int32(parseErr.StartLine), Err: err}, nil
+		}
+		if err != nil {
+			return Row{}, err
+		}
+
+		line, _ := records.FieldPos(0)
+		row := Row{Line: int32(line)}
+		row.Book, row.Err = parseCSVRecord(columns, record)
+		return row, nil
+	}}, nil
+}
+
+func parseCSVRecord(columns map[string]int, record []string) (*pb.Book, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	float := func(name string) (float32, error) {
+		if field(name) == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(field(name), 32)
+		if err != nil {
+			return 0, fmt.Errorf("%s %q isn't a number", name, field(name))
+		}
+		return float32(value), nil
+	}
+
+	book := &pb.Book{Isbn: field("isbn"), Library: field("library")}
+	var err error
+	if libraryID := field("libraryId"); libraryID != "" {
+		if book.LibraryId, err = strconv.ParseInt(libraryID, 10, 64); err != nil {
+			return nil, fmt.Errorf("libraryId %q isn't an ID", libraryID)
+		}
+	}
+	if book.Price, err = float("price"); err != nil {
+		return nil, err
+	}
+	if book.Lat, err = float("lat"); err != nil {
+		return nil, err
+	}
+	if book.Lng, err = float("lng"); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func newJSONLReader(r io.Reader) *Reader {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(nil, 1<<20)
+	var line int32
+
+	return &Reader{next: func() (Row, error) {
+		for lines.Scan() {
+			line++
+			text := bytes.TrimSpace(lines.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+
+			row := Row{Line: line, Book: &pb.Book{}}
+			if err := jsonpb.Unmarshal(bytes.NewReader(text), row.Book); err != nil {
+				row.Book, row.Err = nil, fmt.Errorf("invalid JSON: %v", err)
+			}
+			return row, nil
+		}
+		if err := lines.Err(); err != nil {
+			return Row{}, err
+		}
+		return Row{}, io.EOF
+	}}
+}
@@ -0,0 +1,456 @@
+// Package config loads the typed configuration shared by the gRPC server, the
+// REST gateway and the CLI subcommands.
+//
+// Values are resolved in increasing order of precedence from the defaults, an
+// optional YAML or TOML file, environment variables and command line flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+const redacted = "[redacted]"
+
+// Secret is a string that is never printed. Use string(s) to read the value.
+type Secret string
+
+// String implements fmt.Stringer
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString implements fmt.GoStringer so %#v doesn't leak the value either
+func (s Secret) GoString() string {
+	return strconv.Quote(s.String())
+}
+
+// Duration is a time.Duration written as a string such as "15s" in config files
+type Duration time.Duration
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// String implements fmt.Stringer
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Config is the complete service configuration
+type Config struct {
+	// Store selects the storage backend: "postgres" or "memory"
+	Store string `yaml:"store" toml:"store"`
+	// ShutdownTimeout bounds how long in-flight requests and workers get to finish on shutdown
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+
+	GRPC GRPC     `yaml:"grpc" toml:"grpc"`
+	HTTP HTTP     `yaml:"http" toml:"http"`
+	DB   Database `yaml:"db" toml:"db"`
+	Auth Auth     `yaml:"auth" toml:"auth"`
+
+	Webhooks Webhooks `yaml:"webhooks" toml:"webhooks"`
+	Overdue  Overdue  `yaml:"overdue" toml:"overdue"`
+	Holds    Holds    `yaml:"holds" toml:"holds"`
+}
+
+// GRPC configures the gRPC server
+type GRPC struct {
+	Host string `yaml:"host" toml:"host"`
+	Port int    `yaml:"port" toml:"port"`
+}
+
+// Addr is the address the gRPC server listens on
+func (g GRPC) Addr() string {
+	return fmt.Sprintf("%s:%d", g.Host, g.Port)
+}
+
+// HTTP configures the REST gateway
+type HTTP struct {
+	Host string `yaml:"host" toml:"host"`
+	Port int    `yaml:"port" toml:"port"`
+	// GatewayTarget is the address of the gRPC server the gateway proxies to
+	GatewayTarget string `yaml:"gateway_target" toml:"gateway_target"`
+}
+
+// Addr is the address the REST gateway listens on
+func (h HTTP) Addr() string {
+	return fmt.Sprintf("%s:%d", h.Host, h.Port)
+}
+
+// Database configures the Postgres connection. DSN takes precedence over the individual fields.
+type Database struct {
+	DSN      Secret `yaml:"dsn" toml:"dsn"`
+	Host     string `yaml:"host" toml:"host"`
+	Port     int    `yaml:"port" toml:"port"`
+	User     string `yaml:"user" toml:"user"`
+	Password Secret `yaml:"password" toml:"password"`
+	Name     string `yaml:"name" toml:"name"`
+	SSLMode  string `yaml:"sslmode" toml:"sslmode"`
+}
+
+// DataSourceName returns the connection string to hand to sql.Open. It contains the password.
+func (d Database) DataSourceName() string {
+	if d.DSN != "" {
+		return string(d.DSN)
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s sslmode=%s dbname=%s", d.Host, d.Port, d.User, string(d.Password), d.SSLMode, d.Name)
+}
+
+var passwordKeyValueRegexp = regexp.MustCompile(`password=('(\\'|[^'])*'|\S*)`)
+
+// RedactedDataSourceName returns the connection string with the password masked, safe to log
+func (d Database) RedactedDataSourceName() string {
+	dsn := d.DataSourceName()
+
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		query := u.Query()
+		if query.Get("password") != "" {
+			query.Set("password", redacted)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+
+	return passwordKeyValueRegexp.ReplaceAllString(dsn, "password="+redacted)
+}
+
+// Auth configures how the gRPC server verifies bearer JWTs. Authentication is
+// disabled when neither an HMAC secret nor a JWKS file is set.
+type Auth struct {
+	// HMACSecret verifies HS256/HS384/HS512 tokens
+	HMACSecret Secret `yaml:"hmac_secret" toml:"hmac_secret"`
+	// JWKSFile is a JSON Web Key Set of RSA and EC public keys verifying RS*, PS* and ES* tokens
+	JWKSFile string `yaml:"jwks_file" toml:"jwks_file"`
+	// Issuer and Audience are checked against the "iss" and "aud" claims when set
+	Issuer   string `yaml:"issuer" toml:"issuer"`
+	Audience string `yaml:"audience" toml:"audience"`
+}
+
+// Enabled reports whether a verification key is configured
+func (a Auth) Enabled() bool {
+	return a.HMACSecret != "" || a.JWKSFile != ""
+}
+
+// Webhooks configures the delivery of webhook events
+type Webhooks struct {
+	// PollInterval is how often the delivery worker looks for due deliveries
+	PollInterval Duration `yaml:"poll_interval" toml:"poll_interval"`
+	// Timeout bounds each delivery request
+	Timeout Duration `yaml:"timeout" toml:"timeout"`
+	// MaxAttempts is how many times a delivery is tried before it is marked as failed
+	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts"`
+}
+
+// Overdue configures the detection of overdue checkouts
+type Overdue struct {
+	// CheckInterval is how often checkouts past their reservation's end are flagged
+	CheckInterval Duration `yaml:"check_interval" toml:"check_interval"`
+}
+
+// Holds configures the promotion of waiting holds
+type Holds struct {
+	// PromoteInterval is how often waiting holds are retried, on top of whenever a copy may have freed up
+	PromoteInterval Duration `yaml:"promote_interval" toml:"promote_interval"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() Config {
+	return Config{
+		Store:           "postgres",
+		ShutdownTimeout: Duration(15 * time.Second),
+		GRPC: GRPC{
+			Port: 5001,
+		},
+		HTTP: HTTP{
+			Port:          8080,
+			GatewayTarget: "localhost:5001",
+		},
+		DB: Database{
+			Host:    "localhost",
+			Port:    5432,
+			SSLMode: "disable",
+		},
+		Webhooks: Webhooks{
+			PollInterval: Duration(time.Second),
+			Timeout:      Duration(10 * time.Second),
+			MaxAttempts:  8,
+		},
+		Overdue: Overdue{
+			CheckInterval: Duration(time.Minute),
+		},
+		Holds: Holds{
+			PromoteInterval: Duration(time.Minute),
+		},
+	}
+}
+
+// String renders the configuration with secrets redacted
+func (c Config) String() string {
+	return fmt.Sprintf("store=%s shutdown_timeout=%s grpc=%s http=%s gateway_target=%s db=%q auth=%t",
+		c.Store, c.ShutdownTimeout, c.GRPC.Addr(), c.HTTP.Addr(), c.HTTP.GatewayTarget, c.DB.RedactedDataSourceName(), c.Auth.Enabled())
+}
+
+// Validate reports every invalid setting at once
+func (c Config) Validate() error {
+	var problems []string
+
+	switch c.Store {
+	case "postgres":
+		if c.DB.DSN == "" {
+			if c.DB.Host == "" {
+				problems = append(problems, "db.host is required")
+			}
+			if !validPort(c.DB.Port) {
+				problems = append(problems, fmt.Sprintf("db.port %d is not a valid port", c.DB.Port))
+			}
+			if c.DB.User == "" {
+				problems = append(problems, "db.user is required")
+			}
+			if c.DB.Name == "" {
+				problems = append(problems, "db.name is required")
+			}
+		}
+	case "memory":
+	default:
+		problems = append(problems, fmt.Sprintf("store must be \"postgres\" or \"memory\", got %q", c.Store))
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		problems = append(problems, "shutdown_timeout must be positive")
+	}
+	if !validPort(c.GRPC.Port) {
+		problems = append(problems, fmt.Sprintf("grpc.port %d is not a valid port", c.GRPC.Port))
+	}
+	if !validPort(c.HTTP.Port) {
+		problems = append(problems, fmt.Sprintf("http.port %d is not a valid port", c.HTTP.Port))
+	}
+	if c.HTTP.GatewayTarget == "" {
+		problems = append(problems, "http.gateway_target is required")
+	}
+	if c.Auth.HMACSecret != "" && c.Auth.JWKSFile != "" {
+		problems = append(problems, "auth.hmac_secret and auth.jwks_file are mutually exclusive")
+	}
+	if c.Webhooks.PollInterval <= 0 {
+		problems = append(problems, "webhooks.poll_interval must be positive")
+	}
+	if c.Webhooks.Timeout <= 0 {
+		problems = append(problems, "webhooks.timeout must be positive")
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		problems = append(problems, "webhooks.max_attempts must be at least 1")
+	}
+	if c.Overdue.CheckInterval <= 0 {
+		problems = append(problems, "overdue.check_interval must be positive")
+	}
+	if c.Holds.PromoteInterval <= 0 {
+		problems = append(problems, "holds.promote_interval must be positive")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port < 65536
+}
+
+// Load resolves the configuration from the defaults, the config file, the
+// environment and the flags in args. It returns the arguments left over after
+// the flags, e.g. a subcommand.
+func Load(name string, args []string) (Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+
+	// Flags are bound to a scratch copy and only the ones actually set are applied,
+	// so that unset flags don't clobber values from the file or the environment
+	var flags Config
+	fs.StringVar(&flags.Store, "store", "", `storage backend, "postgres" or "memory" (env STORE)`)
+	fs.DurationVar((*time.Duration)(&flags.ShutdownTimeout), "shutdown-timeout", 0, "how long to wait for in-flight work on shutdown (env SHUTDOWN_TIMEOUT)")
+	fs.StringVar(&flags.GRPC.Host, "grpc-host", "", "interface the gRPC server binds to (env GRPC_HOST)")
+	fs.IntVar(&flags.GRPC.Port, "grpc-port", 0, "port the gRPC server listens on (env GRPC_PORT)")
+	fs.StringVar(&flags.HTTP.Host, "http-host", "", "interface the REST gateway binds to (env HTTP_HOST)")
+	fs.IntVar(&flags.HTTP.Port, "http-port", 0, "port the REST gateway listens on (env HTTP_PORT)")
+	fs.StringVar(&flags.HTTP.GatewayTarget, "gateway-target", "", "gRPC server address the gateway proxies to (env GATEWAY_TARGET)")
+	fs.StringVar(&flags.DB.Host, "db-host", "", "Postgres host (env PG_HOST)")
+	fs.IntVar(&flags.DB.Port, "db-port", 0, "Postgres port (env PG_PORT)")
+	fs.StringVar(&flags.DB.User, "db-user", "", "Postgres user (env PG_USER)")
+	fs.StringVar(&flags.DB.Name, "db-name", "", "Postgres database (env PG_DB)")
+	fs.StringVar(&flags.DB.SSLMode, "db-sslmode", "", "Postgres sslmode (env PG_SSLMODE)")
+	fs.StringVar(&flags.Auth.JWKSFile, "auth-jwks-file", "", "JWKS file of public keys verifying bearer tokens (env AUTH_JWKS_FILE)")
+	fs.StringVar(&flags.Auth.Issuer, "auth-issuer", "", "required bearer token issuer (env AUTH_ISSUER)")
+	fs.StringVar(&flags.Auth.Audience, "auth-audience", "", "required bearer token audience (env AUTH_AUDIENCE)")
+	// The password, DSN and HMAC secret are deliberately not flags as they would show up in the process list
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, &cfg); err != nil {
+			return Config{}, nil, err
+		}
+	}
+
+	if err := loadEnv(&cfg); err != nil {
+		return Config{}, nil, err
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "store":
+			cfg.Store = flags.Store
+		case "shutdown-timeout":
+			cfg.ShutdownTimeout = flags.ShutdownTimeout
+		case "grpc-host":
+			cfg.GRPC.Host = flags.GRPC.Host
+		case "grpc-port":
+			cfg.GRPC.Port = flags.GRPC.Port
+		case "http-host":
+			cfg.HTTP.Host = flags.HTTP.Host
+		case "http-port":
+			cfg.HTTP.Port = flags.HTTP.Port
+		case "gateway-target":
+			cfg.HTTP.GatewayTarget = flags.HTTP.GatewayTarget
+		case "db-host":
+			cfg.DB.Host = flags.DB.Host
+		case "db-port":
+			cfg.DB.Port = flags.DB.Port
+		case "db-user":
+			cfg.DB.User = flags.DB.User
+		case "db-name":
+			cfg.DB.Name = flags.DB.Name
+		case "db-sslmode":
+			cfg.DB.SSLMode = flags.DB.SSLMode
+		case "auth-jwks-file":
+			cfg.Auth.JWKSFile = flags.Auth.JWKSFile
+		case "auth-issuer":
+			cfg.Auth.Issuer = flags.Auth.Issuer
+		case "auth-audience":
+			cfg.Auth.Audience = flags.Auth.Audience
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(contents, cfg)
+	case ".toml":
+		var meta toml.MetaData
+		meta, err = toml.Decode(string(contents), cfg)
+		if err == nil && len(meta.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", meta.Undecoded())
+		}
+	default:
+		return fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %v", path, err)
+	}
+	return nil
+}
+
+func loadEnv(cfg *Config) error {
+	strs := map[string]*string{
+		"STORE":          &cfg.Store,
+		"GRPC_HOST":      &cfg.GRPC.Host,
+		"HTTP_HOST":      &cfg.HTTP.Host,
+		"GATEWAY_TARGET": &cfg.HTTP.GatewayTarget,
+		"PG_HOST":        &cfg.DB.Host,
+		"PG_USER":        &cfg.DB.User,
+		"PG_DB":          &cfg.DB.Name,
+		"PG_SSLMODE":     &cfg.DB.SSLMode,
+		"AUTH_JWKS_FILE": &cfg.Auth.JWKSFile,
+		"AUTH_ISSUER":    &cfg.Auth.Issuer,
+		"AUTH_AUDIENCE":  &cfg.Auth.Audience,
+	}
+	for key, field := range strs {
+		if value, ok := os.LookupEnv(key); ok {
+			*field = value
+		}
+	}
+
+	secrets := map[string]*Secret{
+		"DATABASE_URL":     &cfg.DB.DSN,
+		"PG_PASSWORD":      &cfg.DB.Password,
+		"AUTH_HMAC_SECRET": &cfg.Auth.HMACSecret,
+	}
+	for key, field := range secrets {
+		if value, ok := os.LookupEnv(key); ok {
+			*field = Secret(value)
+		}
+	}
+
+	durations := map[string]*Duration{
+		"SHUTDOWN_TIMEOUT":       &cfg.ShutdownTimeout,
+		"WEBHOOK_POLL_INTERVAL":  &cfg.Webhooks.PollInterval,
+		"WEBHOOK_TIMEOUT":        &cfg.Webhooks.Timeout,
+		"OVERDUE_CHECK_INTERVAL": &cfg.Overdue.CheckInterval,
+		"HOLD_PROMOTE_INTERVAL":  &cfg.Holds.PromoteInterval,
+	}
+	for key, field := range durations {
+		if value, ok := os.LookupEnv(key); ok {
+			if err := field.UnmarshalText([]byte(value)); err != nil {
+				return fmt.Errorf("%s must be a duration, got %q", key, value)
+			}
+		}
+	}
+
+	ints := map[string]*int{
+		"GRPC_PORT":            &cfg.GRPC.Port,
+		"HTTP_PORT":            &cfg.HTTP.Port,
+		"PG_PORT":              &cfg.DB.Port,
+		"WEBHOOK_MAX_ATTEMPTS": &cfg.Webhooks.MaxAttempts,
+	}
+	for key, field := range ints {
+		if value, ok := os.LookupEnv(key); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%s must be an integer, got %q", key, value)
+			}
+			*field = n
+		}
+	}
+
+	return nil
+}
@@ -1,11 +1,15 @@
 module github.com/pmaroli/scheduling-rpc
 
-go 1.14
+go 1.16
 
 require (
+	github.com/BurntSushi/toml v0.3.1
+	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/golang/protobuf v1.3.4
 	github.com/grpc-ecosystem/grpc-gateway v1.13.0
 	github.com/lib/pq v1.3.0
-	google.golang.org/genproto v0.0.0-20190927181202-20e1ac93f88c
+	github.com/teambition/rrule-go v1.8.2
+	google.golang.org/genproto v0.0.0-20200430143042-b979b6f78d84
 	google.golang.org/grpc v1.27.1
+	gopkg.in/yaml.v2 v2.2.3
 )
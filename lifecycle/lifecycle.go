@@ -0,0 +1,119 @@
+// Package lifecycle runs the long-lived parts of the service and shuts them
+// down gracefully when the process is asked to stop.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Component is a long-lived part of the service, such as a server or a background worker
+type Component interface {
+	// Start runs the component and blocks until it has stopped. It returns nil
+	// when stopped through Stop.
+	Start() error
+	// Stop asks the component to finish in-flight work and return from Start.
+	// Once ctx is done the component must abandon whatever work is left.
+	Stop(ctx context.Context) error
+}
+
+type namedComponent struct {
+	name string
+	Component
+}
+
+// Manager starts a set of components and stops them all as soon as the
+// process receives SIGINT or SIGTERM, or any of the components exits
+type Manager struct {
+	// ShutdownTimeout bounds how long stopping the components and closers may take
+	ShutdownTimeout time.Duration
+
+	components []namedComponent
+	closers    []func() error
+}
+
+// New returns a Manager that gives its components shutdownTimeout to stop
+func New(shutdownTimeout time.Duration) *Manager {
+	return &Manager{ShutdownTimeout: shutdownTimeout}
+}
+
+// Add registers a component. Components are started in the order they are
+// added and stopped in reverse order, so a component should be added after
+// the ones it depends on.
+func (m *Manager) Add(name string, c Component) {
+	m.components = append(m.components, namedComponent{name: name, Component: c})
+}
+
+// OnStop registers a function to run once every component has stopped, such
+// as closing a database pool. Closers run in reverse order of registration.
+func (m *Manager) OnStop(f func() error) {
+	m.closers = append(m.closers, f)
+}
+
+// Run starts every component and blocks until they have all been stopped. It
+// returns the error of the first component that exited on its own, if any.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	errChan := make(chan error, len(m.components))
+	for _, c := range m.components {
+		go func(c namedComponent) {
+			err := c.Start()
+			if err != nil {
+				err = fmt.Errorf("%s: %v", c.name, err)
+			}
+			errChan <- err
+		}(c)
+	}
+
+	var (
+		firstErr error
+		running  = len(m.components)
+	)
+	select {
+	case <-ctx.Done():
+		fmt.Println("Received shutdown signal")
+	case firstErr = <-errChan:
+		running--
+		fmt.Println("A component exited, shutting down:", firstErr)
+	}
+	// Restore the default signal behaviour so a second signal kills the process right away
+	stopSignals()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.ShutdownTimeout)
+	defer cancel()
+
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		fmt.Printf("Stopping %s\n", c.name)
+		if err := c.Stop(shutdownCtx); err != nil {
+			fmt.Printf("Failed to stop %s cleanly: %v\n", c.name, err)
+		}
+	}
+
+	for ; running > 0; running-- {
+		select {
+		case err := <-errChan:
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		case <-shutdownCtx.Done():
+			fmt.Printf("Gave up waiting on %d component(s) after %s\n", running, m.ShutdownTimeout)
+			running = 0
+		}
+	}
+
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		if err := m.closers[i](); err != nil {
+			fmt.Println("Failed to clean up:", err)
+		}
+	}
+
+	fmt.Println("Shut down")
+	return firstErr
+}
@@ -0,0 +1,60 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Worker is a Component that runs a function on a fixed interval
+type Worker struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+
+	// ctx is passed to run and cancelled once the shutdown deadline passes
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWorker returns a Worker that calls run every interval until stopped
+func NewWorker(name string, interval time.Duration, run func(ctx context.Context) error) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{
+		name:     name,
+		interval: interval,
+		run:      run,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the worker until Stop is called
+func (w *Worker) Start() error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return nil
+		case <-ticker.C:
+			if err := w.run(w.ctx); err != nil {
+				fmt.Printf("%s: %v\n", w.name, err)
+			}
+		}
+	}
+}
+
+// Stop lets the current run finish and prevents any further ones. The run is
+// cancelled if it is still going when ctx is done.
+func (w *Worker) Stop(ctx context.Context) error {
+	close(w.done)
+	go func() {
+		<-ctx.Done()
+		w.cancel()
+	}()
+	return nil
+}
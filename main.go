@@ -1,30 +1,297 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	// Library time zones are validated and resolved even where the host has no zoneinfo
+	_ "time/tzdata"
 
 	_ "github.com/lib/pq"
+	"github.com/pmaroli/scheduling-rpc/bookimport"
+	"github.com/pmaroli/scheduling-rpc/config"
+	"github.com/pmaroli/scheduling-rpc/lifecycle"
+	"github.com/pmaroli/scheduling-rpc/migrations"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
 	"github.com/pmaroli/scheduling-rpc/server/rest"
 	"github.com/pmaroli/scheduling-rpc/server/rpc"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+const usage = `usage:
+  main [flags]                        start the gRPC server and REST gateway
+  main [flags] migrate up             apply all pending migrations
+  main [flags] migrate down [steps]   roll back the last steps migrations (default 1)
+  main [flags] migrate status         list migrations and whether they are applied
+  main [flags] migrate baseline v     mark migrations up to version v as applied without running them
+  main [flags] import [-dry-run] [-format csv|jsonl] [-token t] file
+                                      import books from a CSV or JSON Lines file
+
+Run main -h to list the flags.`
+
 func main() {
-	var (
-		funcs = []func() error{
-			rpc.Start,
-			rest.Start,
+	cfg, args, err := config.Load(os.Args[0], os.Args[1:])
+	if err == flag.ErrHelp {
+		fmt.Println(usage)
+		return
+	}
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			if err := migrate(cfg, args[1:]); err != nil {
+				log.Fatalf("error: %+v", err)
+			}
+			return
+		case "import":
+			if err := importBooks(cfg, args[1:]); err != nil {
+				log.Fatalf("error: %+v", err)
+			}
+			return
+		default:
+			log.Fatal(usage)
 		}
+	}
+
+	fmt.Println("Loaded config:", cfg)
+	if err := serve(cfg); err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
 
-		errChan = make(chan error)
-	)
+// serve runs the gRPC server, REST gateway, webhook deliveries, overdue checks and hold promotions until the process is signalled to stop
+func serve(cfg config.Config) error {
+	manager := lifecycle.New(time.Duration(cfg.ShutdownTimeout))
 
-	for _, f := range funcs {
-		go func(f func() error) {
-			errChan <- f()
-		}(f)
+	st, err := openStore(cfg, manager)
+	if err != nil {
+		return err
 	}
 
-	for err := range errChan {
-		log.Fatalf("error: %+v", err)
+	grpcServer, err := rpc.New(cfg, st)
+	if err != nil {
+		return err
+	}
+	manager.Add("gRPC server", grpcServer)
+
+	gateway, err := rest.New(cfg)
+	if err != nil {
+		return err
+	}
+	manager.Add("REST gateway", gateway)
+
+	deliverer := webhook.NewDeliverer(st, cfg.Webhooks)
+	manager.Add("webhook deliveries", lifecycle.NewWorker("webhook deliveries", time.Duration(cfg.Webhooks.PollInterval), deliverer.Run))
+	manager.Add("overdue checks", rpc.NewOverdueWorker(st, time.Duration(cfg.Overdue.CheckInterval)))
+	manager.Add("hold promotions", rpc.NewHoldWorker(st, time.Duration(cfg.Holds.PromoteInterval)))
+
+	return manager.Run(context.Background())
+}
+
+// openStore returns the configured store, registering any cleanup with the manager
+func openStore(cfg config.Config, manager *lifecycle.Manager) (store.Store, error) {
+	if cfg.Store == "memory" {
+		fmt.Println("Using the in-memory store")
+		return store.NewMemory(), nil
+	}
+
+	fmt.Println("Connecting to the DB:", cfg.DB.RedactedDataSourceName())
+	db, err := sql.Open("postgres", cfg.DB.DataSourceName())
+	if err != nil {
+		return nil, err
+	}
+	manager.OnStop(db.Close)
+
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	fmt.Println("Connected to the DB!")
+
+	return store.NewPostgres(db), nil
+}
+
+// migrate runs the `migrate` subcommand
+func migrate(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", usage)
+	}
+	if cfg.Store != "postgres" {
+		return fmt.Errorf("migrations only apply to the postgres store, not %q", cfg.Store)
+	}
+
+	db, err := sql.Open("postgres", cfg.DB.DataSourceName())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, m := range rolledBack {
+			fmt.Printf("Rolled back %04d_%s\n", m.Version, m.Name)
+		}
+		return err
+
+	case "baseline":
+		if len(args) < 2 {
+			return fmt.Errorf("missing baseline version\n%s", usage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 1 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+
+		recorded, err := migrator.Baseline(ctx, version)
+		for _, m := range recorded {
+			fmt.Printf("Marked %04d_%s as applied\n", m.Version, m.Name)
+		}
+		if err == nil && len(recorded) == 0 {
+			fmt.Println("Every migration up to that version is already applied")
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, appliedAt)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown migrate command %q\n%s", args[0], usage)
+}
+
+// importBooks runs the `import` subcommand, streaming a file's books to the gRPC server
+// the gateway proxies to. Rows that can't be parsed are reported without being sent.
+func importBooks(cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "validate the rows and report what would change without changing anything")
+	format := fs.String("format", "", "csv or jsonl (default from the file extension)")
+	token := fs.String("token", "", "bearer token to authenticate with when auth is enabled")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("import needs exactly one file\n%s", usage)
+	}
+	path := fs.Arg(0)
+
+	importFormat := bookimport.Format(*format)
+	if importFormat == "" {
+		var err error
+		if importFormat, err = bookimport.FormatFromPath(path); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	rows, err := bookimport.NewReader(file, importFormat)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(cfg.HTTP.GatewayTarget, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	if *token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+	stream, err := pb.NewReservationClient(conn).ImportBooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	unparsed := 0
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if row.Err != nil {
+			fmt.Printf("line %d: rejected (%v)\n", row.Line, row.Err)
+			unparsed++
+			continue
+		}
+		if err = stream.Send(&pb.ImportBooksReq{Book: row.Book, DryRun: *dryRun, Row: row.Line}); err != nil {
+			// The server ended the stream, and its error is returned by CloseAndRecv
+			break
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	for _, row := range res.GetRows() {
+		if row.GetOutcome() == pb.ImportOutcome_REJECTED {
+			fmt.Printf("line %d: %s rejected (%s: %s)\n", row.GetRow(), row.GetIsbn(), row.GetReason(), row.GetError())
+		} else {
+			fmt.Printf("line %d: %s %s\n", row.GetRow(), row.GetIsbn(), strings.ToLower(row.GetOutcome().String()))
+		}
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d rejected", res.GetCreated(), res.GetUpdated(), res.GetRejected()+int32(unparsed))
+	if *dryRun {
+		summary += " (dry run, nothing was changed)"
 	}
+	fmt.Println(summary)
+	return nil
 }
@@ -0,0 +1,4 @@
+-- The postgis and btree_gist extensions are left in place as other schemas may depend on them
+DROP TABLE checked_out;
+DROP TABLE reservations;
+DROP TABLE books;
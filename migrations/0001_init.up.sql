@@ -1,5 +1,5 @@
-CREATE EXTENSION postgis;
-CREATE EXTENSION btree_gist;
+CREATE EXTENSION IF NOT EXISTS postgis;
+CREATE EXTENSION IF NOT EXISTS btree_gist;
 
 CREATE TABLE books (
     isbn VARCHAR PRIMARY KEY NOT NULL,
@@ -28,4 +28,4 @@ VALUES
     (9917, 'Newport Beach', 50.6, ST_MakePoint(-117.9298, 33.6189)),
     (1245, 'Newport Beach', 500.50, ST_MakePoint(-117.9298, 33.6189)),
     (1351, 'Irvine', 25, ST_MakePoint(-117.8265, 33.6846)),
-    (5232, 'Costa Mesa', 300, ST_MakePoint(-117.9047, 33.6638));
\ No newline at end of file
+    (5232, 'Costa Mesa', 300, ST_MakePoint(-117.9047, 33.6638));
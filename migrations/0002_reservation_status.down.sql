@@ -0,0 +1,3 @@
+ALTER TABLE reservations
+    DROP COLUMN status,
+    DROP COLUMN created_at;
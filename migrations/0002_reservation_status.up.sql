@@ -0,0 +1,8 @@
+ALTER TABLE reservations
+    ADD COLUMN status VARCHAR NOT NULL DEFAULT 'reserved'
+        CHECK (status IN ('reserved', 'checked_out', 'returned')),
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE reservations
+SET status = 'checked_out'
+WHERE id IN (SELECT reservation_id FROM checked_out);
@@ -0,0 +1,13 @@
+-- Cancelled reservations are removed as they could overlap other reservations
+DELETE FROM reservations
+WHERE status = 'cancelled';
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_isbn_duration_excl,
+    ADD CONSTRAINT reservations_isbn_duration_excl
+        EXCLUDE USING gist (isbn WITH =, duration WITH &&);
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_status_check,
+    ADD CONSTRAINT reservations_status_check
+        CHECK (status IN ('reserved', 'checked_out', 'returned'));
@@ -0,0 +1,10 @@
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_status_check,
+    ADD CONSTRAINT reservations_status_check
+        CHECK (status IN ('reserved', 'checked_out', 'returned', 'cancelled'));
+
+-- Cancelled reservations no longer hold on to their slot
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_isbn_duration_excl,
+    ADD CONSTRAINT reservations_isbn_duration_excl
+        EXCLUDE USING gist (isbn WITH =, duration WITH &&) WHERE (status <> 'cancelled');
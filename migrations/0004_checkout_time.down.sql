@@ -0,0 +1,4 @@
+DROP INDEX reservation_created_at_index;
+
+ALTER TABLE checked_out
+    DROP COLUMN checked_out_at;
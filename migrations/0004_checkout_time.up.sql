@@ -0,0 +1,4 @@
+ALTER TABLE checked_out
+    ADD COLUMN checked_out_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX reservation_created_at_index ON reservations (created_at);
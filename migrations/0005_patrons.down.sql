@@ -0,0 +1,9 @@
+DROP INDEX reservation_patron_index;
+
+ALTER TABLE checked_out
+    DROP COLUMN patron_id;
+
+ALTER TABLE reservations
+    DROP COLUMN patron_id;
+
+DROP TABLE patrons;
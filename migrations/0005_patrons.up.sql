@@ -0,0 +1,16 @@
+CREATE TABLE patrons (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    email VARCHAR NOT NULL UNIQUE,
+    phone VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Reservations made before patrons existed are left without one
+ALTER TABLE reservations
+    ADD COLUMN patron_id INT REFERENCES patrons (id);
+
+ALTER TABLE checked_out
+    ADD COLUMN patron_id INT REFERENCES patrons (id);
+
+CREATE INDEX reservation_patron_index ON reservations (patron_id);
@@ -0,0 +1,30 @@
+ALTER TABLE books
+    ADD COLUMN library VARCHAR,
+    ADD COLUMN geog GEOGRAPHY;
+
+-- Books get back the library and location of their first copy
+UPDATE books b
+SET library = c.library, geog = c.geog
+FROM (
+    SELECT DISTINCT ON (isbn) isbn, library, geog
+    FROM copies
+    ORDER BY isbn, id
+) c
+WHERE c.isbn = b.isbn;
+
+CREATE INDEX IF NOT EXISTS geograph_index ON books USING gist (geog);
+
+-- Fails if several copies of a title are checked out
+ALTER TABLE checked_out
+    DROP CONSTRAINT checked_out_copy_id_key,
+    DROP COLUMN copy_id,
+    ADD CONSTRAINT checked_out_isbn_key UNIQUE (isbn);
+
+-- Fails if several copies of a title are reserved over overlapping windows
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_copy_id_duration_excl,
+    DROP COLUMN copy_id,
+    ADD CONSTRAINT reservations_isbn_duration_excl
+        EXCLUDE USING gist (isbn WITH =, duration WITH &&) WHERE (status <> 'cancelled');
+
+DROP TABLE copies;
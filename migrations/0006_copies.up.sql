@@ -0,0 +1,53 @@
+-- Books become titles, each held as one or more physical copies
+CREATE TABLE copies (
+    id SERIAL PRIMARY KEY,
+    isbn VARCHAR NOT NULL REFERENCES books (isbn),
+    library VARCHAR NOT NULL,
+    location VARCHAR NOT NULL DEFAULT '',
+    barcode VARCHAR NOT NULL UNIQUE,
+    geog GEOGRAPHY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX copy_isbn_index ON copies (isbn);
+CREATE INDEX copy_geog_index ON copies USING gist (geog);
+
+-- Every existing book becomes the first copy of its title, barcoded with the ISBN
+INSERT INTO copies (isbn, library, barcode, geog)
+SELECT isbn, COALESCE(library, ''), isbn, geog
+FROM books;
+
+-- Reservations hold a single copy, so overlaps are checked per copy
+ALTER TABLE reservations
+    ADD COLUMN copy_id INT REFERENCES copies (id);
+
+UPDATE reservations r
+SET copy_id = c.id
+FROM copies c
+WHERE c.isbn = r.isbn;
+
+ALTER TABLE reservations
+    ALTER COLUMN copy_id SET NOT NULL,
+    DROP CONSTRAINT reservations_isbn_duration_excl,
+    ADD CONSTRAINT reservations_copy_id_duration_excl
+        EXCLUDE USING gist (copy_id WITH =, duration WITH &&) WHERE (status <> 'cancelled');
+
+CREATE INDEX reservation_copy_index ON reservations (copy_id);
+
+-- Each copy can be checked out once at a time, rather than each title
+ALTER TABLE checked_out
+    ADD COLUMN copy_id INT REFERENCES copies (id);
+
+UPDATE checked_out co
+SET copy_id = r.copy_id
+FROM reservations r
+WHERE r.id = co.reservation_id;
+
+ALTER TABLE checked_out
+    ALTER COLUMN copy_id SET NOT NULL,
+    DROP CONSTRAINT checked_out_isbn_key,
+    ADD CONSTRAINT checked_out_copy_id_key UNIQUE (copy_id);
+
+ALTER TABLE books
+    DROP COLUMN library,
+    DROP COLUMN geog;
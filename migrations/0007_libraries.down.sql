@@ -0,0 +1,16 @@
+ALTER TABLE copies
+    ADD COLUMN library VARCHAR,
+    ADD COLUMN geog GEOGRAPHY;
+
+UPDATE copies c
+SET library = l.name, geog = l.geog
+FROM libraries l
+WHERE l.id = c.library_id;
+
+ALTER TABLE copies
+    ALTER COLUMN library SET NOT NULL,
+    DROP COLUMN library_id;
+
+CREATE INDEX copy_geog_index ON copies USING gist (geog);
+
+DROP TABLE libraries;
@@ -0,0 +1,35 @@
+CREATE TABLE libraries (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL UNIQUE,
+    address VARCHAR NOT NULL DEFAULT '',
+    geog GEOGRAPHY NOT NULL,
+    -- IANA time zone, e.g. America/Los_Angeles
+    timezone VARCHAR NOT NULL DEFAULT 'UTC',
+    email VARCHAR NOT NULL DEFAULT '',
+    phone VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX library_geog_index ON libraries USING gist (geog);
+
+-- Every library named on a copy becomes a library, located where its first copy is
+INSERT INTO libraries (name, geog)
+SELECT DISTINCT ON (library) library, COALESCE(geog, ST_MakePoint(0, 0)::geography)
+FROM copies
+ORDER BY library, id;
+
+ALTER TABLE copies
+    ADD COLUMN library_id INT REFERENCES libraries (id);
+
+UPDATE copies c
+SET library_id = l.id
+FROM libraries l
+WHERE l.name = c.library;
+
+-- Copies are located by their library from now on
+ALTER TABLE copies
+    ALTER COLUMN library_id SET NOT NULL,
+    DROP COLUMN library,
+    DROP COLUMN geog;
+
+CREATE INDEX copy_library_index ON copies (library_id);
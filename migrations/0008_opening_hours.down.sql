@@ -0,0 +1,3 @@
+DROP TABLE hours_exceptions;
+
+DROP TABLE opening_hours;
@@ -0,0 +1,26 @@
+-- Weekly opening hours, in the library's time zone. A library without any is open around the clock.
+CREATE TABLE opening_hours (
+    id SERIAL PRIMARY KEY,
+    library_id INT NOT NULL REFERENCES libraries (id) ON DELETE CASCADE,
+    -- 0 is Sunday
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens TIME NOT NULL,
+    closes TIME NOT NULL,
+    CHECK (opens < closes)
+);
+
+CREATE INDEX opening_hours_library_index ON opening_hours (library_id);
+
+-- Dates on which the weekly hours don't apply, such as holidays and closures
+CREATE TABLE hours_exceptions (
+    id SERIAL PRIMARY KEY,
+    library_id INT NOT NULL REFERENCES libraries (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    -- Both NULL when the library is closed all day
+    opens TIME,
+    closes TIME,
+    reason VARCHAR NOT NULL DEFAULT '',
+    CHECK ((opens IS NULL) = (closes IS NULL)),
+    CHECK (opens < closes),
+    UNIQUE (library_id, date)
+);
@@ -0,0 +1,6 @@
+DROP TABLE holds;
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_copy_id_duration_excl,
+    ADD CONSTRAINT reservations_copy_id_duration_excl
+        EXCLUDE USING gist (copy_id WITH =, duration WITH &&) WHERE (status <> 'cancelled');
@@ -0,0 +1,24 @@
+-- Returned reservations no longer hold their slot, so books returned early can be reserved again
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_copy_id_duration_excl,
+    ADD CONSTRAINT reservations_copy_id_duration_excl
+        EXCLUDE USING gist (copy_id WITH =, duration WITH &&) WHERE (status IN ('reserved', 'checked_out'));
+
+-- Holds queue patrons for the next window a book is free, first come first served
+CREATE TABLE holds (
+    id SERIAL PRIMARY KEY,
+    isbn VARCHAR NOT NULL REFERENCES books (isbn),
+    patron_id INT NOT NULL REFERENCES patrons (id),
+    -- The library to reserve at, or any library if NULL
+    library_id INT REFERENCES libraries (id),
+    days INT NOT NULL CHECK (days > 0),
+    status VARCHAR NOT NULL DEFAULT 'waiting'
+        CHECK (status IN ('waiting', 'fulfilled', 'cancelled')),
+    -- The reservation the hold was promoted to
+    reservation_id INT REFERENCES reservations (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- A patron waits in each queue at most once
+CREATE UNIQUE INDEX holds_waiting_key ON holds (isbn, patron_id) WHERE status = 'waiting';
+CREATE INDEX hold_isbn_index ON holds (isbn, id) WHERE status = 'waiting';
@@ -0,0 +1,12 @@
+DROP TABLE fees;
+
+ALTER TABLE reservations
+    DROP COLUMN returned_at;
+
+ALTER TABLE checked_out
+    DROP COLUMN overdue_at;
+
+ALTER TABLE libraries
+    DROP COLUMN late_fee_per_day,
+    DROP COLUMN late_fee_percent_per_day,
+    DROP COLUMN late_fee_max;
@@ -0,0 +1,25 @@
+-- Each library charges for late returns per day late, as a flat fee and a percentage
+-- of the book's price, capped at late_fee_max unless it is 0
+ALTER TABLE libraries
+    ADD COLUMN late_fee_per_day FLOAT8 NOT NULL DEFAULT 0 CHECK (late_fee_per_day >= 0),
+    ADD COLUMN late_fee_percent_per_day FLOAT8 NOT NULL DEFAULT 0 CHECK (late_fee_percent_per_day >= 0),
+    ADD COLUMN late_fee_max FLOAT8 NOT NULL DEFAULT 0 CHECK (late_fee_max >= 0);
+
+-- Set by the overdue job once a checkout is past its reservation's end
+ALTER TABLE checked_out
+    ADD COLUMN overdue_at TIMESTAMPTZ;
+
+ALTER TABLE reservations
+    ADD COLUMN returned_at TIMESTAMPTZ;
+
+-- The charges on patrons' accounts
+CREATE TABLE fees (
+    id SERIAL PRIMARY KEY,
+    patron_id INT NOT NULL REFERENCES patrons (id),
+    reservation_id INT REFERENCES reservations (id),
+    amount FLOAT8 NOT NULL CHECK (amount > 0),
+    days_late INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX fee_patron_index ON fees (patron_id);
@@ -0,0 +1,6 @@
+ALTER TABLE reservations
+    DROP COLUMN renewals;
+
+ALTER TABLE libraries
+    DROP COLUMN max_renewals,
+    DROP COLUMN max_loan_days;
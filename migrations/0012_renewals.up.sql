@@ -0,0 +1,8 @@
+-- Limits on renewing loans at each library, where 0 means no limit
+ALTER TABLE libraries
+    ADD COLUMN max_renewals INT NOT NULL DEFAULT 0 CHECK (max_renewals >= 0),
+    ADD COLUMN max_loan_days INT NOT NULL DEFAULT 0 CHECK (max_loan_days >= 0);
+
+-- How many times a loan has been renewed
+ALTER TABLE reservations
+    ADD COLUMN renewals INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE reservations
+    DROP COLUMN series_id;
+
+DROP TABLE reservation_series;
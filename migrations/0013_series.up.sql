@@ -0,0 +1,18 @@
+-- Series of reservations recurring by an RFC 5545 RRULE, cancellable as one
+CREATE TABLE reservation_series (
+    id SERIAL PRIMARY KEY,
+    isbn VARCHAR NOT NULL REFERENCES books (isbn),
+    patron_id INT REFERENCES patrons (id),
+    rrule VARCHAR NOT NULL,
+    -- The IANA time zone the occurrences keep their wall clock time in
+    timezone VARCHAR NOT NULL,
+    -- The window of the first occurrence
+    duration TSTZRANGE NOT NULL,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE reservations
+    ADD COLUMN series_id INT REFERENCES reservation_series (id);
+
+CREATE INDEX reservation_series_index ON reservations (series_id) WHERE series_id IS NOT NULL;
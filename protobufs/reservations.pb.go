@@ -25,6 +25,261 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Weekday int32
+
+const (
+	Weekday_SUNDAY    Weekday = 0
+	Weekday_MONDAY    Weekday = 1
+	Weekday_TUESDAY   Weekday = 2
+	Weekday_WEDNESDAY Weekday = 3
+	Weekday_THURSDAY  Weekday = 4
+	Weekday_FRIDAY    Weekday = 5
+	Weekday_SATURDAY  Weekday = 6
+)
+
+var Weekday_name = map[int32]string{
+	0: "SUNDAY",
+	1: "MONDAY",
+	2: "TUESDAY",
+	3: "WEDNESDAY",
+	4: "THURSDAY",
+	5: "FRIDAY",
+	6: "SATURDAY",
+}
+
+var Weekday_value = map[string]int32{
+	"SUNDAY":    0,
+	"MONDAY":    1,
+	"TUESDAY":   2,
+	"WEDNESDAY": 3,
+	"THURSDAY":  4,
+	"FRIDAY":    5,
+	"SATURDAY":  6,
+}
+
+func (x Weekday) String() string {
+	return proto.EnumName(Weekday_name, int32(x))
+}
+
+func (Weekday) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{0}
+}
+
+type ImportOutcome int32
+
+const (
+	ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED ImportOutcome = 0
+	ImportOutcome_CREATED                    ImportOutcome = 1
+	ImportOutcome_UPDATED                    ImportOutcome = 2
+	ImportOutcome_REJECTED                   ImportOutcome = 3
+)
+
+var ImportOutcome_name = map[int32]string{
+	0: "IMPORT_OUTCOME_UNSPECIFIED",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "REJECTED",
+}
+
+var ImportOutcome_value = map[string]int32{
+	"IMPORT_OUTCOME_UNSPECIFIED": 0,
+	"CREATED":                    1,
+	"UPDATED":                    2,
+	"REJECTED":                   3,
+}
+
+func (x ImportOutcome) String() string {
+	return proto.EnumName(ImportOutcome_name, int32(x))
+}
+
+func (ImportOutcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{1}
+}
+
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_STATUS_UNSPECIFIED ReservationStatus = 0
+	ReservationStatus_RESERVED                       ReservationStatus = 1
+	ReservationStatus_CHECKED_OUT                    ReservationStatus = 2
+	ReservationStatus_RETURNED                       ReservationStatus = 3
+	ReservationStatus_CANCELLED                      ReservationStatus = 4
+)
+
+var ReservationStatus_name = map[int32]string{
+	0: "RESERVATION_STATUS_UNSPECIFIED",
+	1: "RESERVED",
+	2: "CHECKED_OUT",
+	3: "RETURNED",
+	4: "CANCELLED",
+}
+
+var ReservationStatus_value = map[string]int32{
+	"RESERVATION_STATUS_UNSPECIFIED": 0,
+	"RESERVED":                       1,
+	"CHECKED_OUT":                    2,
+	"RETURNED":                       3,
+	"CANCELLED":                      4,
+}
+
+func (x ReservationStatus) String() string {
+	return proto.EnumName(ReservationStatus_name, int32(x))
+}
+
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{2}
+}
+
+type SeriesMode int32
+
+const (
+	// Reserve none of the occurrences unless every one is free
+	SeriesMode_ALL_OR_NOTHING SeriesMode = 0
+	// Reserve the occurrences that are free, as long as there is one
+	SeriesMode_BEST_EFFORT SeriesMode = 1
+)
+
+var SeriesMode_name = map[int32]string{
+	0: "ALL_OR_NOTHING",
+	1: "BEST_EFFORT",
+}
+
+var SeriesMode_value = map[string]int32{
+	"ALL_OR_NOTHING": 0,
+	"BEST_EFFORT":    1,
+}
+
+func (x SeriesMode) String() string {
+	return proto.EnumName(SeriesMode_name, int32(x))
+}
+
+func (SeriesMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{3}
+}
+
+type ReservationOrder int32
+
+const (
+	ReservationOrder_START_ASC    ReservationOrder = 0
+	ReservationOrder_START_DESC   ReservationOrder = 1
+	ReservationOrder_CREATED_ASC  ReservationOrder = 2
+	ReservationOrder_CREATED_DESC ReservationOrder = 3
+)
+
+var ReservationOrder_name = map[int32]string{
+	0: "START_ASC",
+	1: "START_DESC",
+	2: "CREATED_ASC",
+	3: "CREATED_DESC",
+}
+
+var ReservationOrder_value = map[string]int32{
+	"START_ASC":    0,
+	"START_DESC":   1,
+	"CREATED_ASC":  2,
+	"CREATED_DESC": 3,
+}
+
+func (x ReservationOrder) String() string {
+	return proto.EnumName(ReservationOrder_name, int32(x))
+}
+
+func (ReservationOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{4}
+}
+
+// SearchOrder sorts search results. Ties are broken by ISBN and then library,
+// and ties in price by distance first.
+type SearchOrder int32
+
+const (
+	SearchOrder_DISTANCE     SearchOrder = 0
+	SearchOrder_PRICE        SearchOrder = 1
+	SearchOrder_LIBRARY_NAME SearchOrder = 2
+)
+
+var SearchOrder_name = map[int32]string{
+	0: "DISTANCE",
+	1: "PRICE",
+	2: "LIBRARY_NAME",
+}
+
+var SearchOrder_value = map[string]int32{
+	"DISTANCE":     0,
+	"PRICE":        1,
+	"LIBRARY_NAME": 2,
+}
+
+func (x SearchOrder) String() string {
+	return proto.EnumName(SearchOrder_name, int32(x))
+}
+
+func (SearchOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{5}
+}
+
+type HoldStatus int32
+
+const (
+	HoldStatus_HOLD_STATUS_UNSPECIFIED HoldStatus = 0
+	HoldStatus_WAITING                 HoldStatus = 1
+	HoldStatus_FULFILLED               HoldStatus = 2
+	HoldStatus_HOLD_CANCELLED          HoldStatus = 3
+)
+
+var HoldStatus_name = map[int32]string{
+	0: "HOLD_STATUS_UNSPECIFIED",
+	1: "WAITING",
+	2: "FULFILLED",
+	3: "HOLD_CANCELLED",
+}
+
+var HoldStatus_value = map[string]int32{
+	"HOLD_STATUS_UNSPECIFIED": 0,
+	"WAITING":                 1,
+	"FULFILLED":               2,
+	"HOLD_CANCELLED":          3,
+}
+
+func (x HoldStatus) String() string {
+	return proto.EnumName(HoldStatus_name, int32(x))
+}
+
+func (HoldStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{6}
+}
+
+type DeliveryStatus int32
+
+const (
+	DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED DeliveryStatus = 0
+	DeliveryStatus_PENDING                     DeliveryStatus = 1
+	DeliveryStatus_DELIVERED                   DeliveryStatus = 2
+	DeliveryStatus_FAILED                      DeliveryStatus = 3
+)
+
+var DeliveryStatus_name = map[int32]string{
+	0: "DELIVERY_STATUS_UNSPECIFIED",
+	1: "PENDING",
+	2: "DELIVERED",
+	3: "FAILED",
+}
+
+var DeliveryStatus_value = map[string]int32{
+	"DELIVERY_STATUS_UNSPECIFIED": 0,
+	"PENDING":                     1,
+	"DELIVERED":                   2,
+	"FAILED":                      3,
+}
+
+func (x DeliveryStatus) String() string {
+	return proto.EnumName(DeliveryStatus_name, int32(x))
+}
+
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{7}
+}
+
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -56,842 +311,6590 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
-// Add not null constraints?
-type Book struct {
-	Isbn    string  `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
-	Lat     float32 `protobuf:"fixed32,2,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lng     float32 `protobuf:"fixed32,3,opt,name=lng,proto3" json:"lng,omitempty"`
-	Library string  `protobuf:"bytes,4,opt,name=library,proto3" json:"library,omitempty"`
-	// ISO 4217
-	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+type Library struct {
+	Id      int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address string  `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Lat     float32 `protobuf:"fixed32,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng     float32 `protobuf:"fixed32,5,opt,name=lng,proto3" json:"lng,omitempty"`
+	// IANA time zone, e.g. America/Los_Angeles. Defaults to UTC
+	Timezone string `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Email    string `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Phone    string `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	// ISO8601 format
+	CreatedAt     string         `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	LateFeePolicy *LateFeePolicy `protobuf:"bytes,10,opt,name=lateFeePolicy,proto3" json:"lateFeePolicy,omitempty"`
+	// How many times a loan can be renewed, 0 for no limit
+	MaxRenewals int32 `protobuf:"varint,11,opt,name=maxRenewals,proto3" json:"maxRenewals,omitempty"`
+	// How many days a renewed loan can last from the start of its reservation, 0 for no limit
+	MaxLoanDays          int32    `protobuf:"varint,12,opt,name=maxLoanDays,proto3" json:"maxLoanDays,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Book) Reset()         { *m = Book{} }
-func (m *Book) String() string { return proto.CompactTextString(m) }
-func (*Book) ProtoMessage()    {}
-func (*Book) Descriptor() ([]byte, []int) {
+func (m *Library) Reset()         { *m = Library{} }
+func (m *Library) String() string { return proto.CompactTextString(m) }
+func (*Library) ProtoMessage()    {}
+func (*Library) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{1}
 }
 
-func (m *Book) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Book.Unmarshal(m, b)
+func (m *Library) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Library.Unmarshal(m, b)
 }
-func (m *Book) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Book.Marshal(b, m, deterministic)
+func (m *Library) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Library.Marshal(b, m, deterministic)
 }
-func (m *Book) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Book.Merge(m, src)
+func (m *Library) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Library.Merge(m, src)
 }
-func (m *Book) XXX_Size() int {
-	return xxx_messageInfo_Book.Size(m)
+func (m *Library) XXX_Size() int {
+	return xxx_messageInfo_Library.Size(m)
 }
-func (m *Book) XXX_DiscardUnknown() {
-	xxx_messageInfo_Book.DiscardUnknown(m)
+func (m *Library) XXX_DiscardUnknown() {
+	xxx_messageInfo_Library.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Book proto.InternalMessageInfo
+var xxx_messageInfo_Library proto.InternalMessageInfo
 
-func (m *Book) GetIsbn() string {
+func (m *Library) GetId() int64 {
 	if m != nil {
-		return m.Isbn
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Library) GetName() string {
+	if m != nil {
+		return m.Name
 	}
 	return ""
 }
 
-func (m *Book) GetLat() float32 {
+func (m *Library) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Library) GetLat() float32 {
 	if m != nil {
 		return m.Lat
 	}
 	return 0
 }
 
-func (m *Book) GetLng() float32 {
+func (m *Library) GetLng() float32 {
 	if m != nil {
 		return m.Lng
 	}
 	return 0
 }
 
-func (m *Book) GetLibrary() string {
+func (m *Library) GetTimezone() string {
 	if m != nil {
-		return m.Library
+		return m.Timezone
 	}
 	return ""
 }
 
-func (m *Book) GetPrice() float32 {
+func (m *Library) GetEmail() string {
 	if m != nil {
-		return m.Price
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Library) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *Library) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Library) GetLateFeePolicy() *LateFeePolicy {
+	if m != nil {
+		return m.LateFeePolicy
+	}
+	return nil
+}
+
+func (m *Library) GetMaxRenewals() int32 {
+	if m != nil {
+		return m.MaxRenewals
 	}
 	return 0
 }
 
-type GetAllBooksRes struct {
-	Books                []*Book  `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+func (m *Library) GetMaxLoanDays() int32 {
+	if m != nil {
+		return m.MaxLoanDays
+	}
+	return 0
+}
+
+// LateFeePolicy is what a library charges for each day a book is returned late.
+// Every started day counts, and no fee is charged when every field is 0.
+type LateFeePolicy struct {
+	// A flat fee per day
+	PerDay float32 `protobuf:"fixed32,1,opt,name=perDay,proto3" json:"perDay,omitempty"`
+	// A percentage of the book's price per day
+	PercentPerDay float32 `protobuf:"fixed32,2,opt,name=percentPerDay,proto3" json:"percentPerDay,omitempty"`
+	// Caps the total fee, 0 for no cap
+	Max                  float32  `protobuf:"fixed32,3,opt,name=max,proto3" json:"max,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetAllBooksRes) Reset()         { *m = GetAllBooksRes{} }
-func (m *GetAllBooksRes) String() string { return proto.CompactTextString(m) }
-func (*GetAllBooksRes) ProtoMessage()    {}
-func (*GetAllBooksRes) Descriptor() ([]byte, []int) {
+func (m *LateFeePolicy) Reset()         { *m = LateFeePolicy{} }
+func (m *LateFeePolicy) String() string { return proto.CompactTextString(m) }
+func (*LateFeePolicy) ProtoMessage()    {}
+func (*LateFeePolicy) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{2}
 }
 
-func (m *GetAllBooksRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAllBooksRes.Unmarshal(m, b)
+func (m *LateFeePolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LateFeePolicy.Unmarshal(m, b)
 }
-func (m *GetAllBooksRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAllBooksRes.Marshal(b, m, deterministic)
+func (m *LateFeePolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LateFeePolicy.Marshal(b, m, deterministic)
 }
-func (m *GetAllBooksRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAllBooksRes.Merge(m, src)
+func (m *LateFeePolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LateFeePolicy.Merge(m, src)
 }
-func (m *GetAllBooksRes) XXX_Size() int {
-	return xxx_messageInfo_GetAllBooksRes.Size(m)
+func (m *LateFeePolicy) XXX_Size() int {
+	return xxx_messageInfo_LateFeePolicy.Size(m)
 }
-func (m *GetAllBooksRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAllBooksRes.DiscardUnknown(m)
+func (m *LateFeePolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_LateFeePolicy.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAllBooksRes proto.InternalMessageInfo
+var xxx_messageInfo_LateFeePolicy proto.InternalMessageInfo
 
-func (m *GetAllBooksRes) GetBooks() []*Book {
+func (m *LateFeePolicy) GetPerDay() float32 {
 	if m != nil {
-		return m.Books
+		return m.PerDay
 	}
-	return nil
+	return 0
 }
 
-type GetBookReq struct {
-	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+func (m *LateFeePolicy) GetPercentPerDay() float32 {
+	if m != nil {
+		return m.PercentPerDay
+	}
+	return 0
+}
+
+func (m *LateFeePolicy) GetMax() float32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type CreateLibraryReq struct {
+	Library              *Library `protobuf:"bytes,1,opt,name=library,proto3" json:"library,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetBookReq) Reset()         { *m = GetBookReq{} }
-func (m *GetBookReq) String() string { return proto.CompactTextString(m) }
-func (*GetBookReq) ProtoMessage()    {}
-func (*GetBookReq) Descriptor() ([]byte, []int) {
+func (m *CreateLibraryReq) Reset()         { *m = CreateLibraryReq{} }
+func (m *CreateLibraryReq) String() string { return proto.CompactTextString(m) }
+func (*CreateLibraryReq) ProtoMessage()    {}
+func (*CreateLibraryReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{3}
 }
 
-func (m *GetBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBookReq.Unmarshal(m, b)
+func (m *CreateLibraryReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateLibraryReq.Unmarshal(m, b)
 }
-func (m *GetBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBookReq.Marshal(b, m, deterministic)
+func (m *CreateLibraryReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateLibraryReq.Marshal(b, m, deterministic)
 }
-func (m *GetBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBookReq.Merge(m, src)
+func (m *CreateLibraryReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateLibraryReq.Merge(m, src)
 }
-func (m *GetBookReq) XXX_Size() int {
-	return xxx_messageInfo_GetBookReq.Size(m)
+func (m *CreateLibraryReq) XXX_Size() int {
+	return xxx_messageInfo_CreateLibraryReq.Size(m)
 }
-func (m *GetBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBookReq.DiscardUnknown(m)
+func (m *CreateLibraryReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateLibraryReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBookReq proto.InternalMessageInfo
+var xxx_messageInfo_CreateLibraryReq proto.InternalMessageInfo
 
-func (m *GetBookReq) GetIsbn() string {
+func (m *CreateLibraryReq) GetLibrary() *Library {
 	if m != nil {
-		return m.Isbn
+		return m.Library
 	}
-	return ""
+	return nil
 }
 
-type ReturnBookReq struct {
-	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+type GetLibraryReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReturnBookReq) Reset()         { *m = ReturnBookReq{} }
-func (m *ReturnBookReq) String() string { return proto.CompactTextString(m) }
-func (*ReturnBookReq) ProtoMessage()    {}
-func (*ReturnBookReq) Descriptor() ([]byte, []int) {
+func (m *GetLibraryReq) Reset()         { *m = GetLibraryReq{} }
+func (m *GetLibraryReq) String() string { return proto.CompactTextString(m) }
+func (*GetLibraryReq) ProtoMessage()    {}
+func (*GetLibraryReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{4}
 }
 
-func (m *ReturnBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReturnBookReq.Unmarshal(m, b)
+func (m *GetLibraryReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLibraryReq.Unmarshal(m, b)
 }
-func (m *ReturnBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReturnBookReq.Marshal(b, m, deterministic)
+func (m *GetLibraryReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLibraryReq.Marshal(b, m, deterministic)
 }
-func (m *ReturnBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReturnBookReq.Merge(m, src)
+func (m *GetLibraryReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLibraryReq.Merge(m, src)
 }
-func (m *ReturnBookReq) XXX_Size() int {
-	return xxx_messageInfo_ReturnBookReq.Size(m)
+func (m *GetLibraryReq) XXX_Size() int {
+	return xxx_messageInfo_GetLibraryReq.Size(m)
 }
-func (m *ReturnBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReturnBookReq.DiscardUnknown(m)
+func (m *GetLibraryReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLibraryReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReturnBookReq proto.InternalMessageInfo
+var xxx_messageInfo_GetLibraryReq proto.InternalMessageInfo
 
-func (m *ReturnBookReq) GetIsbn() string {
+func (m *GetLibraryReq) GetId() int64 {
 	if m != nil {
-		return m.Isbn
+		return m.Id
 	}
-	return ""
+	return 0
 }
 
-type AddBookReq struct {
-	Book                 *Book    `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ListLibrariesRes struct {
+	Libraries            []*Library `protobuf:"bytes,1,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *AddBookReq) Reset()         { *m = AddBookReq{} }
-func (m *AddBookReq) String() string { return proto.CompactTextString(m) }
-func (*AddBookReq) ProtoMessage()    {}
-func (*AddBookReq) Descriptor() ([]byte, []int) {
+func (m *ListLibrariesRes) Reset()         { *m = ListLibrariesRes{} }
+func (m *ListLibrariesRes) String() string { return proto.CompactTextString(m) }
+func (*ListLibrariesRes) ProtoMessage()    {}
+func (*ListLibrariesRes) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{5}
 }
 
-func (m *AddBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddBookReq.Unmarshal(m, b)
+func (m *ListLibrariesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLibrariesRes.Unmarshal(m, b)
 }
-func (m *AddBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddBookReq.Marshal(b, m, deterministic)
+func (m *ListLibrariesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLibrariesRes.Marshal(b, m, deterministic)
 }
-func (m *AddBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddBookReq.Merge(m, src)
+func (m *ListLibrariesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLibrariesRes.Merge(m, src)
 }
-func (m *AddBookReq) XXX_Size() int {
-	return xxx_messageInfo_AddBookReq.Size(m)
+func (m *ListLibrariesRes) XXX_Size() int {
+	return xxx_messageInfo_ListLibrariesRes.Size(m)
 }
-func (m *AddBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddBookReq.DiscardUnknown(m)
+func (m *ListLibrariesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLibrariesRes.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddBookReq proto.InternalMessageInfo
+var xxx_messageInfo_ListLibrariesRes proto.InternalMessageInfo
 
-func (m *AddBookReq) GetBook() *Book {
+func (m *ListLibrariesRes) GetLibraries() []*Library {
 	if m != nil {
-		return m.Book
+		return m.Libraries
 	}
 	return nil
 }
 
-type DeleteBookReq struct {
-	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+type UpdateLibraryReq struct {
+	Library              *Library `protobuf:"bytes,1,opt,name=library,proto3" json:"library,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteBookReq) Reset()         { *m = DeleteBookReq{} }
-func (m *DeleteBookReq) String() string { return proto.CompactTextString(m) }
-func (*DeleteBookReq) ProtoMessage()    {}
-func (*DeleteBookReq) Descriptor() ([]byte, []int) {
+func (m *UpdateLibraryReq) Reset()         { *m = UpdateLibraryReq{} }
+func (m *UpdateLibraryReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateLibraryReq) ProtoMessage()    {}
+func (*UpdateLibraryReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{6}
 }
 
-func (m *DeleteBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteBookReq.Unmarshal(m, b)
+func (m *UpdateLibraryReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateLibraryReq.Unmarshal(m, b)
 }
-func (m *DeleteBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteBookReq.Marshal(b, m, deterministic)
+func (m *UpdateLibraryReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateLibraryReq.Marshal(b, m, deterministic)
 }
-func (m *DeleteBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteBookReq.Merge(m, src)
+func (m *UpdateLibraryReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLibraryReq.Merge(m, src)
 }
-func (m *DeleteBookReq) XXX_Size() int {
-	return xxx_messageInfo_DeleteBookReq.Size(m)
+func (m *UpdateLibraryReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateLibraryReq.Size(m)
 }
-func (m *DeleteBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteBookReq.DiscardUnknown(m)
+func (m *UpdateLibraryReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLibraryReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteBookReq proto.InternalMessageInfo
+var xxx_messageInfo_UpdateLibraryReq proto.InternalMessageInfo
 
-func (m *DeleteBookReq) GetIsbn() string {
+func (m *UpdateLibraryReq) GetLibrary() *Library {
 	if m != nil {
-		return m.Isbn
+		return m.Library
 	}
-	return ""
+	return nil
 }
 
-type ReserveBookReq struct {
-	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
-	// Start and End times are ISO8601 format
-	StartDate            string   `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
-	EndDate              string   `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+type DeleteLibraryReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReserveBookReq) Reset()         { *m = ReserveBookReq{} }
-func (m *ReserveBookReq) String() string { return proto.CompactTextString(m) }
-func (*ReserveBookReq) ProtoMessage()    {}
-func (*ReserveBookReq) Descriptor() ([]byte, []int) {
+func (m *DeleteLibraryReq) Reset()         { *m = DeleteLibraryReq{} }
+func (m *DeleteLibraryReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteLibraryReq) ProtoMessage()    {}
+func (*DeleteLibraryReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{7}
 }
 
-func (m *ReserveBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReserveBookReq.Unmarshal(m, b)
+func (m *DeleteLibraryReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteLibraryReq.Unmarshal(m, b)
 }
-func (m *ReserveBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReserveBookReq.Marshal(b, m, deterministic)
-}
-func (m *ReserveBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReserveBookReq.Merge(m, src)
+func (m *DeleteLibraryReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteLibraryReq.Marshal(b, m, deterministic)
 }
-func (m *ReserveBookReq) XXX_Size() int {
-	return xxx_messageInfo_ReserveBookReq.Size(m)
+func (m *DeleteLibraryReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteLibraryReq.Merge(m, src)
 }
-func (m *ReserveBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReserveBookReq.DiscardUnknown(m)
+func (m *DeleteLibraryReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteLibraryReq.Size(m)
 }
-
-var xxx_messageInfo_ReserveBookReq proto.InternalMessageInfo
-
-func (m *ReserveBookReq) GetIsbn() string {
-	if m != nil {
-		return m.Isbn
-	}
-	return ""
+func (m *DeleteLibraryReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteLibraryReq.DiscardUnknown(m)
 }
 
-func (m *ReserveBookReq) GetStartDate() string {
-	if m != nil {
-		return m.StartDate
-	}
-	return ""
-}
+var xxx_messageInfo_DeleteLibraryReq proto.InternalMessageInfo
 
-func (m *ReserveBookReq) GetEndDate() string {
+func (m *DeleteLibraryReq) GetId() int64 {
 	if m != nil {
-		return m.EndDate
+		return m.Id
 	}
-	return ""
+	return 0
 }
 
-type CheckoutBookReq struct {
-	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
-	// Start and End times are ISO8601 format
-	StartDate            string   `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
-	EndDate              string   `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+// OpeningPeriod is a period a library is open every week
+type OpeningPeriod struct {
+	Weekday Weekday `protobuf:"varint,1,opt,name=weekday,proto3,enum=reservations.Weekday" json:"weekday,omitempty"`
+	// Times of day in the library's time zone, HH:MM format. closes may be 24:00
+	Opens                string   `protobuf:"bytes,2,opt,name=opens,proto3" json:"opens,omitempty"`
+	Closes               string   `protobuf:"bytes,3,opt,name=closes,proto3" json:"closes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckoutBookReq) Reset()         { *m = CheckoutBookReq{} }
-func (m *CheckoutBookReq) String() string { return proto.CompactTextString(m) }
-func (*CheckoutBookReq) ProtoMessage()    {}
-func (*CheckoutBookReq) Descriptor() ([]byte, []int) {
+func (m *OpeningPeriod) Reset()         { *m = OpeningPeriod{} }
+func (m *OpeningPeriod) String() string { return proto.CompactTextString(m) }
+func (*OpeningPeriod) ProtoMessage()    {}
+func (*OpeningPeriod) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{8}
 }
 
-func (m *CheckoutBookReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckoutBookReq.Unmarshal(m, b)
+func (m *OpeningPeriod) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpeningPeriod.Unmarshal(m, b)
 }
-func (m *CheckoutBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckoutBookReq.Marshal(b, m, deterministic)
+func (m *OpeningPeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpeningPeriod.Marshal(b, m, deterministic)
 }
-func (m *CheckoutBookReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckoutBookReq.Merge(m, src)
+func (m *OpeningPeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpeningPeriod.Merge(m, src)
 }
-func (m *CheckoutBookReq) XXX_Size() int {
-	return xxx_messageInfo_CheckoutBookReq.Size(m)
+func (m *OpeningPeriod) XXX_Size() int {
+	return xxx_messageInfo_OpeningPeriod.Size(m)
 }
-func (m *CheckoutBookReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckoutBookReq.DiscardUnknown(m)
+func (m *OpeningPeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpeningPeriod.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckoutBookReq proto.InternalMessageInfo
+var xxx_messageInfo_OpeningPeriod proto.InternalMessageInfo
 
-func (m *CheckoutBookReq) GetIsbn() string {
+func (m *OpeningPeriod) GetWeekday() Weekday {
 	if m != nil {
-		return m.Isbn
+		return m.Weekday
 	}
-	return ""
+	return Weekday_SUNDAY
 }
 
-func (m *CheckoutBookReq) GetStartDate() string {
+func (m *OpeningPeriod) GetOpens() string {
 	if m != nil {
-		return m.StartDate
+		return m.Opens
 	}
 	return ""
 }
 
-func (m *CheckoutBookReq) GetEndDate() string {
+func (m *OpeningPeriod) GetCloses() string {
 	if m != nil {
-		return m.EndDate
+		return m.Closes
 	}
 	return ""
 }
 
-type SearchReq struct {
-	Lat   float32 `protobuf:"fixed32,1,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lng   float32 `protobuf:"fixed32,2,opt,name=lng,proto3" json:"lng,omitempty"`
-	Range float32 `protobuf:"fixed32,3,opt,name=range,proto3" json:"range,omitempty"`
-	// Start and End times are ISO8601 format
-	StartDate            string   `protobuf:"bytes,4,opt,name=startDate,proto3" json:"startDate,omitempty"`
-	EndDate              string   `protobuf:"bytes,5,opt,name=endDate,proto3" json:"endDate,omitempty"`
+// HoursException replaces a library's weekly opening hours on a date
+type HoursException struct {
+	Id        int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LibraryId int64 `protobuf:"varint,2,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// YYYY-MM-DD format, in the library's time zone
+	Date string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// HH:MM format. Both are empty if the library is closed all day
+	Opens                string   `protobuf:"bytes,4,opt,name=opens,proto3" json:"opens,omitempty"`
+	Closes               string   `protobuf:"bytes,5,opt,name=closes,proto3" json:"closes,omitempty"`
+	Reason               string   `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SearchReq) Reset()         { *m = SearchReq{} }
-func (m *SearchReq) String() string { return proto.CompactTextString(m) }
-func (*SearchReq) ProtoMessage()    {}
-func (*SearchReq) Descriptor() ([]byte, []int) {
+func (m *HoursException) Reset()         { *m = HoursException{} }
+func (m *HoursException) String() string { return proto.CompactTextString(m) }
+func (*HoursException) ProtoMessage()    {}
+func (*HoursException) Descriptor() ([]byte, []int) {
 	return fileDescriptor_25f40a216b443982, []int{9}
 }
 
-func (m *SearchReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchReq.Unmarshal(m, b)
+func (m *HoursException) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoursException.Unmarshal(m, b)
 }
-func (m *SearchReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchReq.Marshal(b, m, deterministic)
+func (m *HoursException) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoursException.Marshal(b, m, deterministic)
 }
-func (m *SearchReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchReq.Merge(m, src)
+func (m *HoursException) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoursException.Merge(m, src)
 }
-func (m *SearchReq) XXX_Size() int {
-	return xxx_messageInfo_SearchReq.Size(m)
+func (m *HoursException) XXX_Size() int {
+	return xxx_messageInfo_HoursException.Size(m)
 }
-func (m *SearchReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchReq.DiscardUnknown(m)
+func (m *HoursException) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoursException.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchReq proto.InternalMessageInfo
+var xxx_messageInfo_HoursException proto.InternalMessageInfo
 
-func (m *SearchReq) GetLat() float32 {
+func (m *HoursException) GetId() int64 {
 	if m != nil {
-		return m.Lat
+		return m.Id
 	}
 	return 0
 }
 
-func (m *SearchReq) GetLng() float32 {
+func (m *HoursException) GetLibraryId() int64 {
 	if m != nil {
-		return m.Lng
+		return m.LibraryId
 	}
 	return 0
 }
 
-func (m *SearchReq) GetRange() float32 {
+func (m *HoursException) GetDate() string {
 	if m != nil {
-		return m.Range
+		return m.Date
 	}
-	return 0
+	return ""
 }
 
-func (m *SearchReq) GetStartDate() string {
+func (m *HoursException) GetOpens() string {
 	if m != nil {
-		return m.StartDate
+		return m.Opens
 	}
 	return ""
 }
 
-func (m *SearchReq) GetEndDate() string {
+func (m *HoursException) GetCloses() string {
 	if m != nil {
-		return m.EndDate
+		return m.Closes
 	}
 	return ""
 }
 
-type SearchRes struct {
-	Books                []*Book  `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+func (m *HoursException) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type OpeningHours struct {
+	LibraryId int64 `protobuf:"varint,1,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// The time zone the hours are in
+	Timezone string           `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Weekly   []*OpeningPeriod `protobuf:"bytes,3,rep,name=weekly,proto3" json:"weekly,omitempty"`
+	// The exceptions from today on, ordered by date
+	Exceptions           []*HoursException `protobuf:"bytes,4,rep,name=exceptions,proto3" json:"exceptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OpeningHours) Reset()         { *m = OpeningHours{} }
+func (m *OpeningHours) String() string { return proto.CompactTextString(m) }
+func (*OpeningHours) ProtoMessage()    {}
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{10}
+}
+
+func (m *OpeningHours) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpeningHours.Unmarshal(m, b)
+}
+func (m *OpeningHours) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpeningHours.Marshal(b, m, deterministic)
+}
+func (m *OpeningHours) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpeningHours.Merge(m, src)
+}
+func (m *OpeningHours) XXX_Size() int {
+	return xxx_messageInfo_OpeningHours.Size(m)
+}
+func (m *OpeningHours) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpeningHours.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpeningHours proto.InternalMessageInfo
+
+func (m *OpeningHours) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *OpeningHours) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *OpeningHours) GetWeekly() []*OpeningPeriod {
+	if m != nil {
+		return m.Weekly
+	}
+	return nil
+}
+
+func (m *OpeningHours) GetExceptions() []*HoursException {
+	if m != nil {
+		return m.Exceptions
+	}
+	return nil
+}
+
+type GetOpeningHoursReq struct {
+	LibraryId            int64    `protobuf:"varint,1,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SearchRes) Reset()         { *m = SearchRes{} }
-func (m *SearchRes) String() string { return proto.CompactTextString(m) }
-func (*SearchRes) ProtoMessage()    {}
-func (*SearchRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25f40a216b443982, []int{10}
+func (m *GetOpeningHoursReq) Reset()         { *m = GetOpeningHoursReq{} }
+func (m *GetOpeningHoursReq) String() string { return proto.CompactTextString(m) }
+func (*GetOpeningHoursReq) ProtoMessage()    {}
+func (*GetOpeningHoursReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{11}
 }
 
-func (m *SearchRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchRes.Unmarshal(m, b)
+func (m *GetOpeningHoursReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOpeningHoursReq.Unmarshal(m, b)
 }
-func (m *SearchRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchRes.Marshal(b, m, deterministic)
+func (m *GetOpeningHoursReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOpeningHoursReq.Marshal(b, m, deterministic)
 }
-func (m *SearchRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchRes.Merge(m, src)
+func (m *GetOpeningHoursReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOpeningHoursReq.Merge(m, src)
 }
-func (m *SearchRes) XXX_Size() int {
-	return xxx_messageInfo_SearchRes.Size(m)
+func (m *GetOpeningHoursReq) XXX_Size() int {
+	return xxx_messageInfo_GetOpeningHoursReq.Size(m)
 }
-func (m *SearchRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchRes.DiscardUnknown(m)
+func (m *GetOpeningHoursReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOpeningHoursReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchRes proto.InternalMessageInfo
+var xxx_messageInfo_GetOpeningHoursReq proto.InternalMessageInfo
 
-func (m *SearchRes) GetBooks() []*Book {
+func (m *GetOpeningHoursReq) GetLibraryId() int64 {
 	if m != nil {
-		return m.Books
+		return m.LibraryId
+	}
+	return 0
+}
+
+type SetOpeningHoursReq struct {
+	LibraryId            int64            `protobuf:"varint,1,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	Weekly               []*OpeningPeriod `protobuf:"bytes,2,rep,name=weekly,proto3" json:"weekly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SetOpeningHoursReq) Reset()         { *m = SetOpeningHoursReq{} }
+func (m *SetOpeningHoursReq) String() string { return proto.CompactTextString(m) }
+func (*SetOpeningHoursReq) ProtoMessage()    {}
+func (*SetOpeningHoursReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{12}
+}
+
+func (m *SetOpeningHoursReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOpeningHoursReq.Unmarshal(m, b)
+}
+func (m *SetOpeningHoursReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOpeningHoursReq.Marshal(b, m, deterministic)
+}
+func (m *SetOpeningHoursReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOpeningHoursReq.Merge(m, src)
+}
+func (m *SetOpeningHoursReq) XXX_Size() int {
+	return xxx_messageInfo_SetOpeningHoursReq.Size(m)
+}
+func (m *SetOpeningHoursReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOpeningHoursReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOpeningHoursReq proto.InternalMessageInfo
+
+func (m *SetOpeningHoursReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *SetOpeningHoursReq) GetWeekly() []*OpeningPeriod {
+	if m != nil {
+		return m.Weekly
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "reservations.Empty")
-	proto.RegisterType((*Book)(nil), "reservations.Book")
-	proto.RegisterType((*GetAllBooksRes)(nil), "reservations.GetAllBooksRes")
-	proto.RegisterType((*GetBookReq)(nil), "reservations.GetBookReq")
-	proto.RegisterType((*ReturnBookReq)(nil), "reservations.ReturnBookReq")
-	proto.RegisterType((*AddBookReq)(nil), "reservations.AddBookReq")
-	proto.RegisterType((*DeleteBookReq)(nil), "reservations.DeleteBookReq")
-	proto.RegisterType((*ReserveBookReq)(nil), "reservations.ReserveBookReq")
-	proto.RegisterType((*CheckoutBookReq)(nil), "reservations.CheckoutBookReq")
-	proto.RegisterType((*SearchReq)(nil), "reservations.SearchReq")
-	proto.RegisterType((*SearchRes)(nil), "reservations.SearchRes")
+type AddHoursExceptionReq struct {
+	Exception            *HoursException `protobuf:"bytes,1,opt,name=exception,proto3" json:"exception,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("protobufs/reservations.proto", fileDescriptor_25f40a216b443982)
+func (m *AddHoursExceptionReq) Reset()         { *m = AddHoursExceptionReq{} }
+func (m *AddHoursExceptionReq) String() string { return proto.CompactTextString(m) }
+func (*AddHoursExceptionReq) ProtoMessage()    {}
+func (*AddHoursExceptionReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{13}
 }
 
-var fileDescriptor_25f40a216b443982 = []byte{
-	// 565 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0xe5, 0x24, 0x6e, 0x94, 0x49, 0x7e, 0xf9, 0x95, 0x21, 0xa8, 0x8b, 0x9b, 0xa2, 0x68,
-	0x8b, 0x50, 0x94, 0x43, 0x2d, 0x0a, 0x5c, 0x72, 0x2b, 0x14, 0xf5, 0x56, 0x09, 0xf7, 0x08, 0x1c,
-	0xec, 0x64, 0x49, 0xa2, 0x9a, 0x75, 0x58, 0x6f, 0x2a, 0x55, 0xa8, 0x17, 0x5e, 0x81, 0x47, 0xe3,
-	0x15, 0x90, 0x78, 0x0d, 0xb4, 0x63, 0xa7, 0xce, 0x3a, 0x26, 0x12, 0x07, 0x6e, 0xbb, 0xb3, 0x33,
-	0x9f, 0xef, 0xce, 0x9f, 0x5d, 0xe8, 0x2f, 0x55, 0xa2, 0x93, 0x68, 0xf5, 0x29, 0xf5, 0x95, 0x48,
-	0x85, 0xba, 0x09, 0xf5, 0x22, 0x91, 0xe9, 0x09, 0x99, 0xb1, 0xb3, 0x69, 0xf3, 0xfa, 0xb3, 0x24,
-	0x99, 0xc5, 0xc2, 0x0f, 0x97, 0x0b, 0x3f, 0x94, 0x32, 0xd1, 0x9b, 0xbe, 0xbc, 0x09, 0xee, 0xdb,
-	0xcf, 0x4b, 0x7d, 0xcb, 0x25, 0x34, 0x5e, 0x27, 0xc9, 0x35, 0x22, 0x34, 0x16, 0x69, 0x24, 0x99,
-	0x33, 0x70, 0x86, 0xad, 0x80, 0xd6, 0xb8, 0x0f, 0xf5, 0x38, 0xd4, 0xac, 0x36, 0x70, 0x86, 0xb5,
-	0xc0, 0x2c, 0xc9, 0x22, 0x67, 0xac, 0x9e, 0x5b, 0xe4, 0x0c, 0x19, 0x34, 0xe3, 0x45, 0xa4, 0x42,
-	0x75, 0xcb, 0x1a, 0x14, 0xba, 0xde, 0x62, 0x0f, 0xdc, 0xa5, 0x5a, 0x4c, 0x04, 0x73, 0xc9, 0x3b,
-	0xdb, 0xf0, 0x31, 0x74, 0x2f, 0x84, 0x3e, 0x8b, 0x63, 0xa3, 0x9a, 0x06, 0x22, 0xc5, 0x21, 0xb8,
-	0x91, 0x59, 0x33, 0x67, 0x50, 0x1f, 0xb6, 0x4f, 0xf1, 0xc4, 0x4a, 0xcd, 0xb8, 0x05, 0x99, 0x03,
-	0x1f, 0x00, 0x5c, 0x08, 0x4d, 0x16, 0xf1, 0xa5, 0xea, 0xc6, 0xfc, 0x18, 0xfe, 0x0b, 0x84, 0x5e,
-	0x29, 0xb9, 0xcb, 0xe9, 0x25, 0xc0, 0xd9, 0x74, 0xba, 0xf6, 0x78, 0x06, 0x0d, 0x43, 0x27, 0x8f,
-	0x6a, 0x75, 0x3a, 0x37, 0xe8, 0x73, 0x11, 0x0b, 0x2d, 0x76, 0xa1, 0x3f, 0x40, 0x37, 0xa0, 0xf8,
-	0x5d, 0x5e, 0xd8, 0x87, 0x56, 0xaa, 0x43, 0xa5, 0xcf, 0x43, 0x2d, 0xa8, 0xba, 0xad, 0xa0, 0x30,
-	0x98, 0x8a, 0x0a, 0x39, 0xa5, 0xb3, 0x7a, 0x56, 0xd1, 0x7c, 0xcb, 0x3f, 0xc2, 0xff, 0x6f, 0xe6,
-	0x62, 0x72, 0x9d, 0xac, 0xf4, 0xbf, 0xc0, 0xdf, 0x41, 0xeb, 0x4a, 0x84, 0x6a, 0x32, 0x37, 0xe0,
-	0xbc, 0xf7, 0xce, 0x56, 0xef, 0x6b, 0x45, 0xef, 0x7b, 0xe0, 0xaa, 0x50, 0xce, 0x44, 0x3e, 0x0f,
-	0xd9, 0xc6, 0x96, 0x6f, 0xec, 0x90, 0x77, 0x6d, 0xf9, 0x57, 0x85, 0xfc, 0x5f, 0x0c, 0xc5, 0xe9,
-	0x2f, 0x17, 0xda, 0x41, 0x71, 0x88, 0x57, 0xd0, 0xde, 0x18, 0x30, 0x7c, 0x68, 0x47, 0xd2, 0xd0,
-	0x7b, 0x7d, 0xdb, 0x68, 0x0f, 0x24, 0x7f, 0xf0, 0xed, 0xc7, 0xcf, 0xef, 0xb5, 0x36, 0xb6, 0xfc,
-	0x9b, 0xe7, 0x3e, 0x89, 0xe0, 0x3b, 0x68, 0xe6, 0x93, 0x87, 0x6c, 0x2b, 0x36, 0xef, 0x85, 0x57,
-	0x71, 0x49, 0xce, 0x88, 0x85, 0xb8, 0x7f, 0xcf, 0xf2, 0xbf, 0x9a, 0x26, 0xdd, 0xe1, 0x25, 0xec,
-	0x65, 0xe9, 0xe2, 0x81, 0x1d, 0x77, 0xdf, 0x03, 0xef, 0x0f, 0x07, 0x29, 0x47, 0xa2, 0x76, 0x10,
-	0x0c, 0x35, 0xcd, 0x28, 0x97, 0xd0, 0xcc, 0xa7, 0xba, 0x7c, 0xc5, 0x62, 0xd8, 0xbd, 0xaa, 0x6a,
-	0xf0, 0x1e, 0xd1, 0xba, 0xbc, 0xc8, 0x77, 0xec, 0x8c, 0xf0, 0x3d, 0x40, 0x31, 0xef, 0x78, 0x68,
-	0x07, 0x5a, 0x2f, 0xa1, 0x9a, 0x7a, 0x48, 0xd4, 0x47, 0xa3, 0xad, 0xcc, 0x0d, 0x5c, 0xac, 0x7b,
-	0x96, 0xd1, 0x4b, 0xfd, 0xb0, 0x9f, 0x50, 0x35, 0xfe, 0x98, 0xf0, 0x47, 0x1e, 0x2b, 0xe3, 0xf3,
-	0x9f, 0x51, 0x18, 0x99, 0x39, 0x74, 0x36, 0x1f, 0x0c, 0x1e, 0xd9, 0xa4, 0xd2, 0x63, 0xaa, 0x16,
-	0x7a, 0x4a, 0x42, 0x4f, 0xf8, 0xe3, 0x2d, 0xa1, 0x49, 0x1e, 0x6e, 0x94, 0x22, 0x80, 0xe2, 0xe3,
-	0x29, 0x57, 0xcb, 0xfa, 0x92, 0xaa, 0x55, 0x38, 0xa9, 0xf4, 0xf9, 0x41, 0x45, 0x3a, 0x26, 0x78,
-	0xec, 0x8c, 0xa2, 0x3d, 0xfa, 0xba, 0x5f, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x22, 0x55,
-	0x48, 0x06, 0x06, 0x00, 0x00,
+func (m *AddHoursExceptionReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddHoursExceptionReq.Unmarshal(m, b)
+}
+func (m *AddHoursExceptionReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddHoursExceptionReq.Marshal(b, m, deterministic)
+}
+func (m *AddHoursExceptionReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddHoursExceptionReq.Merge(m, src)
+}
+func (m *AddHoursExceptionReq) XXX_Size() int {
+	return xxx_messageInfo_AddHoursExceptionReq.Size(m)
+}
+func (m *AddHoursExceptionReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddHoursExceptionReq.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_AddHoursExceptionReq proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *AddHoursExceptionReq) GetException() *HoursException {
+	if m != nil {
+		return m.Exception
+	}
+	return nil
+}
 
-// ReservationClient is the client API for Reservation service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ReservationClient interface {
-	GetAllBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetAllBooksRes, error)
-	GetBook(ctx context.Context, in *GetBookReq, opts ...grpc.CallOption) (*Book, error)
-	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error)
-	AddBook(ctx context.Context, in *AddBookReq, opts ...grpc.CallOption) (*Empty, error)
-	DeleteBook(ctx context.Context, in *DeleteBookReq, opts ...grpc.CallOption) (*Empty, error)
-	ReserveBook(ctx context.Context, in *ReserveBookReq, opts ...grpc.CallOption) (*Empty, error)
-	CheckoutBook(ctx context.Context, in *CheckoutBookReq, opts ...grpc.CallOption) (*Empty, error)
-	ReturnBook(ctx context.Context, in *ReturnBookReq, opts ...grpc.CallOption) (*Empty, error)
+type DeleteHoursExceptionReq struct {
+	LibraryId            int64    `protobuf:"varint,1,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type reservationClient struct {
-	cc grpc.ClientConnInterface
+func (m *DeleteHoursExceptionReq) Reset()         { *m = DeleteHoursExceptionReq{} }
+func (m *DeleteHoursExceptionReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteHoursExceptionReq) ProtoMessage()    {}
+func (*DeleteHoursExceptionReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{14}
 }
 
-func NewReservationClient(cc grpc.ClientConnInterface) ReservationClient {
-	return &reservationClient{cc}
+func (m *DeleteHoursExceptionReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteHoursExceptionReq.Unmarshal(m, b)
+}
+func (m *DeleteHoursExceptionReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteHoursExceptionReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteHoursExceptionReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteHoursExceptionReq.Merge(m, src)
+}
+func (m *DeleteHoursExceptionReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteHoursExceptionReq.Size(m)
+}
+func (m *DeleteHoursExceptionReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteHoursExceptionReq.DiscardUnknown(m)
 }
 
-func (c *reservationClient) GetAllBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetAllBooksRes, error) {
-	out := new(GetAllBooksRes)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetAllBooks", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeleteHoursExceptionReq proto.InternalMessageInfo
+
+func (m *DeleteHoursExceptionReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *DeleteHoursExceptionReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// Add not null constraints?
+type Book struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// The library of the book's first copy, and its name and coordinates. AddBook
+	// adds that copy when libraryId, or the name of an existing library, is set.
+	// Search returns the library the available copies are at.
+	Lat     float32 `protobuf:"fixed32,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng     float32 `protobuf:"fixed32,3,opt,name=lng,proto3" json:"lng,omitempty"`
+	Library string  `protobuf:"bytes,4,opt,name=library,proto3" json:"library,omitempty"`
+	// ISO 4217
+	Price float32 `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	// The number of copies free over the searched window, or right now if the search
+	// has no window, only set by Search
+	AvailableCopies int32 `protobuf:"varint,6,opt,name=availableCopies,proto3" json:"availableCopies,omitempty"`
+	LibraryId       int64 `protobuf:"varint,7,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// How far the library is from the searched point, only set by Search
+	DistanceMeters float32 `protobuf:"fixed32,8,opt,name=distanceMeters,proto3" json:"distanceMeters,omitempty"`
+	// The number of copies at the library, only set by Search
+	TotalCopies          int32    `protobuf:"varint,9,opt,name=totalCopies,proto3" json:"totalCopies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Book) Reset()         { *m = Book{} }
+func (m *Book) String() string { return proto.CompactTextString(m) }
+func (*Book) ProtoMessage()    {}
+func (*Book) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{15}
+}
+
+func (m *Book) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Book.Unmarshal(m, b)
+}
+func (m *Book) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Book.Marshal(b, m, deterministic)
+}
+func (m *Book) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Book.Merge(m, src)
+}
+func (m *Book) XXX_Size() int {
+	return xxx_messageInfo_Book.Size(m)
+}
+func (m *Book) XXX_DiscardUnknown() {
+	xxx_messageInfo_Book.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Book proto.InternalMessageInfo
+
+func (m *Book) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *Book) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Book) GetLng() float32 {
+	if m != nil {
+		return m.Lng
+	}
+	return 0
+}
+
+func (m *Book) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *Book) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Book) GetAvailableCopies() int32 {
+	if m != nil {
+		return m.AvailableCopies
+	}
+	return 0
+}
+
+func (m *Book) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *Book) GetDistanceMeters() float32 {
+	if m != nil {
+		return m.DistanceMeters
+	}
+	return 0
+}
+
+func (m *Book) GetTotalCopies() int32 {
+	if m != nil {
+		return m.TotalCopies
+	}
+	return 0
+}
+
+// Copy is a physical copy of a book
+type Copy struct {
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Isbn string `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// The name of the library, which AddCopy accepts in place of libraryId
+	Library string `protobuf:"bytes,3,opt,name=library,proto3" json:"library,omitempty"`
+	// Where the copy is kept within the library, e.g. a shelf
+	Location string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Barcode  string `protobuf:"bytes,5,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	// The coordinates of the library
+	Lat                  float32  `protobuf:"fixed32,6,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng                  float32  `protobuf:"fixed32,7,opt,name=lng,proto3" json:"lng,omitempty"`
+	LibraryId            int64    `protobuf:"varint,8,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Copy) Reset()         { *m = Copy{} }
+func (m *Copy) String() string { return proto.CompactTextString(m) }
+func (*Copy) ProtoMessage()    {}
+func (*Copy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{16}
+}
+
+func (m *Copy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Copy.Unmarshal(m, b)
+}
+func (m *Copy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Copy.Marshal(b, m, deterministic)
+}
+func (m *Copy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Copy.Merge(m, src)
+}
+func (m *Copy) XXX_Size() int {
+	return xxx_messageInfo_Copy.Size(m)
+}
+func (m *Copy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Copy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Copy proto.InternalMessageInfo
+
+func (m *Copy) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Copy) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *Copy) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *Copy) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Copy) GetBarcode() string {
+	if m != nil {
+		return m.Barcode
+	}
+	return ""
+}
+
+func (m *Copy) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Copy) GetLng() float32 {
+	if m != nil {
+		return m.Lng
+	}
+	return 0
+}
+
+func (m *Copy) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+type AddCopyReq struct {
+	Copy                 *Copy    `protobuf:"bytes,1,opt,name=copy,proto3" json:"copy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddCopyReq) Reset()         { *m = AddCopyReq{} }
+func (m *AddCopyReq) String() string { return proto.CompactTextString(m) }
+func (*AddCopyReq) ProtoMessage()    {}
+func (*AddCopyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{17}
+}
+
+func (m *AddCopyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddCopyReq.Unmarshal(m, b)
+}
+func (m *AddCopyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddCopyReq.Marshal(b, m, deterministic)
+}
+func (m *AddCopyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddCopyReq.Merge(m, src)
+}
+func (m *AddCopyReq) XXX_Size() int {
+	return xxx_messageInfo_AddCopyReq.Size(m)
+}
+func (m *AddCopyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddCopyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddCopyReq proto.InternalMessageInfo
+
+func (m *AddCopyReq) GetCopy() *Copy {
+	if m != nil {
+		return m.Copy
+	}
+	return nil
+}
+
+type ListCopiesReq struct {
+	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCopiesReq) Reset()         { *m = ListCopiesReq{} }
+func (m *ListCopiesReq) String() string { return proto.CompactTextString(m) }
+func (*ListCopiesReq) ProtoMessage()    {}
+func (*ListCopiesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{18}
+}
+
+func (m *ListCopiesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCopiesReq.Unmarshal(m, b)
+}
+func (m *ListCopiesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCopiesReq.Marshal(b, m, deterministic)
+}
+func (m *ListCopiesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCopiesReq.Merge(m, src)
+}
+func (m *ListCopiesReq) XXX_Size() int {
+	return xxx_messageInfo_ListCopiesReq.Size(m)
+}
+func (m *ListCopiesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCopiesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCopiesReq proto.InternalMessageInfo
+
+func (m *ListCopiesReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+type ListCopiesRes struct {
+	Copies               []*Copy  `protobuf:"bytes,1,rep,name=copies,proto3" json:"copies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCopiesRes) Reset()         { *m = ListCopiesRes{} }
+func (m *ListCopiesRes) String() string { return proto.CompactTextString(m) }
+func (*ListCopiesRes) ProtoMessage()    {}
+func (*ListCopiesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{19}
+}
+
+func (m *ListCopiesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCopiesRes.Unmarshal(m, b)
+}
+func (m *ListCopiesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCopiesRes.Marshal(b, m, deterministic)
+}
+func (m *ListCopiesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCopiesRes.Merge(m, src)
+}
+func (m *ListCopiesRes) XXX_Size() int {
+	return xxx_messageInfo_ListCopiesRes.Size(m)
+}
+func (m *ListCopiesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCopiesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCopiesRes proto.InternalMessageInfo
+
+func (m *ListCopiesRes) GetCopies() []*Copy {
+	if m != nil {
+		return m.Copies
+	}
+	return nil
+}
+
+type DeleteCopyReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCopyReq) Reset()         { *m = DeleteCopyReq{} }
+func (m *DeleteCopyReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteCopyReq) ProtoMessage()    {}
+func (*DeleteCopyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{20}
+}
+
+func (m *DeleteCopyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCopyReq.Unmarshal(m, b)
+}
+func (m *DeleteCopyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCopyReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteCopyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCopyReq.Merge(m, src)
+}
+func (m *DeleteCopyReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteCopyReq.Size(m)
+}
+func (m *DeleteCopyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCopyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCopyReq proto.InternalMessageInfo
+
+func (m *DeleteCopyReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetAvailabilityReq struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// ISO8601 format, both required and at most 366 days apart
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Leaves out free intervals shorter than this
+	MinDurationMinutes int32 `protobuf:"varint,4,opt,name=minDurationMinutes,proto3" json:"minDurationMinutes,omitempty"`
+	// Only returns the copies at this library if set
+	LibraryId            int64    `protobuf:"varint,5,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAvailabilityReq) Reset()         { *m = GetAvailabilityReq{} }
+func (m *GetAvailabilityReq) String() string { return proto.CompactTextString(m) }
+func (*GetAvailabilityReq) ProtoMessage()    {}
+func (*GetAvailabilityReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{21}
+}
+
+func (m *GetAvailabilityReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAvailabilityReq.Unmarshal(m, b)
+}
+func (m *GetAvailabilityReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAvailabilityReq.Marshal(b, m, deterministic)
+}
+func (m *GetAvailabilityReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAvailabilityReq.Merge(m, src)
+}
+func (m *GetAvailabilityReq) XXX_Size() int {
+	return xxx_messageInfo_GetAvailabilityReq.Size(m)
+}
+func (m *GetAvailabilityReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAvailabilityReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAvailabilityReq proto.InternalMessageInfo
+
+func (m *GetAvailabilityReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *GetAvailabilityReq) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *GetAvailabilityReq) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *GetAvailabilityReq) GetMinDurationMinutes() int32 {
+	if m != nil {
+		return m.MinDurationMinutes
+	}
+	return 0
+}
+
+func (m *GetAvailabilityReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+// Interval is a span of time from start up to end, in ISO8601 format
+type Interval struct {
+	Start                string   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  string   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Interval) Reset()         { *m = Interval{} }
+func (m *Interval) String() string { return proto.CompactTextString(m) }
+func (*Interval) ProtoMessage()    {}
+func (*Interval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{22}
+}
+
+func (m *Interval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interval.Unmarshal(m, b)
+}
+func (m *Interval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interval.Marshal(b, m, deterministic)
+}
+func (m *Interval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interval.Merge(m, src)
+}
+func (m *Interval) XXX_Size() int {
+	return xxx_messageInfo_Interval.Size(m)
+}
+func (m *Interval) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interval proto.InternalMessageInfo
+
+func (m *Interval) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *Interval) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+type CopyAvailability struct {
+	CopyId    int64  `protobuf:"varint,1,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	LibraryId int64  `protobuf:"varint,2,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	Library   string `protobuf:"bytes,3,opt,name=library,proto3" json:"library,omitempty"`
+	// When the copy can be reserved, trimmed to start and end while the library is
+	// open where its opening hours are known
+	Free []*Interval `protobuf:"bytes,4,rep,name=free,proto3" json:"free,omitempty"`
+	// When the copy is reserved or checked out
+	Busy                 []*Interval `protobuf:"bytes,5,rep,name=busy,proto3" json:"busy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CopyAvailability) Reset()         { *m = CopyAvailability{} }
+func (m *CopyAvailability) String() string { return proto.CompactTextString(m) }
+func (*CopyAvailability) ProtoMessage()    {}
+func (*CopyAvailability) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{23}
+}
+
+func (m *CopyAvailability) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyAvailability.Unmarshal(m, b)
+}
+func (m *CopyAvailability) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyAvailability.Marshal(b, m, deterministic)
+}
+func (m *CopyAvailability) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyAvailability.Merge(m, src)
+}
+func (m *CopyAvailability) XXX_Size() int {
+	return xxx_messageInfo_CopyAvailability.Size(m)
+}
+func (m *CopyAvailability) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyAvailability.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyAvailability proto.InternalMessageInfo
+
+func (m *CopyAvailability) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+func (m *CopyAvailability) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *CopyAvailability) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *CopyAvailability) GetFree() []*Interval {
+	if m != nil {
+		return m.Free
+	}
+	return nil
+}
+
+func (m *CopyAvailability) GetBusy() []*Interval {
+	if m != nil {
+		return m.Busy
+	}
+	return nil
+}
+
+type Availability struct {
+	Isbn                 string              `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	From                 string              `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string              `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Copies               []*CopyAvailability `protobuf:"bytes,4,rep,name=copies,proto3" json:"copies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Availability) Reset()         { *m = Availability{} }
+func (m *Availability) String() string { return proto.CompactTextString(m) }
+func (*Availability) ProtoMessage()    {}
+func (*Availability) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{24}
+}
+
+func (m *Availability) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Availability.Unmarshal(m, b)
+}
+func (m *Availability) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Availability.Marshal(b, m, deterministic)
+}
+func (m *Availability) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Availability.Merge(m, src)
+}
+func (m *Availability) XXX_Size() int {
+	return xxx_messageInfo_Availability.Size(m)
+}
+func (m *Availability) XXX_DiscardUnknown() {
+	xxx_messageInfo_Availability.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Availability proto.InternalMessageInfo
+
+func (m *Availability) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *Availability) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Availability) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Availability) GetCopies() []*CopyAvailability {
+	if m != nil {
+		return m.Copies
+	}
+	return nil
+}
+
+type GetAllBooksReq struct {
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The nextPageToken of the previous page
+	PageToken            string   `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAllBooksReq) Reset()         { *m = GetAllBooksReq{} }
+func (m *GetAllBooksReq) String() string { return proto.CompactTextString(m) }
+func (*GetAllBooksReq) ProtoMessage()    {}
+func (*GetAllBooksReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{25}
+}
+
+func (m *GetAllBooksReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllBooksReq.Unmarshal(m, b)
+}
+func (m *GetAllBooksReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllBooksReq.Marshal(b, m, deterministic)
+}
+func (m *GetAllBooksReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllBooksReq.Merge(m, src)
+}
+func (m *GetAllBooksReq) XXX_Size() int {
+	return xxx_messageInfo_GetAllBooksReq.Size(m)
+}
+func (m *GetAllBooksReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllBooksReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAllBooksReq proto.InternalMessageInfo
+
+func (m *GetAllBooksReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllBooksReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type GetAllBooksRes struct {
+	Books []*Book `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	// Empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAllBooksRes) Reset()         { *m = GetAllBooksRes{} }
+func (m *GetAllBooksRes) String() string { return proto.CompactTextString(m) }
+func (*GetAllBooksRes) ProtoMessage()    {}
+func (*GetAllBooksRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{26}
+}
+
+func (m *GetAllBooksRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllBooksRes.Unmarshal(m, b)
+}
+func (m *GetAllBooksRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllBooksRes.Marshal(b, m, deterministic)
+}
+func (m *GetAllBooksRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllBooksRes.Merge(m, src)
+}
+func (m *GetAllBooksRes) XXX_Size() int {
+	return xxx_messageInfo_GetAllBooksRes.Size(m)
+}
+func (m *GetAllBooksRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllBooksRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAllBooksRes proto.InternalMessageInfo
+
+func (m *GetAllBooksRes) GetBooks() []*Book {
+	if m != nil {
+		return m.Books
+	}
+	return nil
+}
+
+func (m *GetAllBooksRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetBookReq struct {
+	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBookReq) Reset()         { *m = GetBookReq{} }
+func (m *GetBookReq) String() string { return proto.CompactTextString(m) }
+func (*GetBookReq) ProtoMessage()    {}
+func (*GetBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{27}
+}
+
+func (m *GetBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBookReq.Unmarshal(m, b)
+}
+func (m *GetBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBookReq.Marshal(b, m, deterministic)
+}
+func (m *GetBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBookReq.Merge(m, src)
+}
+func (m *GetBookReq) XXX_Size() int {
+	return xxx_messageInfo_GetBookReq.Size(m)
+}
+func (m *GetBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBookReq proto.InternalMessageInfo
+
+func (m *GetBookReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+type ReturnBookReq struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// The copy being returned, required if several copies of the book are checked out
+	CopyId               int64    `protobuf:"varint,2,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReturnBookReq) Reset()         { *m = ReturnBookReq{} }
+func (m *ReturnBookReq) String() string { return proto.CompactTextString(m) }
+func (*ReturnBookReq) ProtoMessage()    {}
+func (*ReturnBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{28}
+}
+
+func (m *ReturnBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnBookReq.Unmarshal(m, b)
+}
+func (m *ReturnBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnBookReq.Marshal(b, m, deterministic)
+}
+func (m *ReturnBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnBookReq.Merge(m, src)
+}
+func (m *ReturnBookReq) XXX_Size() int {
+	return xxx_messageInfo_ReturnBookReq.Size(m)
+}
+func (m *ReturnBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReturnBookReq proto.InternalMessageInfo
+
+func (m *ReturnBookReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ReturnBookReq) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+type ReturnBookRes struct {
+	Reservation *BookReservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// The late fee charged, 0 if the book was on time
+	LateFee              float32  `protobuf:"fixed32,2,opt,name=lateFee,proto3" json:"lateFee,omitempty"`
+	DaysLate             int32    `protobuf:"varint,3,opt,name=daysLate,proto3" json:"daysLate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReturnBookRes) Reset()         { *m = ReturnBookRes{} }
+func (m *ReturnBookRes) String() string { return proto.CompactTextString(m) }
+func (*ReturnBookRes) ProtoMessage()    {}
+func (*ReturnBookRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{29}
+}
+
+func (m *ReturnBookRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnBookRes.Unmarshal(m, b)
+}
+func (m *ReturnBookRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnBookRes.Marshal(b, m, deterministic)
+}
+func (m *ReturnBookRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnBookRes.Merge(m, src)
+}
+func (m *ReturnBookRes) XXX_Size() int {
+	return xxx_messageInfo_ReturnBookRes.Size(m)
+}
+func (m *ReturnBookRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnBookRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReturnBookRes proto.InternalMessageInfo
+
+func (m *ReturnBookRes) GetReservation() *BookReservation {
+	if m != nil {
+		return m.Reservation
+	}
+	return nil
+}
+
+func (m *ReturnBookRes) GetLateFee() float32 {
+	if m != nil {
+		return m.LateFee
+	}
+	return 0
+}
+
+func (m *ReturnBookRes) GetDaysLate() int32 {
+	if m != nil {
+		return m.DaysLate
+	}
+	return 0
+}
+
+type AddBookReq struct {
+	Book                 *Book    `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddBookReq) Reset()         { *m = AddBookReq{} }
+func (m *AddBookReq) String() string { return proto.CompactTextString(m) }
+func (*AddBookReq) ProtoMessage()    {}
+func (*AddBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{30}
+}
+
+func (m *AddBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddBookReq.Unmarshal(m, b)
+}
+func (m *AddBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddBookReq.Marshal(b, m, deterministic)
+}
+func (m *AddBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddBookReq.Merge(m, src)
+}
+func (m *AddBookReq) XXX_Size() int {
+	return xxx_messageInfo_AddBookReq.Size(m)
+}
+func (m *AddBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddBookReq proto.InternalMessageInfo
+
+func (m *AddBookReq) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+type DeleteBookReq struct {
+	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBookReq) Reset()         { *m = DeleteBookReq{} }
+func (m *DeleteBookReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteBookReq) ProtoMessage()    {}
+func (*DeleteBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{31}
+}
+
+func (m *DeleteBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBookReq.Unmarshal(m, b)
+}
+func (m *DeleteBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBookReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBookReq.Merge(m, src)
+}
+func (m *DeleteBookReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteBookReq.Size(m)
+}
+func (m *DeleteBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBookReq proto.InternalMessageInfo
+
+func (m *DeleteBookReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+type ImportBooksReq struct {
+	// The book to add, or whose price to update, along with a copy at its library,
+	// named by library or libraryId, unless the book already has one there. Its lat
+	// and lng are only checked to be valid, as a book is located by its library.
+	Book *Book `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	// Rolls back every batch instead of keeping it, only read from the first message
+	DryRun bool `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// The row number reported back for the book, e.g. its line in the imported file.
+	// Defaults to the 1-based position of the message in the stream.
+	Row                  int32    `protobuf:"varint,3,opt,name=row,proto3" json:"row,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBooksReq) Reset()         { *m = ImportBooksReq{} }
+func (m *ImportBooksReq) String() string { return proto.CompactTextString(m) }
+func (*ImportBooksReq) ProtoMessage()    {}
+func (*ImportBooksReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{32}
+}
+
+func (m *ImportBooksReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBooksReq.Unmarshal(m, b)
+}
+func (m *ImportBooksReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBooksReq.Marshal(b, m, deterministic)
+}
+func (m *ImportBooksReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBooksReq.Merge(m, src)
+}
+func (m *ImportBooksReq) XXX_Size() int {
+	return xxx_messageInfo_ImportBooksReq.Size(m)
+}
+func (m *ImportBooksReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBooksReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBooksReq proto.InternalMessageInfo
+
+func (m *ImportBooksReq) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+func (m *ImportBooksReq) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportBooksReq) GetRow() int32 {
+	if m != nil {
+		return m.Row
+	}
+	return 0
+}
+
+type ImportedRow struct {
+	Row     int32         `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Isbn    string        `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Outcome ImportOutcome `protobuf:"varint,3,opt,name=outcome,proto3,enum=reservations.ImportOutcome" json:"outcome,omitempty"`
+	// Why a rejected row wasn't imported, and the matching ErrorInfo reason such as
+	// INVALID_ROW or LIBRARY_NOT_FOUND
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Reason               string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportedRow) Reset()         { *m = ImportedRow{} }
+func (m *ImportedRow) String() string { return proto.CompactTextString(m) }
+func (*ImportedRow) ProtoMessage()    {}
+func (*ImportedRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{33}
+}
+
+func (m *ImportedRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportedRow.Unmarshal(m, b)
+}
+func (m *ImportedRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportedRow.Marshal(b, m, deterministic)
+}
+func (m *ImportedRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportedRow.Merge(m, src)
+}
+func (m *ImportedRow) XXX_Size() int {
+	return xxx_messageInfo_ImportedRow.Size(m)
+}
+func (m *ImportedRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportedRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportedRow proto.InternalMessageInfo
+
+func (m *ImportedRow) GetRow() int32 {
+	if m != nil {
+		return m.Row
+	}
+	return 0
+}
+
+func (m *ImportedRow) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ImportedRow) GetOutcome() ImportOutcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED
+}
+
+func (m *ImportedRow) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ImportedRow) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ImportBooksRes struct {
+	Created  int32 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated  int32 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Rejected int32 `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// Nothing was kept
+	DryRun               bool           `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Rows                 []*ImportedRow `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportBooksRes) Reset()         { *m = ImportBooksRes{} }
+func (m *ImportBooksRes) String() string { return proto.CompactTextString(m) }
+func (*ImportBooksRes) ProtoMessage()    {}
+func (*ImportBooksRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{34}
+}
+
+func (m *ImportBooksRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBooksRes.Unmarshal(m, b)
+}
+func (m *ImportBooksRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBooksRes.Marshal(b, m, deterministic)
+}
+func (m *ImportBooksRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBooksRes.Merge(m, src)
+}
+func (m *ImportBooksRes) XXX_Size() int {
+	return xxx_messageInfo_ImportBooksRes.Size(m)
+}
+func (m *ImportBooksRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBooksRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBooksRes proto.InternalMessageInfo
+
+func (m *ImportBooksRes) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ImportBooksRes) GetUpdated() int32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *ImportBooksRes) GetRejected() int32 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
+func (m *ImportBooksRes) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportBooksRes) GetRows() []*ImportedRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type ReserveBookReq struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// Start and End times are ISO8601 format
+	StartDate string `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// The patron making the reservation
+	PatronId int64 `protobuf:"varint,4,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The copy to reserve, or 0 to reserve any free copy
+	CopyId               int64    `protobuf:"varint,5,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReserveBookReq) Reset()         { *m = ReserveBookReq{} }
+func (m *ReserveBookReq) String() string { return proto.CompactTextString(m) }
+func (*ReserveBookReq) ProtoMessage()    {}
+func (*ReserveBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{35}
+}
+
+func (m *ReserveBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReserveBookReq.Unmarshal(m, b)
+}
+func (m *ReserveBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReserveBookReq.Marshal(b, m, deterministic)
+}
+func (m *ReserveBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReserveBookReq.Merge(m, src)
+}
+func (m *ReserveBookReq) XXX_Size() int {
+	return xxx_messageInfo_ReserveBookReq.Size(m)
+}
+func (m *ReserveBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReserveBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReserveBookReq proto.InternalMessageInfo
+
+func (m *ReserveBookReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ReserveBookReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *ReserveBookReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *ReserveBookReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *ReserveBookReq) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+// ReservationSuggestions is attached to the RESERVATION_OVERLAP error of ReserveBook
+// with alternatives to the window that was taken
+type ReservationSuggestions struct {
+	// The next windows of the same length a copy of the book, or the requested copy,
+	// is free over, earliest first
+	Slots []*SuggestedSlot `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	// Books with copies free over the requested window near the requested copy, or
+	// the book's first copy, nearest first
+	Nearby               []*Book  `protobuf:"bytes,2,rep,name=nearby,proto3" json:"nearby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReservationSuggestions) Reset()         { *m = ReservationSuggestions{} }
+func (m *ReservationSuggestions) String() string { return proto.CompactTextString(m) }
+func (*ReservationSuggestions) ProtoMessage()    {}
+func (*ReservationSuggestions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{36}
+}
+
+func (m *ReservationSuggestions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReservationSuggestions.Unmarshal(m, b)
+}
+func (m *ReservationSuggestions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReservationSuggestions.Marshal(b, m, deterministic)
+}
+func (m *ReservationSuggestions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReservationSuggestions.Merge(m, src)
+}
+func (m *ReservationSuggestions) XXX_Size() int {
+	return xxx_messageInfo_ReservationSuggestions.Size(m)
+}
+func (m *ReservationSuggestions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReservationSuggestions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReservationSuggestions proto.InternalMessageInfo
+
+func (m *ReservationSuggestions) GetSlots() []*SuggestedSlot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+func (m *ReservationSuggestions) GetNearby() []*Book {
+	if m != nil {
+		return m.Nearby
+	}
+	return nil
+}
+
+type SuggestedSlot struct {
+	CopyId    int64  `protobuf:"varint,1,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	LibraryId int64  `protobuf:"varint,2,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	Library   string `protobuf:"bytes,3,opt,name=library,proto3" json:"library,omitempty"`
+	// ISO8601 format
+	StartDate            string   `protobuf:"bytes,4,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate              string   `protobuf:"bytes,5,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SuggestedSlot) Reset()         { *m = SuggestedSlot{} }
+func (m *SuggestedSlot) String() string { return proto.CompactTextString(m) }
+func (*SuggestedSlot) ProtoMessage()    {}
+func (*SuggestedSlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{37}
+}
+
+func (m *SuggestedSlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuggestedSlot.Unmarshal(m, b)
+}
+func (m *SuggestedSlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuggestedSlot.Marshal(b, m, deterministic)
+}
+func (m *SuggestedSlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestedSlot.Merge(m, src)
+}
+func (m *SuggestedSlot) XXX_Size() int {
+	return xxx_messageInfo_SuggestedSlot.Size(m)
+}
+func (m *SuggestedSlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestedSlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuggestedSlot proto.InternalMessageInfo
+
+func (m *SuggestedSlot) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+func (m *SuggestedSlot) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *SuggestedSlot) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *SuggestedSlot) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *SuggestedSlot) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+// BookReservation is named so as not to clash with the Reservation service
+type BookReservation struct {
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Isbn string `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// Start, End and Created times are ISO8601 format
+	StartDate string            `protobuf:"bytes,3,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string            `protobuf:"bytes,4,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	Status    ReservationStatus `protobuf:"varint,5,opt,name=status,proto3,enum=reservations.ReservationStatus" json:"status,omitempty"`
+	CreatedAt string            `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Library   string            `protobuf:"bytes,7,opt,name=library,proto3" json:"library,omitempty"`
+	// Set once the book has been checked out, ISO8601 format
+	CheckedOutAt string `protobuf:"bytes,8,opt,name=checkedOutAt,proto3" json:"checkedOutAt,omitempty"`
+	// The patron who made the reservation, 0 for reservations made before patrons existed
+	PatronId int64 `protobuf:"varint,9,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The patron who checked the book out
+	CheckedOutBy int64 `protobuf:"varint,10,opt,name=checkedOutBy,proto3" json:"checkedOutBy,omitempty"`
+	// The reserved copy
+	CopyId    int64 `protobuf:"varint,11,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	LibraryId int64 `protobuf:"varint,12,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// Set once a checkout has been flagged as overdue, ISO8601 format
+	OverdueAt string `protobuf:"bytes,13,opt,name=overdueAt,proto3" json:"overdueAt,omitempty"`
+	// Set once the book has been returned, ISO8601 format
+	ReturnedAt string `protobuf:"bytes,14,opt,name=returnedAt,proto3" json:"returnedAt,omitempty"`
+	// How many times the loan has been renewed
+	Renewals int32 `protobuf:"varint,15,opt,name=renewals,proto3" json:"renewals,omitempty"`
+	// The series the reservation was made for by ReserveRecurring
+	SeriesId             int64    `protobuf:"varint,16,opt,name=seriesId,proto3" json:"seriesId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookReservation) Reset()         { *m = BookReservation{} }
+func (m *BookReservation) String() string { return proto.CompactTextString(m) }
+func (*BookReservation) ProtoMessage()    {}
+func (*BookReservation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{38}
+}
+
+func (m *BookReservation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookReservation.Unmarshal(m, b)
+}
+func (m *BookReservation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookReservation.Marshal(b, m, deterministic)
+}
+func (m *BookReservation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookReservation.Merge(m, src)
+}
+func (m *BookReservation) XXX_Size() int {
+	return xxx_messageInfo_BookReservation.Size(m)
+}
+func (m *BookReservation) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookReservation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookReservation proto.InternalMessageInfo
+
+func (m *BookReservation) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BookReservation) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *BookReservation) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *BookReservation) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *BookReservation) GetStatus() ReservationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ReservationStatus_RESERVATION_STATUS_UNSPECIFIED
+}
+
+func (m *BookReservation) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *BookReservation) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *BookReservation) GetCheckedOutAt() string {
+	if m != nil {
+		return m.CheckedOutAt
+	}
+	return ""
+}
+
+func (m *BookReservation) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *BookReservation) GetCheckedOutBy() int64 {
+	if m != nil {
+		return m.CheckedOutBy
+	}
+	return 0
+}
+
+func (m *BookReservation) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+func (m *BookReservation) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *BookReservation) GetOverdueAt() string {
+	if m != nil {
+		return m.OverdueAt
+	}
+	return ""
+}
+
+func (m *BookReservation) GetReturnedAt() string {
+	if m != nil {
+		return m.ReturnedAt
+	}
+	return ""
+}
+
+func (m *BookReservation) GetRenewals() int32 {
+	if m != nil {
+		return m.Renewals
+	}
+	return 0
+}
+
+func (m *BookReservation) GetSeriesId() int64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+type ReserveRecurringReq struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// The window of the first occurrence, ISO8601 format
+	StartDate string `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// An RFC 5545 recurrence rule without DTSTART, e.g.
+	// FREQ=WEEKLY;BYDAY=TU;UNTIL=20261218T000000Z. It must end with COUNT or
+	// UNTIL within 200 occurrences.
+	Rrule string `protobuf:"bytes,4,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	// The IANA time zone the occurrences keep their wall clock time in, e.g. Europe/London
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// The patron making the reservations
+	PatronId int64 `protobuf:"varint,6,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The copy to reserve, or 0 to reserve any free copy for each occurrence
+	CopyId               int64      `protobuf:"varint,7,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	Mode                 SeriesMode `protobuf:"varint,8,opt,name=mode,proto3,enum=reservations.SeriesMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReserveRecurringReq) Reset()         { *m = ReserveRecurringReq{} }
+func (m *ReserveRecurringReq) String() string { return proto.CompactTextString(m) }
+func (*ReserveRecurringReq) ProtoMessage()    {}
+func (*ReserveRecurringReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{39}
+}
+
+func (m *ReserveRecurringReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReserveRecurringReq.Unmarshal(m, b)
+}
+func (m *ReserveRecurringReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReserveRecurringReq.Marshal(b, m, deterministic)
+}
+func (m *ReserveRecurringReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReserveRecurringReq.Merge(m, src)
+}
+func (m *ReserveRecurringReq) XXX_Size() int {
+	return xxx_messageInfo_ReserveRecurringReq.Size(m)
+}
+func (m *ReserveRecurringReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReserveRecurringReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReserveRecurringReq proto.InternalMessageInfo
+
+func (m *ReserveRecurringReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ReserveRecurringReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *ReserveRecurringReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *ReserveRecurringReq) GetRrule() string {
+	if m != nil {
+		return m.Rrule
+	}
+	return ""
+}
+
+func (m *ReserveRecurringReq) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *ReserveRecurringReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *ReserveRecurringReq) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+func (m *ReserveRecurringReq) GetMode() SeriesMode {
+	if m != nil {
+		return m.Mode
+	}
+	return SeriesMode_ALL_OR_NOTHING
+}
+
+type ReservationSeries struct {
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Isbn     string `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	PatronId int64  `protobuf:"varint,3,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	Rrule    string `protobuf:"bytes,4,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// The window of the first occurrence, ISO8601 format
+	StartDate string `protobuf:"bytes,6,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,7,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	CreatedAt string `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// Set once the series has been cancelled, ISO8601 format
+	CancelledAt string `protobuf:"bytes,9,opt,name=cancelledAt,proto3" json:"cancelledAt,omitempty"`
+	// The reservations made for the series, earliest first
+	Reservations         []*BookReservation `protobuf:"bytes,10,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ReservationSeries) Reset()         { *m = ReservationSeries{} }
+func (m *ReservationSeries) String() string { return proto.CompactTextString(m) }
+func (*ReservationSeries) ProtoMessage()    {}
+func (*ReservationSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{40}
+}
+
+func (m *ReservationSeries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReservationSeries.Unmarshal(m, b)
+}
+func (m *ReservationSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReservationSeries.Marshal(b, m, deterministic)
+}
+func (m *ReservationSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReservationSeries.Merge(m, src)
+}
+func (m *ReservationSeries) XXX_Size() int {
+	return xxx_messageInfo_ReservationSeries.Size(m)
+}
+func (m *ReservationSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReservationSeries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReservationSeries proto.InternalMessageInfo
+
+func (m *ReservationSeries) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ReservationSeries) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *ReservationSeries) GetRrule() string {
+	if m != nil {
+		return m.Rrule
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetCancelledAt() string {
+	if m != nil {
+		return m.CancelledAt
+	}
+	return ""
+}
+
+func (m *ReservationSeries) GetReservations() []*BookReservation {
+	if m != nil {
+		return m.Reservations
+	}
+	return nil
+}
+
+type Occurrence struct {
+	// ISO8601 format
+	StartDate string `protobuf:"bytes,1,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,2,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// The reservation made for the occurrence, if any
+	Reservation *BookReservation `protobuf:"bytes,3,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// Why the occurrence wasn't reserved, and the matching ErrorInfo reason such as
+	// RESERVATION_OVERLAP or LIBRARY_CLOSED
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Reason               string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Occurrence) Reset()         { *m = Occurrence{} }
+func (m *Occurrence) String() string { return proto.CompactTextString(m) }
+func (*Occurrence) ProtoMessage()    {}
+func (*Occurrence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{41}
+}
+
+func (m *Occurrence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Occurrence.Unmarshal(m, b)
+}
+func (m *Occurrence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Occurrence.Marshal(b, m, deterministic)
+}
+func (m *Occurrence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Occurrence.Merge(m, src)
+}
+func (m *Occurrence) XXX_Size() int {
+	return xxx_messageInfo_Occurrence.Size(m)
+}
+func (m *Occurrence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Occurrence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Occurrence proto.InternalMessageInfo
+
+func (m *Occurrence) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *Occurrence) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *Occurrence) GetReservation() *BookReservation {
+	if m != nil {
+		return m.Reservation
+	}
+	return nil
+}
+
+func (m *Occurrence) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Occurrence) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// ReserveRecurringRes is also attached to the SERIES_CONFLICT error of
+// ReserveRecurring, without a series, to describe the conflicting occurrences
+type ReserveRecurringRes struct {
+	Series               *ReservationSeries `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	Occurrences          []*Occurrence      `protobuf:"bytes,2,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ReserveRecurringRes) Reset()         { *m = ReserveRecurringRes{} }
+func (m *ReserveRecurringRes) String() string { return proto.CompactTextString(m) }
+func (*ReserveRecurringRes) ProtoMessage()    {}
+func (*ReserveRecurringRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{42}
+}
+
+func (m *ReserveRecurringRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReserveRecurringRes.Unmarshal(m, b)
+}
+func (m *ReserveRecurringRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReserveRecurringRes.Marshal(b, m, deterministic)
+}
+func (m *ReserveRecurringRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReserveRecurringRes.Merge(m, src)
+}
+func (m *ReserveRecurringRes) XXX_Size() int {
+	return xxx_messageInfo_ReserveRecurringRes.Size(m)
+}
+func (m *ReserveRecurringRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReserveRecurringRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReserveRecurringRes proto.InternalMessageInfo
+
+func (m *ReserveRecurringRes) GetSeries() *ReservationSeries {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+func (m *ReserveRecurringRes) GetOccurrences() []*Occurrence {
+	if m != nil {
+		return m.Occurrences
+	}
+	return nil
+}
+
+type GetSeriesReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSeriesReq) Reset()         { *m = GetSeriesReq{} }
+func (m *GetSeriesReq) String() string { return proto.CompactTextString(m) }
+func (*GetSeriesReq) ProtoMessage()    {}
+func (*GetSeriesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{43}
+}
+
+func (m *GetSeriesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSeriesReq.Unmarshal(m, b)
+}
+func (m *GetSeriesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSeriesReq.Marshal(b, m, deterministic)
+}
+func (m *GetSeriesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeriesReq.Merge(m, src)
+}
+func (m *GetSeriesReq) XXX_Size() int {
+	return xxx_messageInfo_GetSeriesReq.Size(m)
+}
+func (m *GetSeriesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeriesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeriesReq proto.InternalMessageInfo
+
+func (m *GetSeriesReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type CancelSeriesReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelSeriesReq) Reset()         { *m = CancelSeriesReq{} }
+func (m *CancelSeriesReq) String() string { return proto.CompactTextString(m) }
+func (*CancelSeriesReq) ProtoMessage()    {}
+func (*CancelSeriesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{44}
+}
+
+func (m *CancelSeriesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelSeriesReq.Unmarshal(m, b)
+}
+func (m *CancelSeriesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelSeriesReq.Marshal(b, m, deterministic)
+}
+func (m *CancelSeriesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelSeriesReq.Merge(m, src)
+}
+func (m *CancelSeriesReq) XXX_Size() int {
+	return xxx_messageInfo_CancelSeriesReq.Size(m)
+}
+func (m *CancelSeriesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelSeriesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelSeriesReq proto.InternalMessageInfo
+
+func (m *CancelSeriesReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ListReservationsReq struct {
+	Isbn    string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Library string `protobuf:"bytes,2,opt,name=library,proto3" json:"library,omitempty"`
+	// Only reservations overlapping the window are listed. Either end may be
+	// left empty to leave it unbounded. ISO8601 format
+	StartDate string `protobuf:"bytes,3,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,4,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// Lists reservations in any of the statuses, or every status if empty
+	Statuses []ReservationStatus `protobuf:"varint,5,rep,packed,name=statuses,proto3,enum=reservations.ReservationStatus" json:"statuses,omitempty"`
+	OrderBy  ReservationOrder    `protobuf:"varint,6,opt,name=orderBy,proto3,enum=reservations.ReservationOrder" json:"orderBy,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,7,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The nextPageToken of the previous page
+	PageToken string `protobuf:"bytes,8,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// Only lists reservations made by the patron
+	PatronId int64 `protobuf:"varint,9,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// Only lists reservations at the library, like library but by ID
+	LibraryId            int64    `protobuf:"varint,10,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReservationsReq) Reset()         { *m = ListReservationsReq{} }
+func (m *ListReservationsReq) String() string { return proto.CompactTextString(m) }
+func (*ListReservationsReq) ProtoMessage()    {}
+func (*ListReservationsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{45}
+}
+
+func (m *ListReservationsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReservationsReq.Unmarshal(m, b)
+}
+func (m *ListReservationsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReservationsReq.Marshal(b, m, deterministic)
+}
+func (m *ListReservationsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReservationsReq.Merge(m, src)
+}
+func (m *ListReservationsReq) XXX_Size() int {
+	return xxx_messageInfo_ListReservationsReq.Size(m)
+}
+func (m *ListReservationsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReservationsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReservationsReq proto.InternalMessageInfo
+
+func (m *ListReservationsReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *ListReservationsReq) GetLibrary() string {
+	if m != nil {
+		return m.Library
+	}
+	return ""
+}
+
+func (m *ListReservationsReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *ListReservationsReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *ListReservationsReq) GetStatuses() []ReservationStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *ListReservationsReq) GetOrderBy() ReservationOrder {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ReservationOrder_START_ASC
+}
+
+func (m *ListReservationsReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListReservationsReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListReservationsReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *ListReservationsReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+type ListReservationsRes struct {
+	Reservations []*BookReservation `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	// Empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReservationsRes) Reset()         { *m = ListReservationsRes{} }
+func (m *ListReservationsRes) String() string { return proto.CompactTextString(m) }
+func (*ListReservationsRes) ProtoMessage()    {}
+func (*ListReservationsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{46}
+}
+
+func (m *ListReservationsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReservationsRes.Unmarshal(m, b)
+}
+func (m *ListReservationsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReservationsRes.Marshal(b, m, deterministic)
+}
+func (m *ListReservationsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReservationsRes.Merge(m, src)
+}
+func (m *ListReservationsRes) XXX_Size() int {
+	return xxx_messageInfo_ListReservationsRes.Size(m)
+}
+func (m *ListReservationsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReservationsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReservationsRes proto.InternalMessageInfo
+
+func (m *ListReservationsRes) GetReservations() []*BookReservation {
+	if m != nil {
+		return m.Reservations
+	}
+	return nil
+}
+
+func (m *ListReservationsRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListOverdueReq struct {
+	// Only lists overdue books of the library
+	LibraryId int64 `protobuf:"varint,1,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The nextPageToken of the previous page
+	PageToken            string   `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOverdueReq) Reset()         { *m = ListOverdueReq{} }
+func (m *ListOverdueReq) String() string { return proto.CompactTextString(m) }
+func (*ListOverdueReq) ProtoMessage()    {}
+func (*ListOverdueReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{47}
+}
+
+func (m *ListOverdueReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOverdueReq.Unmarshal(m, b)
+}
+func (m *ListOverdueReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOverdueReq.Marshal(b, m, deterministic)
+}
+func (m *ListOverdueReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOverdueReq.Merge(m, src)
+}
+func (m *ListOverdueReq) XXX_Size() int {
+	return xxx_messageInfo_ListOverdueReq.Size(m)
+}
+func (m *ListOverdueReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOverdueReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOverdueReq proto.InternalMessageInfo
+
+func (m *ListOverdueReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *ListOverdueReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListOverdueReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type GetReservationReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReservationReq) Reset()         { *m = GetReservationReq{} }
+func (m *GetReservationReq) String() string { return proto.CompactTextString(m) }
+func (*GetReservationReq) ProtoMessage()    {}
+func (*GetReservationReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{48}
+}
+
+func (m *GetReservationReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReservationReq.Unmarshal(m, b)
+}
+func (m *GetReservationReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReservationReq.Marshal(b, m, deterministic)
+}
+func (m *GetReservationReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReservationReq.Merge(m, src)
+}
+func (m *GetReservationReq) XXX_Size() int {
+	return xxx_messageInfo_GetReservationReq.Size(m)
+}
+func (m *GetReservationReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReservationReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReservationReq proto.InternalMessageInfo
+
+func (m *GetReservationReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type CheckoutReservationReq struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The patron taking the book, defaults to the patron who made the reservation
+	PatronId             int64    `protobuf:"varint,2,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckoutReservationReq) Reset()         { *m = CheckoutReservationReq{} }
+func (m *CheckoutReservationReq) String() string { return proto.CompactTextString(m) }
+func (*CheckoutReservationReq) ProtoMessage()    {}
+func (*CheckoutReservationReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{49}
+}
+
+func (m *CheckoutReservationReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckoutReservationReq.Unmarshal(m, b)
+}
+func (m *CheckoutReservationReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckoutReservationReq.Marshal(b, m, deterministic)
+}
+func (m *CheckoutReservationReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckoutReservationReq.Merge(m, src)
+}
+func (m *CheckoutReservationReq) XXX_Size() int {
+	return xxx_messageInfo_CheckoutReservationReq.Size(m)
+}
+func (m *CheckoutReservationReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckoutReservationReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckoutReservationReq proto.InternalMessageInfo
+
+func (m *CheckoutReservationReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CheckoutReservationReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+type CancelReservationReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelReservationReq) Reset()         { *m = CancelReservationReq{} }
+func (m *CancelReservationReq) String() string { return proto.CompactTextString(m) }
+func (*CancelReservationReq) ProtoMessage()    {}
+func (*CancelReservationReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{50}
+}
+
+func (m *CancelReservationReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelReservationReq.Unmarshal(m, b)
+}
+func (m *CancelReservationReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelReservationReq.Marshal(b, m, deterministic)
+}
+func (m *CancelReservationReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelReservationReq.Merge(m, src)
+}
+func (m *CancelReservationReq) XXX_Size() int {
+	return xxx_messageInfo_CancelReservationReq.Size(m)
+}
+func (m *CancelReservationReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelReservationReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelReservationReq proto.InternalMessageInfo
+
+func (m *CancelReservationReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type RescheduleReservationReq struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Start and End times are ISO8601 format
+	StartDate            string   `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate              string   `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescheduleReservationReq) Reset()         { *m = RescheduleReservationReq{} }
+func (m *RescheduleReservationReq) String() string { return proto.CompactTextString(m) }
+func (*RescheduleReservationReq) ProtoMessage()    {}
+func (*RescheduleReservationReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{51}
+}
+
+func (m *RescheduleReservationReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescheduleReservationReq.Unmarshal(m, b)
+}
+func (m *RescheduleReservationReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescheduleReservationReq.Marshal(b, m, deterministic)
+}
+func (m *RescheduleReservationReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescheduleReservationReq.Merge(m, src)
+}
+func (m *RescheduleReservationReq) XXX_Size() int {
+	return xxx_messageInfo_RescheduleReservationReq.Size(m)
+}
+func (m *RescheduleReservationReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescheduleReservationReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescheduleReservationReq proto.InternalMessageInfo
+
+func (m *RescheduleReservationReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RescheduleReservationReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *RescheduleReservationReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+type RenewLoanReq struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The new end of the reservation, after its current end. ISO8601 format
+	EndDate              string   `protobuf:"bytes,2,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewLoanReq) Reset()         { *m = RenewLoanReq{} }
+func (m *RenewLoanReq) String() string { return proto.CompactTextString(m) }
+func (*RenewLoanReq) ProtoMessage()    {}
+func (*RenewLoanReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{52}
+}
+
+func (m *RenewLoanReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewLoanReq.Unmarshal(m, b)
+}
+func (m *RenewLoanReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewLoanReq.Marshal(b, m, deterministic)
+}
+func (m *RenewLoanReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewLoanReq.Merge(m, src)
+}
+func (m *RenewLoanReq) XXX_Size() int {
+	return xxx_messageInfo_RenewLoanReq.Size(m)
+}
+func (m *RenewLoanReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewLoanReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewLoanReq proto.InternalMessageInfo
+
+func (m *RenewLoanReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RenewLoanReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+type CheckoutBookReq struct {
+	Isbn string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// Start and End times are ISO8601 format
+	StartDate string `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// The patron taking the book, defaults to the patron who made the reservation
+	PatronId int64 `protobuf:"varint,4,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The reserved copy, required if several copies are reserved over the window
+	CopyId               int64    `protobuf:"varint,5,opt,name=copyId,proto3" json:"copyId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckoutBookReq) Reset()         { *m = CheckoutBookReq{} }
+func (m *CheckoutBookReq) String() string { return proto.CompactTextString(m) }
+func (*CheckoutBookReq) ProtoMessage()    {}
+func (*CheckoutBookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{53}
+}
+
+func (m *CheckoutBookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckoutBookReq.Unmarshal(m, b)
+}
+func (m *CheckoutBookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckoutBookReq.Marshal(b, m, deterministic)
+}
+func (m *CheckoutBookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckoutBookReq.Merge(m, src)
+}
+func (m *CheckoutBookReq) XXX_Size() int {
+	return xxx_messageInfo_CheckoutBookReq.Size(m)
+}
+func (m *CheckoutBookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckoutBookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckoutBookReq proto.InternalMessageInfo
+
+func (m *CheckoutBookReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *CheckoutBookReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *CheckoutBookReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *CheckoutBookReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *CheckoutBookReq) GetCopyId() int64 {
+	if m != nil {
+		return m.CopyId
+	}
+	return 0
+}
+
+type SearchReq struct {
+	Lat   float32 `protobuf:"fixed32,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng   float32 `protobuf:"fixed32,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	Range float32 `protobuf:"fixed32,3,opt,name=range,proto3" json:"range,omitempty"`
+	// Start and End times are ISO8601 format. Either may be left empty to leave that
+	// end of the window unbounded. Without either, every book in range is listed
+	// with the copies free right now as its availableCopies.
+	StartDate string `protobuf:"bytes,4,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,5,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The nextPageToken of the previous page, only valid for the same search
+	PageToken string `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// Only lists books priced within the bounds, either of which may be 0 to leave it unbounded
+	MinPrice float32 `protobuf:"fixed32,8,opt,name=minPrice,proto3" json:"minPrice,omitempty"`
+	MaxPrice float32 `protobuf:"fixed32,9,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`
+	// Only lists books at any of the libraries, or at every library if empty
+	LibraryIds []int64 `protobuf:"varint,10,rep,packed,name=libraryIds,proto3" json:"libraryIds,omitempty"`
+	// Only lists any of the books, or every book if empty
+	Isbns                []string    `protobuf:"bytes,11,rep,name=isbns,proto3" json:"isbns,omitempty"`
+	OrderBy              SearchOrder `protobuf:"varint,12,opt,name=orderBy,proto3,enum=reservations.SearchOrder" json:"orderBy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SearchReq) Reset()         { *m = SearchReq{} }
+func (m *SearchReq) String() string { return proto.CompactTextString(m) }
+func (*SearchReq) ProtoMessage()    {}
+func (*SearchReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{54}
+}
+
+func (m *SearchReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReq.Unmarshal(m, b)
+}
+func (m *SearchReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReq.Marshal(b, m, deterministic)
+}
+func (m *SearchReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReq.Merge(m, src)
+}
+func (m *SearchReq) XXX_Size() int {
+	return xxx_messageInfo_SearchReq.Size(m)
+}
+func (m *SearchReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReq proto.InternalMessageInfo
+
+func (m *SearchReq) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *SearchReq) GetLng() float32 {
+	if m != nil {
+		return m.Lng
+	}
+	return 0
+}
+
+func (m *SearchReq) GetRange() float32 {
+	if m != nil {
+		return m.Range
+	}
+	return 0
+}
+
+func (m *SearchReq) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *SearchReq) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *SearchReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *SearchReq) GetMinPrice() float32 {
+	if m != nil {
+		return m.MinPrice
+	}
+	return 0
+}
+
+func (m *SearchReq) GetMaxPrice() float32 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
+}
+
+func (m *SearchReq) GetLibraryIds() []int64 {
+	if m != nil {
+		return m.LibraryIds
+	}
+	return nil
+}
+
+func (m *SearchReq) GetIsbns() []string {
+	if m != nil {
+		return m.Isbns
+	}
+	return nil
+}
+
+func (m *SearchReq) GetOrderBy() SearchOrder {
+	if m != nil {
+		return m.OrderBy
+	}
+	return SearchOrder_DISTANCE
+}
+
+type SearchRes struct {
+	Books []*Book `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	// Empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRes) Reset()         { *m = SearchRes{} }
+func (m *SearchRes) String() string { return proto.CompactTextString(m) }
+func (*SearchRes) ProtoMessage()    {}
+func (*SearchRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{55}
+}
+
+func (m *SearchRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRes.Unmarshal(m, b)
+}
+func (m *SearchRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRes.Marshal(b, m, deterministic)
+}
+func (m *SearchRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRes.Merge(m, src)
+}
+func (m *SearchRes) XXX_Size() int {
+	return xxx_messageInfo_SearchRes.Size(m)
+}
+func (m *SearchRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRes proto.InternalMessageInfo
+
+func (m *SearchRes) GetBooks() []*Book {
+	if m != nil {
+		return m.Books
+	}
+	return nil
+}
+
+func (m *SearchRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type Patron struct {
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	// ISO8601 format
+	CreatedAt string `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// The total of the fees charged to the patron, read only
+	Balance              float32  `protobuf:"fixed32,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Patron) Reset()         { *m = Patron{} }
+func (m *Patron) String() string { return proto.CompactTextString(m) }
+func (*Patron) ProtoMessage()    {}
+func (*Patron) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{56}
+}
+
+func (m *Patron) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Patron.Unmarshal(m, b)
+}
+func (m *Patron) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Patron.Marshal(b, m, deterministic)
+}
+func (m *Patron) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Patron.Merge(m, src)
+}
+func (m *Patron) XXX_Size() int {
+	return xxx_messageInfo_Patron.Size(m)
+}
+func (m *Patron) XXX_DiscardUnknown() {
+	xxx_messageInfo_Patron.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Patron proto.InternalMessageInfo
+
+func (m *Patron) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Patron) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Patron) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Patron) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *Patron) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Patron) GetBalance() float32 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type CreatePatronReq struct {
+	Patron               *Patron  `protobuf:"bytes,1,opt,name=patron,proto3" json:"patron,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreatePatronReq) Reset()         { *m = CreatePatronReq{} }
+func (m *CreatePatronReq) String() string { return proto.CompactTextString(m) }
+func (*CreatePatronReq) ProtoMessage()    {}
+func (*CreatePatronReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{57}
+}
+
+func (m *CreatePatronReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreatePatronReq.Unmarshal(m, b)
+}
+func (m *CreatePatronReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreatePatronReq.Marshal(b, m, deterministic)
+}
+func (m *CreatePatronReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePatronReq.Merge(m, src)
+}
+func (m *CreatePatronReq) XXX_Size() int {
+	return xxx_messageInfo_CreatePatronReq.Size(m)
+}
+func (m *CreatePatronReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePatronReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreatePatronReq proto.InternalMessageInfo
+
+func (m *CreatePatronReq) GetPatron() *Patron {
+	if m != nil {
+		return m.Patron
+	}
+	return nil
+}
+
+type GetPatronReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPatronReq) Reset()         { *m = GetPatronReq{} }
+func (m *GetPatronReq) String() string { return proto.CompactTextString(m) }
+func (*GetPatronReq) ProtoMessage()    {}
+func (*GetPatronReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{58}
+}
+
+func (m *GetPatronReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPatronReq.Unmarshal(m, b)
+}
+func (m *GetPatronReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPatronReq.Marshal(b, m, deterministic)
+}
+func (m *GetPatronReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPatronReq.Merge(m, src)
+}
+func (m *GetPatronReq) XXX_Size() int {
+	return xxx_messageInfo_GetPatronReq.Size(m)
+}
+func (m *GetPatronReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPatronReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPatronReq proto.InternalMessageInfo
+
+func (m *GetPatronReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type UpdatePatronReq struct {
+	Patron               *Patron  `protobuf:"bytes,1,opt,name=patron,proto3" json:"patron,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdatePatronReq) Reset()         { *m = UpdatePatronReq{} }
+func (m *UpdatePatronReq) String() string { return proto.CompactTextString(m) }
+func (*UpdatePatronReq) ProtoMessage()    {}
+func (*UpdatePatronReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{59}
+}
+
+func (m *UpdatePatronReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePatronReq.Unmarshal(m, b)
+}
+func (m *UpdatePatronReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePatronReq.Marshal(b, m, deterministic)
+}
+func (m *UpdatePatronReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePatronReq.Merge(m, src)
+}
+func (m *UpdatePatronReq) XXX_Size() int {
+	return xxx_messageInfo_UpdatePatronReq.Size(m)
+}
+func (m *UpdatePatronReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePatronReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePatronReq proto.InternalMessageInfo
+
+func (m *UpdatePatronReq) GetPatron() *Patron {
+	if m != nil {
+		return m.Patron
+	}
+	return nil
+}
+
+// Fee is a charge to a patron's account
+type Fee struct {
+	Id       int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PatronId int64 `protobuf:"varint,2,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The reservation a late fee was charged for
+	ReservationId int64   `protobuf:"varint,3,opt,name=reservationId,proto3" json:"reservationId,omitempty"`
+	Amount        float32 `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	DaysLate      int32   `protobuf:"varint,5,opt,name=daysLate,proto3" json:"daysLate,omitempty"`
+	// ISO8601 format
+	CreatedAt            string   `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Fee) Reset()         { *m = Fee{} }
+func (m *Fee) String() string { return proto.CompactTextString(m) }
+func (*Fee) ProtoMessage()    {}
+func (*Fee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{60}
+}
+
+func (m *Fee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Fee.Unmarshal(m, b)
+}
+func (m *Fee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Fee.Marshal(b, m, deterministic)
+}
+func (m *Fee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Fee.Merge(m, src)
+}
+func (m *Fee) XXX_Size() int {
+	return xxx_messageInfo_Fee.Size(m)
+}
+func (m *Fee) XXX_DiscardUnknown() {
+	xxx_messageInfo_Fee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Fee proto.InternalMessageInfo
+
+func (m *Fee) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Fee) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *Fee) GetReservationId() int64 {
+	if m != nil {
+		return m.ReservationId
+	}
+	return 0
+}
+
+func (m *Fee) GetAmount() float32 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Fee) GetDaysLate() int32 {
+	if m != nil {
+		return m.DaysLate
+	}
+	return 0
+}
+
+func (m *Fee) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type ListFeesReq struct {
+	PatronId             int64    `protobuf:"varint,1,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFeesReq) Reset()         { *m = ListFeesReq{} }
+func (m *ListFeesReq) String() string { return proto.CompactTextString(m) }
+func (*ListFeesReq) ProtoMessage()    {}
+func (*ListFeesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{61}
+}
+
+func (m *ListFeesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFeesReq.Unmarshal(m, b)
+}
+func (m *ListFeesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFeesReq.Marshal(b, m, deterministic)
+}
+func (m *ListFeesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeesReq.Merge(m, src)
+}
+func (m *ListFeesReq) XXX_Size() int {
+	return xxx_messageInfo_ListFeesReq.Size(m)
+}
+func (m *ListFeesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeesReq proto.InternalMessageInfo
+
+func (m *ListFeesReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+type ListFeesRes struct {
+	Fees                 []*Fee   `protobuf:"bytes,1,rep,name=fees,proto3" json:"fees,omitempty"`
+	Balance              float32  `protobuf:"fixed32,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFeesRes) Reset()         { *m = ListFeesRes{} }
+func (m *ListFeesRes) String() string { return proto.CompactTextString(m) }
+func (*ListFeesRes) ProtoMessage()    {}
+func (*ListFeesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{62}
+}
+
+func (m *ListFeesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFeesRes.Unmarshal(m, b)
+}
+func (m *ListFeesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFeesRes.Marshal(b, m, deterministic)
+}
+func (m *ListFeesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeesRes.Merge(m, src)
+}
+func (m *ListFeesRes) XXX_Size() int {
+	return xxx_messageInfo_ListFeesRes.Size(m)
+}
+func (m *ListFeesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeesRes proto.InternalMessageInfo
+
+func (m *ListFeesRes) GetFees() []*Fee {
+	if m != nil {
+		return m.Fees
+	}
+	return nil
+}
+
+func (m *ListFeesRes) GetBalance() float32 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+// Hold is a patron waiting in the queue of a book
+type Hold struct {
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Isbn     string `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	PatronId int64  `protobuf:"varint,3,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The library to reserve at, or 0 for any library
+	LibraryId int64 `protobuf:"varint,4,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	// The length of the reservation to make
+	Days   int32      `protobuf:"varint,5,opt,name=days,proto3" json:"days,omitempty"`
+	Status HoldStatus `protobuf:"varint,6,opt,name=status,proto3,enum=reservations.HoldStatus" json:"status,omitempty"`
+	// The reservation the hold was promoted to, once fulfilled
+	ReservationId int64 `protobuf:"varint,7,opt,name=reservationId,proto3" json:"reservationId,omitempty"`
+	// ISO8601 format
+	CreatedAt string `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// The 1-based place in the queue of a waiting hold
+	Position             int32    `protobuf:"varint,9,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hold) Reset()         { *m = Hold{} }
+func (m *Hold) String() string { return proto.CompactTextString(m) }
+func (*Hold) ProtoMessage()    {}
+func (*Hold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{63}
+}
+
+func (m *Hold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hold.Unmarshal(m, b)
+}
+func (m *Hold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hold.Marshal(b, m, deterministic)
+}
+func (m *Hold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hold.Merge(m, src)
+}
+func (m *Hold) XXX_Size() int {
+	return xxx_messageInfo_Hold.Size(m)
+}
+func (m *Hold) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hold proto.InternalMessageInfo
+
+func (m *Hold) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Hold) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *Hold) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *Hold) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+func (m *Hold) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *Hold) GetStatus() HoldStatus {
+	if m != nil {
+		return m.Status
+	}
+	return HoldStatus_HOLD_STATUS_UNSPECIFIED
+}
+
+func (m *Hold) GetReservationId() int64 {
+	if m != nil {
+		return m.ReservationId
+	}
+	return 0
+}
+
+func (m *Hold) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Hold) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+type PlaceHoldReq struct {
+	Isbn     string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	PatronId int64  `protobuf:"varint,2,opt,name=patronId,proto3" json:"patronId,omitempty"`
+	// The length of the reservation to make, at most 90
+	Days int32 `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	// The library to reserve at, or 0 for any library
+	LibraryId            int64    `protobuf:"varint,4,opt,name=libraryId,proto3" json:"libraryId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlaceHoldReq) Reset()         { *m = PlaceHoldReq{} }
+func (m *PlaceHoldReq) String() string { return proto.CompactTextString(m) }
+func (*PlaceHoldReq) ProtoMessage()    {}
+func (*PlaceHoldReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{64}
+}
+
+func (m *PlaceHoldReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceHoldReq.Unmarshal(m, b)
+}
+func (m *PlaceHoldReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceHoldReq.Marshal(b, m, deterministic)
+}
+func (m *PlaceHoldReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceHoldReq.Merge(m, src)
+}
+func (m *PlaceHoldReq) XXX_Size() int {
+	return xxx_messageInfo_PlaceHoldReq.Size(m)
+}
+func (m *PlaceHoldReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceHoldReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceHoldReq proto.InternalMessageInfo
+
+func (m *PlaceHoldReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+func (m *PlaceHoldReq) GetPatronId() int64 {
+	if m != nil {
+		return m.PatronId
+	}
+	return 0
+}
+
+func (m *PlaceHoldReq) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *PlaceHoldReq) GetLibraryId() int64 {
+	if m != nil {
+		return m.LibraryId
+	}
+	return 0
+}
+
+type GetHoldReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHoldReq) Reset()         { *m = GetHoldReq{} }
+func (m *GetHoldReq) String() string { return proto.CompactTextString(m) }
+func (*GetHoldReq) ProtoMessage()    {}
+func (*GetHoldReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{65}
+}
+
+func (m *GetHoldReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHoldReq.Unmarshal(m, b)
+}
+func (m *GetHoldReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHoldReq.Marshal(b, m, deterministic)
+}
+func (m *GetHoldReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHoldReq.Merge(m, src)
+}
+func (m *GetHoldReq) XXX_Size() int {
+	return xxx_messageInfo_GetHoldReq.Size(m)
+}
+func (m *GetHoldReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHoldReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHoldReq proto.InternalMessageInfo
+
+func (m *GetHoldReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ListHoldsReq struct {
+	Isbn                 string   `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListHoldsReq) Reset()         { *m = ListHoldsReq{} }
+func (m *ListHoldsReq) String() string { return proto.CompactTextString(m) }
+func (*ListHoldsReq) ProtoMessage()    {}
+func (*ListHoldsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{66}
+}
+
+func (m *ListHoldsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListHoldsReq.Unmarshal(m, b)
+}
+func (m *ListHoldsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListHoldsReq.Marshal(b, m, deterministic)
+}
+func (m *ListHoldsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListHoldsReq.Merge(m, src)
+}
+func (m *ListHoldsReq) XXX_Size() int {
+	return xxx_messageInfo_ListHoldsReq.Size(m)
+}
+func (m *ListHoldsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListHoldsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListHoldsReq proto.InternalMessageInfo
+
+func (m *ListHoldsReq) GetIsbn() string {
+	if m != nil {
+		return m.Isbn
+	}
+	return ""
+}
+
+type ListHoldsRes struct {
+	Holds                []*Hold  `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListHoldsRes) Reset()         { *m = ListHoldsRes{} }
+func (m *ListHoldsRes) String() string { return proto.CompactTextString(m) }
+func (*ListHoldsRes) ProtoMessage()    {}
+func (*ListHoldsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{67}
+}
+
+func (m *ListHoldsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListHoldsRes.Unmarshal(m, b)
+}
+func (m *ListHoldsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListHoldsRes.Marshal(b, m, deterministic)
+}
+func (m *ListHoldsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListHoldsRes.Merge(m, src)
+}
+func (m *ListHoldsRes) XXX_Size() int {
+	return xxx_messageInfo_ListHoldsRes.Size(m)
+}
+func (m *ListHoldsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListHoldsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListHoldsRes proto.InternalMessageInfo
+
+func (m *ListHoldsRes) GetHolds() []*Hold {
+	if m != nil {
+		return m.Holds
+	}
+	return nil
+}
+
+type CancelHoldReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelHoldReq) Reset()         { *m = CancelHoldReq{} }
+func (m *CancelHoldReq) String() string { return proto.CompactTextString(m) }
+func (*CancelHoldReq) ProtoMessage()    {}
+func (*CancelHoldReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{68}
+}
+
+func (m *CancelHoldReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelHoldReq.Unmarshal(m, b)
+}
+func (m *CancelHoldReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelHoldReq.Marshal(b, m, deterministic)
+}
+func (m *CancelHoldReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelHoldReq.Merge(m, src)
+}
+func (m *CancelHoldReq) XXX_Size() int {
+	return xxx_messageInfo_CancelHoldReq.Size(m)
+}
+func (m *CancelHoldReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelHoldReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelHoldReq proto.InternalMessageInfo
+
+func (m *CancelHoldReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// Webhook is an HTTP endpoint subscribed to events
+type Webhook struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// An http or https URL deliveries are POSTed to
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// reservation.created, reservation.cancelled, reservation.rescheduled,
+	// book.checked_out, book.returned or hold.promoted. Every event if empty.
+	Events []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// The key deliveries are signed with, only returned when it is set
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Inactive webhooks aren't sent new events
+	Active bool `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	// ISO8601 format
+	CreatedAt            string   `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{69}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *Webhook) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type CreateWebhookReq struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookReq) Reset()         { *m = CreateWebhookReq{} }
+func (m *CreateWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookReq) ProtoMessage()    {}
+func (*CreateWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{70}
+}
+
+func (m *CreateWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookReq.Unmarshal(m, b)
+}
+func (m *CreateWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookReq.Merge(m, src)
+}
+func (m *CreateWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookReq.Size(m)
+}
+func (m *CreateWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookReq proto.InternalMessageInfo
+
+func (m *CreateWebhookReq) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type GetWebhookReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWebhookReq) Reset()         { *m = GetWebhookReq{} }
+func (m *GetWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*GetWebhookReq) ProtoMessage()    {}
+func (*GetWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{71}
+}
+
+func (m *GetWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWebhookReq.Unmarshal(m, b)
+}
+func (m *GetWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *GetWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWebhookReq.Merge(m, src)
+}
+func (m *GetWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_GetWebhookReq.Size(m)
+}
+func (m *GetWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWebhookReq proto.InternalMessageInfo
+
+func (m *GetWebhookReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ListWebhooksRes struct {
+	Webhooks             []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListWebhooksRes) Reset()         { *m = ListWebhooksRes{} }
+func (m *ListWebhooksRes) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRes) ProtoMessage()    {}
+func (*ListWebhooksRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{72}
+}
+
+func (m *ListWebhooksRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRes.Unmarshal(m, b)
+}
+func (m *ListWebhooksRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRes.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRes.Merge(m, src)
+}
+func (m *ListWebhooksRes) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRes.Size(m)
+}
+func (m *ListWebhooksRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRes proto.InternalMessageInfo
+
+func (m *ListWebhooksRes) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+type UpdateWebhookReq struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateWebhookReq) Reset()         { *m = UpdateWebhookReq{} }
+func (m *UpdateWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateWebhookReq) ProtoMessage()    {}
+func (*UpdateWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{73}
+}
+
+func (m *UpdateWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateWebhookReq.Unmarshal(m, b)
+}
+func (m *UpdateWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateWebhookReq.Merge(m, src)
+}
+func (m *UpdateWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateWebhookReq.Size(m)
+}
+func (m *UpdateWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateWebhookReq proto.InternalMessageInfo
+
+func (m *UpdateWebhookReq) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookReq struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookReq) Reset()         { *m = DeleteWebhookReq{} }
+func (m *DeleteWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookReq) ProtoMessage()    {}
+func (*DeleteWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{74}
+}
+
+func (m *DeleteWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookReq.Unmarshal(m, b)
+}
+func (m *DeleteWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookReq.Merge(m, src)
+}
+func (m *DeleteWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookReq.Size(m)
+}
+func (m *DeleteWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookReq proto.InternalMessageInfo
+
+func (m *DeleteWebhookReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// WebhookDelivery is an event queued for, or delivered to, a webhook
+type WebhookDelivery struct {
+	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId int64  `protobuf:"varint,2,opt,name=webhookId,proto3" json:"webhookId,omitempty"`
+	Event     string `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	// The JSON request body
+	Payload  string         `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status   DeliveryStatus `protobuf:"varint,5,opt,name=status,proto3,enum=reservations.DeliveryStatus" json:"status,omitempty"`
+	Attempts int32          `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Times are ISO8601 format
+	NextAttemptAt string `protobuf:"bytes,7,opt,name=nextAttemptAt,proto3" json:"nextAttemptAt,omitempty"`
+	LastAttemptAt string `protobuf:"bytes,8,opt,name=lastAttemptAt,proto3" json:"lastAttemptAt,omitempty"`
+	// The response status of the last attempt, 0 if there was no response
+	LastStatusCode       int32    `protobuf:"varint,9,opt,name=lastStatusCode,proto3" json:"lastStatusCode,omitempty"`
+	LastError            string   `protobuf:"bytes,10,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	DeliveredAt          string   `protobuf:"bytes,11,opt,name=deliveredAt,proto3" json:"deliveredAt,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,12,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{75}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() DeliveryStatus {
+	if m != nil {
+		return m.Status
+	}
+	return DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() string {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetLastAttemptAt() string {
+	if m != nil {
+		return m.LastAttemptAt
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetLastStatusCode() int32 {
+	if m != nil {
+		return m.LastStatusCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetDeliveredAt() string {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesReq struct {
+	WebhookId int64 `protobuf:"varint,1,opt,name=webhookId,proto3" json:"webhookId,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize             int32    `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesReq) Reset()         { *m = ListWebhookDeliveriesReq{} }
+func (m *ListWebhookDeliveriesReq) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesReq) ProtoMessage()    {}
+func (*ListWebhookDeliveriesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{76}
+}
+
+func (m *ListWebhookDeliveriesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesReq.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesReq.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesReq.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesReq) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesReq.Size(m)
+}
+func (m *ListWebhookDeliveriesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesReq proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesReq) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesRes struct {
+	Deliveries           []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesRes) Reset()         { *m = ListWebhookDeliveriesRes{} }
+func (m *ListWebhookDeliveriesRes) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesRes) ProtoMessage()    {}
+func (*ListWebhookDeliveriesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25f40a216b443982, []int{77}
+}
+
+func (m *ListWebhookDeliveriesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesRes.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesRes.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRes.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRes) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesRes.Size(m)
+}
+func (m *ListWebhookDeliveriesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRes proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRes) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("reservations.Weekday", Weekday_name, Weekday_value)
+	proto.RegisterEnum("reservations.ImportOutcome", ImportOutcome_name, ImportOutcome_value)
+	proto.RegisterEnum("reservations.ReservationStatus", ReservationStatus_name, ReservationStatus_value)
+	proto.RegisterEnum("reservations.SeriesMode", SeriesMode_name, SeriesMode_value)
+	proto.RegisterEnum("reservations.ReservationOrder", ReservationOrder_name, ReservationOrder_value)
+	proto.RegisterEnum("reservations.SearchOrder", SearchOrder_name, SearchOrder_value)
+	proto.RegisterEnum("reservations.HoldStatus", HoldStatus_name, HoldStatus_value)
+	proto.RegisterEnum("reservations.DeliveryStatus", DeliveryStatus_name, DeliveryStatus_value)
+	proto.RegisterType((*Empty)(nil), "reservations.Empty")
+	proto.RegisterType((*Library)(nil), "reservations.Library")
+	proto.RegisterType((*LateFeePolicy)(nil), "reservations.LateFeePolicy")
+	proto.RegisterType((*CreateLibraryReq)(nil), "reservations.CreateLibraryReq")
+	proto.RegisterType((*GetLibraryReq)(nil), "reservations.GetLibraryReq")
+	proto.RegisterType((*ListLibrariesRes)(nil), "reservations.ListLibrariesRes")
+	proto.RegisterType((*UpdateLibraryReq)(nil), "reservations.UpdateLibraryReq")
+	proto.RegisterType((*DeleteLibraryReq)(nil), "reservations.DeleteLibraryReq")
+	proto.RegisterType((*OpeningPeriod)(nil), "reservations.OpeningPeriod")
+	proto.RegisterType((*HoursException)(nil), "reservations.HoursException")
+	proto.RegisterType((*OpeningHours)(nil), "reservations.OpeningHours")
+	proto.RegisterType((*GetOpeningHoursReq)(nil), "reservations.GetOpeningHoursReq")
+	proto.RegisterType((*SetOpeningHoursReq)(nil), "reservations.SetOpeningHoursReq")
+	proto.RegisterType((*AddHoursExceptionReq)(nil), "reservations.AddHoursExceptionReq")
+	proto.RegisterType((*DeleteHoursExceptionReq)(nil), "reservations.DeleteHoursExceptionReq")
+	proto.RegisterType((*Book)(nil), "reservations.Book")
+	proto.RegisterType((*Copy)(nil), "reservations.Copy")
+	proto.RegisterType((*AddCopyReq)(nil), "reservations.AddCopyReq")
+	proto.RegisterType((*ListCopiesReq)(nil), "reservations.ListCopiesReq")
+	proto.RegisterType((*ListCopiesRes)(nil), "reservations.ListCopiesRes")
+	proto.RegisterType((*DeleteCopyReq)(nil), "reservations.DeleteCopyReq")
+	proto.RegisterType((*GetAvailabilityReq)(nil), "reservations.GetAvailabilityReq")
+	proto.RegisterType((*Interval)(nil), "reservations.Interval")
+	proto.RegisterType((*CopyAvailability)(nil), "reservations.CopyAvailability")
+	proto.RegisterType((*Availability)(nil), "reservations.Availability")
+	proto.RegisterType((*GetAllBooksReq)(nil), "reservations.GetAllBooksReq")
+	proto.RegisterType((*GetAllBooksRes)(nil), "reservations.GetAllBooksRes")
+	proto.RegisterType((*GetBookReq)(nil), "reservations.GetBookReq")
+	proto.RegisterType((*ReturnBookReq)(nil), "reservations.ReturnBookReq")
+	proto.RegisterType((*ReturnBookRes)(nil), "reservations.ReturnBookRes")
+	proto.RegisterType((*AddBookReq)(nil), "reservations.AddBookReq")
+	proto.RegisterType((*DeleteBookReq)(nil), "reservations.DeleteBookReq")
+	proto.RegisterType((*ImportBooksReq)(nil), "reservations.ImportBooksReq")
+	proto.RegisterType((*ImportedRow)(nil), "reservations.ImportedRow")
+	proto.RegisterType((*ImportBooksRes)(nil), "reservations.ImportBooksRes")
+	proto.RegisterType((*ReserveBookReq)(nil), "reservations.ReserveBookReq")
+	proto.RegisterType((*ReservationSuggestions)(nil), "reservations.ReservationSuggestions")
+	proto.RegisterType((*SuggestedSlot)(nil), "reservations.SuggestedSlot")
+	proto.RegisterType((*BookReservation)(nil), "reservations.BookReservation")
+	proto.RegisterType((*ReserveRecurringReq)(nil), "reservations.ReserveRecurringReq")
+	proto.RegisterType((*ReservationSeries)(nil), "reservations.ReservationSeries")
+	proto.RegisterType((*Occurrence)(nil), "reservations.Occurrence")
+	proto.RegisterType((*ReserveRecurringRes)(nil), "reservations.ReserveRecurringRes")
+	proto.RegisterType((*GetSeriesReq)(nil), "reservations.GetSeriesReq")
+	proto.RegisterType((*CancelSeriesReq)(nil), "reservations.CancelSeriesReq")
+	proto.RegisterType((*ListReservationsReq)(nil), "reservations.ListReservationsReq")
+	proto.RegisterType((*ListReservationsRes)(nil), "reservations.ListReservationsRes")
+	proto.RegisterType((*ListOverdueReq)(nil), "reservations.ListOverdueReq")
+	proto.RegisterType((*GetReservationReq)(nil), "reservations.GetReservationReq")
+	proto.RegisterType((*CheckoutReservationReq)(nil), "reservations.CheckoutReservationReq")
+	proto.RegisterType((*CancelReservationReq)(nil), "reservations.CancelReservationReq")
+	proto.RegisterType((*RescheduleReservationReq)(nil), "reservations.RescheduleReservationReq")
+	proto.RegisterType((*RenewLoanReq)(nil), "reservations.RenewLoanReq")
+	proto.RegisterType((*CheckoutBookReq)(nil), "reservations.CheckoutBookReq")
+	proto.RegisterType((*SearchReq)(nil), "reservations.SearchReq")
+	proto.RegisterType((*SearchRes)(nil), "reservations.SearchRes")
+	proto.RegisterType((*Patron)(nil), "reservations.Patron")
+	proto.RegisterType((*CreatePatronReq)(nil), "reservations.CreatePatronReq")
+	proto.RegisterType((*GetPatronReq)(nil), "reservations.GetPatronReq")
+	proto.RegisterType((*UpdatePatronReq)(nil), "reservations.UpdatePatronReq")
+	proto.RegisterType((*Fee)(nil), "reservations.Fee")
+	proto.RegisterType((*ListFeesReq)(nil), "reservations.ListFeesReq")
+	proto.RegisterType((*ListFeesRes)(nil), "reservations.ListFeesRes")
+	proto.RegisterType((*Hold)(nil), "reservations.Hold")
+	proto.RegisterType((*PlaceHoldReq)(nil), "reservations.PlaceHoldReq")
+	proto.RegisterType((*GetHoldReq)(nil), "reservations.GetHoldReq")
+	proto.RegisterType((*ListHoldsReq)(nil), "reservations.ListHoldsReq")
+	proto.RegisterType((*ListHoldsRes)(nil), "reservations.ListHoldsRes")
+	proto.RegisterType((*CancelHoldReq)(nil), "reservations.CancelHoldReq")
+	proto.RegisterType((*Webhook)(nil), "reservations.Webhook")
+	proto.RegisterType((*CreateWebhookReq)(nil), "reservations.CreateWebhookReq")
+	proto.RegisterType((*GetWebhookReq)(nil), "reservations.GetWebhookReq")
+	proto.RegisterType((*ListWebhooksRes)(nil), "reservations.ListWebhooksRes")
+	proto.RegisterType((*UpdateWebhookReq)(nil), "reservations.UpdateWebhookReq")
+	proto.RegisterType((*DeleteWebhookReq)(nil), "reservations.DeleteWebhookReq")
+	proto.RegisterType((*WebhookDelivery)(nil), "reservations.WebhookDelivery")
+	proto.RegisterType((*ListWebhookDeliveriesReq)(nil), "reservations.ListWebhookDeliveriesReq")
+	proto.RegisterType((*ListWebhookDeliveriesRes)(nil), "reservations.ListWebhookDeliveriesRes")
+}
+
+func init() {
+	proto.RegisterFile("protobufs/reservations.proto", fileDescriptor_25f40a216b443982)
+}
+
+var fileDescriptor_25f40a216b443982 = []byte{
+	// 4135 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3b, 0x4d, 0x73, 0x1b, 0x47,
+	0x76, 0x1e, 0x7c, 0x12, 0x0f, 0x20, 0x09, 0xb5, 0xbe, 0x20, 0x88, 0xa2, 0xe8, 0x91, 0xad, 0xc8,
+	0xd8, 0x8d, 0x18, 0xc9, 0x9b, 0xc4, 0x45, 0x27, 0xe5, 0x82, 0x00, 0x48, 0xc2, 0x86, 0x22, 0x99,
+	0x01, 0xb8, 0x8a, 0xca, 0x5b, 0xa1, 0x87, 0x98, 0x36, 0x89, 0x35, 0x88, 0x81, 0x67, 0x06, 0x92,
+	0xb8, 0x8e, 0x37, 0xa9, 0x54, 0x6d, 0x0e, 0x9b, 0xcb, 0x6e, 0x6d, 0xaa, 0xb2, 0x95, 0x3f, 0x90,
+	0xca, 0x39, 0xb7, 0x24, 0x87, 0xfc, 0x87, 0x54, 0xaa, 0x52, 0x39, 0xa7, 0x72, 0xcc, 0x2d, 0xf7,
+	0xd4, 0xeb, 0x8f, 0x99, 0xee, 0xc6, 0x00, 0x20, 0x6d, 0x1f, 0x72, 0xc3, 0xeb, 0x7e, 0xf3, 0xbe,
+	0xfb, 0x75, 0xf7, 0x7b, 0x0d, 0xd8, 0x98, 0x04, 0x7e, 0xe4, 0x1f, 0x4f, 0x3f, 0x0f, 0xb7, 0x03,
+	0x1a, 0xd2, 0xe0, 0xb5, 0x1b, 0x0d, 0xfd, 0x71, 0xf8, 0x90, 0x0d, 0x93, 0x8a, 0x3a, 0x56, 0xdf,
+	0x38, 0xf1, 0xfd, 0x93, 0x11, 0xdd, 0x76, 0x27, 0xc3, 0x6d, 0x77, 0x3c, 0xf6, 0x23, 0x15, 0xd7,
+	0x2e, 0x42, 0xbe, 0x73, 0x36, 0x89, 0xce, 0xed, 0xff, 0xc8, 0x40, 0x71, 0x77, 0x78, 0x1c, 0xb8,
+	0xc1, 0x39, 0x59, 0x83, 0xcc, 0xd0, 0xab, 0x59, 0x5b, 0xd6, 0x83, 0xac, 0x93, 0x19, 0x7a, 0x84,
+	0x40, 0x6e, 0xec, 0x9e, 0xd1, 0x5a, 0x66, 0xcb, 0x7a, 0x50, 0x72, 0xd8, 0x6f, 0x52, 0x83, 0xa2,
+	0xeb, 0x79, 0x01, 0x0d, 0xc3, 0x5a, 0x96, 0x0d, 0x4b, 0x90, 0x54, 0x21, 0x3b, 0x72, 0xa3, 0x5a,
+	0x6e, 0xcb, 0x7a, 0x90, 0x71, 0xf0, 0x27, 0x1b, 0x19, 0x9f, 0xd4, 0xf2, 0x62, 0x64, 0x7c, 0x42,
+	0xea, 0xb0, 0x12, 0x0d, 0xcf, 0xe8, 0x4f, 0xfd, 0x31, 0xad, 0x15, 0xd8, 0xe7, 0x31, 0x4c, 0xae,
+	0x41, 0x9e, 0x9e, 0xb9, 0xc3, 0x51, 0xad, 0xc8, 0x26, 0x38, 0x80, 0xa3, 0x93, 0x53, 0x44, 0x5f,
+	0xe1, 0xa3, 0x0c, 0x20, 0x1b, 0x50, 0x1a, 0x04, 0xd4, 0x8d, 0xa8, 0xd7, 0x8c, 0x6a, 0x25, 0x36,
+	0x93, 0x0c, 0x90, 0x26, 0xac, 0x8e, 0xdc, 0x88, 0x3e, 0xa5, 0xf4, 0xc0, 0x1f, 0x0d, 0x07, 0xe7,
+	0x35, 0xd8, 0xb2, 0x1e, 0x94, 0x1f, 0xdf, 0x7e, 0xa8, 0x19, 0x6d, 0x57, 0x45, 0x71, 0xf4, 0x2f,
+	0xc8, 0x16, 0x94, 0xcf, 0xdc, 0xb7, 0x0e, 0x1d, 0xd3, 0x37, 0xee, 0x28, 0xac, 0x95, 0xb7, 0xac,
+	0x07, 0x79, 0x47, 0x1d, 0x12, 0x18, 0xbb, 0xbe, 0x3b, 0x6e, 0xbb, 0xe7, 0x61, 0xad, 0x12, 0x63,
+	0xc8, 0x21, 0xfb, 0x08, 0x56, 0x35, 0x1e, 0xe4, 0x06, 0x14, 0x26, 0x34, 0x68, 0xbb, 0xe7, 0xcc,
+	0xc6, 0x19, 0x47, 0x40, 0xe4, 0x3d, 0x58, 0x9d, 0xd0, 0x60, 0x40, 0xc7, 0xd1, 0x01, 0x9f, 0xce,
+	0xb0, 0x69, 0x7d, 0x10, 0xad, 0x79, 0xe6, 0xbe, 0x65, 0x56, 0xcf, 0x38, 0xf8, 0xd3, 0x6e, 0x41,
+	0xb5, 0xc5, 0x94, 0x16, 0x0e, 0x74, 0xe8, 0x97, 0x64, 0x1b, 0x8a, 0x23, 0x0e, 0x31, 0x26, 0xe5,
+	0xc7, 0xd7, 0x0d, 0xad, 0x05, 0xaa, 0xc4, 0xb2, 0xef, 0xc2, 0xea, 0x33, 0x1a, 0x29, 0x14, 0x8c,
+	0x28, 0xb0, 0x9f, 0x41, 0x75, 0x77, 0x18, 0x0a, 0x8c, 0x21, 0x0d, 0x1d, 0x1a, 0x92, 0x0f, 0xa1,
+	0x34, 0x92, 0x70, 0xcd, 0xda, 0xca, 0xce, 0xe7, 0x93, 0xe0, 0xa1, 0xb8, 0x87, 0x13, 0xef, 0x5b,
+	0x8a, 0x6b, 0x43, 0xb5, 0x4d, 0x47, 0x34, 0xa2, 0x0b, 0x24, 0x1e, 0xc3, 0xea, 0xfe, 0x84, 0x8e,
+	0x87, 0xe3, 0x93, 0x03, 0x1a, 0x0c, 0x7d, 0x0f, 0xb9, 0xbc, 0xa1, 0xf4, 0x0b, 0x4f, 0x58, 0x7e,
+	0xcd, 0xe4, 0xf2, 0x92, 0x4f, 0x3a, 0x12, 0x0b, 0xa3, 0xce, 0x9f, 0xd0, 0x71, 0x28, 0x42, 0x9f,
+	0x03, 0xe8, 0xbf, 0xc1, 0xc8, 0x0f, 0xa9, 0x0c, 0x7d, 0x01, 0xd9, 0xbf, 0xb1, 0x60, 0xed, 0xb9,
+	0x3f, 0x0d, 0xc2, 0xce, 0xdb, 0x01, 0x9d, 0x20, 0xc9, 0x99, 0xa5, 0xb4, 0x21, 0x0d, 0x76, 0xde,
+	0xf5, 0x18, 0xd1, 0xac, 0x93, 0x0c, 0xe0, 0x42, 0x43, 0xbb, 0x08, 0xb2, 0xec, 0x77, 0x22, 0x42,
+	0x2e, 0x5d, 0x84, 0xbc, 0x2a, 0x02, 0x8e, 0x07, 0xd4, 0x0d, 0xfd, 0xb1, 0x58, 0x56, 0x02, 0xb2,
+	0xff, 0xd9, 0x82, 0x8a, 0xb0, 0x05, 0x93, 0x50, 0x17, 0xc4, 0x32, 0x05, 0x51, 0xd7, 0x67, 0xc6,
+	0x58, 0x9f, 0x1f, 0x42, 0x01, 0xcd, 0x33, 0x3a, 0xaf, 0x65, 0xb7, 0xb2, 0xb3, 0xcb, 0x49, 0xb3,
+	0xb8, 0x23, 0x50, 0xc9, 0x1f, 0x00, 0x50, 0x69, 0x14, 0x54, 0x05, 0x3f, 0xdc, 0xd0, 0x3f, 0xd4,
+	0x2d, 0xe7, 0x28, 0xf8, 0xf6, 0x63, 0x20, 0xcf, 0x68, 0xa4, 0xca, 0x8f, 0xee, 0x5e, 0xa8, 0x82,
+	0x7d, 0x02, 0xa4, 0x77, 0xc9, 0x6f, 0x14, 0xd5, 0x32, 0x17, 0x56, 0xcd, 0x76, 0xe0, 0x5a, 0xd3,
+	0xf3, 0x0c, 0xe9, 0xe9, 0x97, 0x64, 0x07, 0x4a, 0xb1, 0x0a, 0x22, 0xa8, 0x17, 0x6b, 0x9c, 0xa0,
+	0xdb, 0xcf, 0xe0, 0x26, 0x8f, 0xee, 0x59, 0xb2, 0x8b, 0x35, 0xe0, 0xf1, 0x96, 0x89, 0x97, 0xc0,
+	0x5f, 0x64, 0x20, 0xf7, 0xc4, 0xf7, 0xbf, 0xc0, 0xd0, 0x1a, 0x86, 0xc7, 0x5c, 0x90, 0x92, 0xc3,
+	0x7e, 0xcb, 0x4c, 0x9d, 0x99, 0xc9, 0xd4, 0xd9, 0x24, 0x53, 0xd7, 0x92, 0x85, 0xc9, 0x03, 0x50,
+	0x82, 0x2c, 0x23, 0x07, 0xc3, 0x01, 0x15, 0x79, 0x9d, 0x03, 0xe4, 0x01, 0xac, 0xbb, 0xaf, 0xdd,
+	0xe1, 0xc8, 0x3d, 0x1e, 0xd1, 0x96, 0x3f, 0xc1, 0xbc, 0x50, 0x60, 0x29, 0xd1, 0x1c, 0xd6, 0x15,
+	0x29, 0x9a, 0x8a, 0xdc, 0x87, 0x35, 0x6f, 0x18, 0x46, 0xee, 0x78, 0x40, 0x5f, 0xd0, 0x88, 0x06,
+	0x21, 0x4b, 0xfc, 0x19, 0xc7, 0x18, 0xc5, 0xf4, 0x1b, 0xf9, 0x91, 0x3b, 0x12, 0xbc, 0x4a, 0x3c,
+	0xfd, 0x2a, 0x43, 0xf6, 0xbf, 0x58, 0x90, 0x6b, 0xf9, 0x93, 0xd4, 0x6d, 0x8d, 0x99, 0x24, 0xa3,
+	0x98, 0x44, 0x51, 0x37, 0xab, 0xab, 0x5b, 0x87, 0x95, 0x91, 0x3f, 0x60, 0x9e, 0x13, 0x96, 0x88,
+	0x61, 0xfc, 0xea, 0xd8, 0x0d, 0x06, 0xbe, 0x47, 0xc5, 0x72, 0x94, 0xa0, 0x34, 0x71, 0x61, 0xc6,
+	0xc4, 0xc5, 0xc4, 0xc4, 0x9a, 0x21, 0x56, 0xcc, 0x38, 0xfe, 0x01, 0x40, 0xd3, 0xf3, 0x50, 0x01,
+	0xf4, 0xfe, 0x7d, 0xc8, 0x0d, 0xfc, 0x89, 0x4c, 0x92, 0x44, 0x8f, 0x27, 0x86, 0xc4, 0xe6, 0xed,
+	0x7b, 0xb0, 0x8a, 0xc9, 0x9a, 0x9b, 0x00, 0x3f, 0x4c, 0xf1, 0xbf, 0xfd, 0xb1, 0x8e, 0x14, 0x92,
+	0x06, 0x14, 0x06, 0xfe, 0x24, 0xc9, 0xe5, 0x69, 0xf4, 0x05, 0x06, 0xee, 0x17, 0x3c, 0x44, 0xa5,
+	0x68, 0x66, 0xf6, 0xfd, 0x3b, 0x8b, 0xad, 0xda, 0x26, 0x77, 0xfb, 0x70, 0x34, 0x8c, 0xce, 0xe7,
+	0x08, 0x82, 0x63, 0x9f, 0x07, 0xfe, 0x99, 0xf4, 0x04, 0xfe, 0x46, 0x72, 0x91, 0x2f, 0x9c, 0x90,
+	0x89, 0x7c, 0xf2, 0x10, 0xc8, 0xd9, 0x70, 0xdc, 0x9e, 0x06, 0x4c, 0x98, 0x17, 0xc3, 0xf1, 0x34,
+	0xa2, 0x3c, 0x29, 0xe6, 0x9d, 0x94, 0x19, 0xdd, 0xaa, 0x79, 0xd3, 0xaa, 0x8f, 0x61, 0xa5, 0x3b,
+	0x8e, 0x50, 0x35, 0x76, 0xb4, 0x08, 0x23, 0x37, 0x88, 0x84, 0x48, 0x1c, 0x40, 0x3f, 0xd1, 0xb1,
+	0x27, 0x44, 0xc2, 0x9f, 0xf6, 0x3f, 0x59, 0x50, 0x45, 0x65, 0x55, 0x8d, 0x58, 0x22, 0xf6, 0x27,
+	0xc9, 0x5a, 0x14, 0xd0, 0x92, 0x44, 0x3f, 0x3f, 0xcc, 0x1a, 0x68, 0x0a, 0x4a, 0x45, 0x8a, 0xbc,
+	0xa1, 0x3b, 0x40, 0x8a, 0xec, 0x30, 0x1c, 0xc4, 0x3d, 0x9e, 0x86, 0xe7, 0xb5, 0xfc, 0x62, 0x5c,
+	0xc4, 0xb1, 0x7f, 0x06, 0x15, 0x4d, 0xee, 0x6f, 0xea, 0x86, 0xdf, 0x8b, 0x43, 0x84, 0x4b, 0xb8,
+	0x39, 0x1b, 0x22, 0x9a, 0xc7, 0x65, 0xb8, 0xfc, 0x10, 0xd6, 0x30, 0x18, 0x46, 0x23, 0xcc, 0x46,
+	0x2c, 0x22, 0xeb, 0xb0, 0x32, 0x71, 0x4f, 0x68, 0x6f, 0xf8, 0x53, 0xca, 0xa4, 0xc8, 0x3b, 0x31,
+	0x8c, 0xd6, 0xc3, 0xdf, 0x7d, 0xff, 0x0b, 0x2a, 0xd7, 0x67, 0x32, 0x60, 0x7f, 0x66, 0xd0, 0x0a,
+	0xc9, 0x03, 0xc8, 0x1f, 0xe3, 0xef, 0xf4, 0xb8, 0x45, 0x34, 0x87, 0x23, 0xe0, 0x19, 0x6b, 0x4c,
+	0xdf, 0x46, 0x07, 0x06, 0x75, 0x7d, 0xd0, 0xde, 0x02, 0x78, 0x46, 0x23, 0xf6, 0xdd, 0xfc, 0xb5,
+	0xe3, 0xd0, 0x68, 0x1a, 0x8c, 0x17, 0x20, 0x29, 0xc1, 0x91, 0x51, 0x83, 0xc3, 0xfe, 0x2b, 0x4b,
+	0xff, 0x3a, 0x24, 0x9f, 0x40, 0x59, 0x11, 0x59, 0x2c, 0xef, 0x3b, 0x29, 0x6a, 0x24, 0x03, 0x8e,
+	0xfa, 0x05, 0x8b, 0x28, 0x7e, 0xc8, 0x14, 0xf9, 0x5c, 0x82, 0x68, 0x67, 0xcf, 0x3d, 0x0f, 0x77,
+	0xe5, 0xc1, 0x22, 0xef, 0xc4, 0xb0, 0x48, 0x2e, 0x52, 0x85, 0xfb, 0x90, 0x43, 0x23, 0xa5, 0x27,
+	0x17, 0x86, 0xc4, 0xe6, 0xed, 0x7b, 0x72, 0xe9, 0x2f, 0x32, 0xd0, 0x31, 0xac, 0x75, 0xcf, 0x26,
+	0x7e, 0x10, 0xc5, 0x0e, 0xbf, 0x20, 0x79, 0xb4, 0x9a, 0x17, 0x9c, 0x3b, 0x53, 0xee, 0x9b, 0x15,
+	0x47, 0x40, 0xb8, 0x22, 0x03, 0xff, 0x8d, 0xd0, 0x01, 0x7f, 0xe2, 0x81, 0xab, 0xcc, 0x99, 0x50,
+	0xcf, 0xf1, 0xdf, 0x48, 0x0c, 0x2b, 0xc6, 0x48, 0xcd, 0xf1, 0xbf, 0x0b, 0x45, 0x7f, 0x1a, 0x0d,
+	0xfc, 0x33, 0x6e, 0x8f, 0x35, 0x73, 0x9b, 0xe7, 0x14, 0xf7, 0x39, 0x8a, 0x23, 0x71, 0xd9, 0xbd,
+	0x24, 0x08, 0xfc, 0x40, 0x1e, 0xc4, 0x18, 0xa0, 0x1c, 0xb8, 0xf2, 0xda, 0x81, 0xeb, 0xef, 0x2d,
+	0x43, 0xff, 0x10, 0x5d, 0x24, 0xee, 0x26, 0x42, 0x42, 0x09, 0xe2, 0xcc, 0x94, 0x9d, 0x88, 0x79,
+	0xa0, 0xe4, 0x1d, 0x09, 0xa2, 0xf3, 0x02, 0xfa, 0x13, 0x3a, 0xc0, 0x29, 0xe1, 0x3c, 0x09, 0x2b,
+	0x76, 0xca, 0x69, 0x76, 0xfa, 0x6d, 0xc8, 0x05, 0xfe, 0x9b, 0x50, 0xa4, 0x85, 0x5b, 0x69, 0xca,
+	0x31, 0x73, 0x39, 0x0c, 0xcd, 0xfe, 0xa5, 0x05, 0x6b, 0x3c, 0xac, 0x16, 0xf9, 0x13, 0x97, 0x24,
+	0x4b, 0x8c, 0x6d, 0x8c, 0x23, 0xb1, 0x24, 0xe3, 0x01, 0xd4, 0x80, 0x8e, 0xbd, 0x76, 0x72, 0x78,
+	0x95, 0x20, 0x5f, 0xe6, 0x51, 0xe0, 0x8f, 0xbb, 0x1e, 0x93, 0x33, 0xeb, 0xc4, 0xb0, 0xb2, 0x3e,
+	0xf2, 0xda, 0xfa, 0x78, 0x03, 0x37, 0x94, 0x40, 0xef, 0x4d, 0x4f, 0x4e, 0x68, 0x88, 0xbf, 0x42,
+	0xf2, 0x08, 0xf2, 0xe1, 0xc8, 0x8f, 0xe4, 0x42, 0x37, 0x3c, 0x27, 0x30, 0xa9, 0xd7, 0x1b, 0xf9,
+	0x91, 0xc3, 0x31, 0x71, 0x53, 0x1b, 0x53, 0x37, 0x38, 0x96, 0x87, 0xba, 0xb4, 0xc0, 0x13, 0x18,
+	0xf6, 0xdf, 0x5a, 0xb0, 0xaa, 0x11, 0xf9, 0xce, 0xf3, 0xbb, 0x66, 0xc6, 0xdc, 0x02, 0x33, 0xe6,
+	0x35, 0x33, 0xda, 0xff, 0x93, 0x85, 0x75, 0x23, 0x01, 0x5c, 0xe8, 0x40, 0xa3, 0xf1, 0xcb, 0x2e,
+	0xe0, 0x97, 0xd3, 0xdd, 0xf6, 0xfb, 0x50, 0x08, 0x23, 0x37, 0x9a, 0xf2, 0x0b, 0xc6, 0xda, 0xe3,
+	0xbb, 0xba, 0xd5, 0x54, 0xf7, 0x30, 0x34, 0x47, 0xa0, 0xeb, 0x57, 0xf2, 0x82, 0x79, 0x25, 0x57,
+	0x0c, 0x53, 0xd4, 0x0d, 0x63, 0x43, 0x65, 0x70, 0x4a, 0x07, 0x5f, 0x50, 0x6f, 0x7f, 0x1a, 0x35,
+	0x23, 0x71, 0xcf, 0xd7, 0xc6, 0xb4, 0x58, 0x2a, 0x19, 0xb1, 0xa4, 0x7d, 0xff, 0x84, 0xdf, 0xf5,
+	0xb3, 0x8e, 0x36, 0xa6, 0x38, 0xb3, 0x3c, 0xdf, 0x99, 0x15, 0xd3, 0x99, 0x1b, 0x50, 0xf2, 0x5f,
+	0xd3, 0xc0, 0x9b, 0xd2, 0x66, 0x54, 0x5b, 0xe5, 0x1a, 0xc5, 0x03, 0x64, 0x13, 0x20, 0x60, 0xa9,
+	0x9c, 0x29, 0xbc, 0xc6, 0xa6, 0x95, 0x11, 0xbe, 0x82, 0x45, 0xf9, 0x60, 0x5d, 0xae, 0x60, 0x0e,
+	0xe3, 0x5c, 0x48, 0x83, 0x21, 0x0d, 0xbb, 0x5e, 0xad, 0xca, 0xf5, 0x91, 0xb0, 0xfd, 0xbf, 0x16,
+	0x5c, 0x15, 0xcb, 0xd2, 0xa1, 0x83, 0x69, 0x10, 0x0c, 0xc7, 0x27, 0xdf, 0xf5, 0xda, 0xbc, 0x06,
+	0xf9, 0x20, 0x98, 0x8e, 0xa4, 0xf3, 0x39, 0xa0, 0x5d, 0xfe, 0xf2, 0xc6, 0xe5, 0x4f, 0xf5, 0x40,
+	0x61, 0xee, 0x6a, 0x2e, 0x6a, 0xd6, 0xfd, 0x3e, 0xe4, 0xce, 0xf0, 0x68, 0xbc, 0xc2, 0x02, 0xa9,
+	0x66, 0x2c, 0x59, 0xa6, 0xef, 0x0b, 0xdf, 0xa3, 0x0e, 0xc3, 0xb2, 0xff, 0x35, 0x03, 0x57, 0xd4,
+	0xe8, 0x62, 0xf3, 0x17, 0x0a, 0x75, 0x55, 0xb6, 0xac, 0x21, 0xdb, 0xe5, 0x35, 0xd5, 0x6c, 0x5a,
+	0x58, 0x60, 0xd3, 0xa2, 0x6e, 0x53, 0x2d, 0xfe, 0x57, 0xcc, 0xf8, 0xdf, 0x82, 0xf2, 0x00, 0x6f,
+	0x2f, 0xa3, 0x91, 0x52, 0xb2, 0x52, 0x87, 0x48, 0x13, 0xb4, 0xfa, 0x5d, 0x0d, 0xb6, 0xb2, 0xcb,
+	0x8f, 0x02, 0xda, 0x27, 0xf6, 0x3f, 0x5a, 0x00, 0xfb, 0x03, 0x8c, 0x19, 0x3a, 0x1e, 0x18, 0x9a,
+	0x58, 0x0b, 0x34, 0xc9, 0xe8, 0x9a, 0x18, 0x67, 0x92, 0xec, 0xa5, 0xcf, 0x24, 0x97, 0xdb, 0x31,
+	0x7f, 0x91, 0x1a, 0xf0, 0x21, 0xcb, 0x44, 0x54, 0x14, 0x98, 0x50, 0x82, 0x05, 0x99, 0x88, 0xa1,
+	0x39, 0x02, 0x9d, 0xec, 0x40, 0xd9, 0x8f, 0xad, 0x10, 0x8a, 0xec, 0x6f, 0x84, 0x5f, 0x62, 0x26,
+	0x47, 0x45, 0xb6, 0x37, 0xa1, 0xf2, 0x8c, 0x46, 0x82, 0x60, 0xca, 0xe5, 0xe6, 0x5d, 0x58, 0x6f,
+	0x31, 0xa7, 0xcd, 0x47, 0xf9, 0xef, 0x0c, 0x5c, 0xc5, 0xeb, 0x95, 0x22, 0xe0, 0xbc, 0x9b, 0x98,
+	0x9a, 0x16, 0x33, 0x0b, 0xf6, 0x8b, 0x4b, 0xe4, 0xef, 0x8f, 0x61, 0x85, 0x27, 0x64, 0xca, 0x0f,
+	0x02, 0x17, 0xc8, 0xe0, 0xf1, 0x07, 0xe4, 0x23, 0x28, 0xfa, 0x81, 0x47, 0x83, 0x27, 0xe7, 0x2c,
+	0xf2, 0xd7, 0x1e, 0x6f, 0xce, 0xfd, 0x76, 0x1f, 0xf1, 0x1c, 0x89, 0xae, 0x1d, 0xea, 0x8b, 0x8b,
+	0x0e, 0xf5, 0x2b, 0xc6, 0xa1, 0x7e, 0x61, 0x6e, 0xd7, 0xf2, 0x33, 0x98, 0x77, 0xb9, 0x9f, 0xa5,
+	0xd9, 0x39, 0x9c, 0x59, 0x48, 0xd6, 0xa5, 0x17, 0xd2, 0x05, 0x2f, 0x0b, 0xa7, 0xb0, 0x86, 0xfc,
+	0xf7, 0xf9, 0x96, 0xb0, 0xbc, 0x46, 0xa3, 0xda, 0x28, 0xb3, 0xc8, 0x46, 0x59, 0xf3, 0xe2, 0x73,
+	0x0f, 0xae, 0x3c, 0xa3, 0xaa, 0xa2, 0x69, 0x71, 0xd7, 0x86, 0x1b, 0x2d, 0xdc, 0xf4, 0xfc, 0xe9,
+	0x12, 0x4c, 0xcd, 0xe4, 0x19, 0xdd, 0xe4, 0xf6, 0x7d, 0xb8, 0xc6, 0x03, 0x7c, 0x09, 0xb7, 0x63,
+	0xa8, 0x39, 0x34, 0x1c, 0x9c, 0x52, 0x6f, 0x3a, 0xa2, 0x4b, 0xf8, 0x7d, 0xc3, 0x6d, 0xca, 0xfe,
+	0x08, 0x2a, 0xac, 0xdc, 0x8e, 0x15, 0xf5, 0x34, 0xba, 0x73, 0x53, 0x98, 0xfd, 0x2b, 0x0b, 0xd6,
+	0xa5, 0x31, 0xfe, 0xbf, 0x1c, 0x6e, 0xff, 0x33, 0x03, 0xa5, 0x1e, 0x75, 0x83, 0xc1, 0x29, 0x4a,
+	0x23, 0x0a, 0x44, 0xd6, 0x4c, 0x81, 0x28, 0x93, 0x14, 0x88, 0x70, 0xf3, 0x72, 0xc7, 0x27, 0x54,
+	0xd4, 0xe5, 0x38, 0xf0, 0x4d, 0x4f, 0x92, 0x5a, 0xf8, 0x15, 0x16, 0x85, 0x5f, 0x31, 0x65, 0x89,
+	0x9e, 0x0d, 0xc7, 0x07, 0xac, 0xe8, 0xc7, 0xab, 0x71, 0x31, 0xcc, 0xe6, 0xdc, 0xb7, 0x7c, 0xae,
+	0x24, 0xe6, 0x04, 0x8c, 0x47, 0xa4, 0x38, 0xfa, 0xf9, 0x86, 0x96, 0x75, 0x94, 0x11, 0xd4, 0x0f,
+	0xbd, 0x80, 0xed, 0x95, 0x2c, 0xee, 0x13, 0x0c, 0x20, 0x1f, 0x26, 0x49, 0xa8, 0xc2, 0x92, 0xd0,
+	0x2d, 0xf3, 0xe4, 0x80, 0x36, 0xd4, 0xf3, 0x8f, 0xfd, 0x69, 0x62, 0xdb, 0xef, 0xbe, 0x2a, 0xf0,
+	0x4b, 0x0b, 0x0a, 0x07, 0xcc, 0xbd, 0x17, 0x6a, 0x91, 0xc5, 0x8d, 0xac, 0x6c, 0x6a, 0x23, 0x2b,
+	0x37, 0xb7, 0x91, 0x95, 0x4f, 0x39, 0x35, 0x1f, 0xbb, 0x23, 0x5c, 0x8e, 0xa2, 0x92, 0x28, 0x41,
+	0xfb, 0x13, 0x58, 0xe7, 0xad, 0x1f, 0x2e, 0x17, 0x46, 0xd4, 0xf7, 0xa1, 0xc0, 0x63, 0x50, 0xec,
+	0x97, 0xd7, 0x74, 0xb5, 0x05, 0xa2, 0xc0, 0x11, 0x1b, 0x5d, 0xf2, 0xb5, 0xb9, 0xbe, 0x3f, 0x81,
+	0x75, 0xde, 0xac, 0xf9, 0xa6, 0x0c, 0xfe, 0xc1, 0x82, 0x2c, 0x96, 0x21, 0x2e, 0x91, 0x7c, 0xd0,
+	0x1d, 0x0a, 0xc9, 0xf8, 0x38, 0xa7, 0x0f, 0xe2, 0x02, 0x73, 0xcf, 0xfc, 0xe9, 0x58, 0xf6, 0x1a,
+	0x05, 0xa4, 0x15, 0x3c, 0xf2, 0x7a, 0xc1, 0x63, 0xf1, 0xed, 0xc4, 0xfe, 0x00, 0xca, 0x98, 0xc9,
+	0x9f, 0x52, 0x9a, 0x54, 0xa8, 0x84, 0x88, 0x96, 0x91, 0x1f, 0xf7, 0x54, 0xd4, 0x90, 0xbc, 0x0f,
+	0xb9, 0xcf, 0x69, 0x5c, 0x37, 0xbd, 0xa2, 0x5b, 0xe4, 0x29, 0xa5, 0x0e, 0x9b, 0x56, 0x1d, 0x99,
+	0xd1, 0x1d, 0xf9, 0x8b, 0x0c, 0xe4, 0x9e, 0xfb, 0x23, 0xef, 0x5b, 0x9f, 0x74, 0xb5, 0xbd, 0x27,
+	0x97, 0xda, 0x61, 0x3a, 0x0f, 0x85, 0x5d, 0xd8, 0x6f, 0xf2, 0x3b, 0xf1, 0x55, 0xaf, 0x90, 0x76,
+	0x42, 0x47, 0xa9, 0x8c, 0x3b, 0xde, 0x8c, 0x7f, 0x8a, 0x69, 0xfe, 0x59, 0x7c, 0x12, 0x46, 0x1d,
+	0xfc, 0x70, 0x88, 0xb8, 0xa2, 0x6a, 0x1f, 0xc3, 0xf6, 0x04, 0x2a, 0x07, 0x23, 0x77, 0x40, 0x91,
+	0xf5, 0xbc, 0x94, 0xbd, 0x28, 0x7e, 0xa4, 0x96, 0x59, 0x45, 0xcb, 0x85, 0x76, 0xb1, 0x37, 0x58,
+	0xc1, 0x4f, 0xf2, 0x33, 0x17, 0x81, 0x0d, 0x15, 0x74, 0x36, 0x4e, 0xcf, 0x2d, 0xa6, 0x7f, 0xa4,
+	0xe1, 0xb0, 0xe4, 0x73, 0x8a, 0xbf, 0xd3, 0x93, 0x0f, 0xe3, 0xc4, 0x11, 0xb0, 0x92, 0xce, 0xb7,
+	0xda, 0x79, 0xec, 0x7f, 0x65, 0x41, 0xf1, 0x25, 0x3d, 0x3e, 0xc5, 0xe2, 0x98, 0x31, 0x87, 0xbb,
+	0xc5, 0x34, 0x18, 0xc9, 0x32, 0xf5, 0x34, 0x18, 0xe1, 0xb2, 0xa0, 0xaf, 0xe9, 0x38, 0x0a, 0x59,
+	0x7f, 0xae, 0xe4, 0x08, 0x08, 0xc7, 0x43, 0x3a, 0x08, 0x68, 0x24, 0x32, 0x8f, 0x80, 0x70, 0xdc,
+	0x1d, 0x44, 0xc3, 0xd7, 0x7c, 0xb1, 0xac, 0x38, 0x02, 0x5a, 0xb2, 0x54, 0xe2, 0x9e, 0xb3, 0x10,
+	0x4c, 0x34, 0x71, 0xdf, 0x70, 0x28, 0xbd, 0x89, 0x2b, 0x51, 0x25, 0x96, 0xe8, 0x39, 0x2b, 0x14,
+	0x66, 0xcf, 0x32, 0xeb, 0x68, 0x54, 0x81, 0xc1, 0xec, 0xfa, 0x08, 0x56, 0xc4, 0xe7, 0x73, 0x3a,
+	0xce, 0x92, 0x5c, 0x8c, 0x96, 0x34, 0x9c, 0xbf, 0x8d, 0xac, 0x71, 0xc3, 0x79, 0x81, 0xb8, 0xbf,
+	0xce, 0xc2, 0xba, 0x98, 0x6e, 0xd3, 0xd1, 0xf0, 0x35, 0x4d, 0x79, 0x4c, 0xb1, 0x01, 0x25, 0x41,
+	0x32, 0x29, 0x1c, 0xc5, 0x03, 0x6c, 0xcf, 0x40, 0x77, 0xc5, 0x7b, 0x06, 0x02, 0x98, 0x36, 0x26,
+	0xee, 0xf9, 0xc8, 0x77, 0x3d, 0x79, 0xcc, 0x17, 0x20, 0xf9, 0x81, 0x51, 0xa6, 0x31, 0x3a, 0x8c,
+	0x52, 0x0a, 0x63, 0xfd, 0xd6, 0x61, 0xc5, 0x8d, 0x22, 0x7a, 0x36, 0x89, 0x64, 0x77, 0x2e, 0x86,
+	0xe5, 0x56, 0xd8, 0xe4, 0x70, 0x33, 0x12, 0xc7, 0x00, 0x7d, 0x10, 0xb1, 0x46, 0x6e, 0xa8, 0x60,
+	0xf1, 0xf5, 0xad, 0x0f, 0x62, 0x13, 0x0f, 0x07, 0x38, 0xf7, 0x16, 0xd6, 0x00, 0xf8, 0x4a, 0x37,
+	0x46, 0xd9, 0xda, 0x74, 0xc3, 0xa8, 0xc3, 0x2e, 0x8b, 0xc0, 0x43, 0x2d, 0x1e, 0xc0, 0x3b, 0xb3,
+	0xc7, 0xf5, 0x60, 0xa1, 0x58, 0xe6, 0x77, 0x66, 0x65, 0x48, 0x0f, 0xd5, 0x8a, 0x19, 0xaa, 0x7d,
+	0xa8, 0x29, 0x41, 0x24, 0x4c, 0x22, 0x2e, 0x6d, 0x9a, 0x37, 0x2c, 0xd3, 0x1b, 0x0b, 0x4e, 0xea,
+	0xf6, 0xab, 0xb9, 0x54, 0x43, 0xf2, 0x87, 0x00, 0x5e, 0x3c, 0x90, 0x7e, 0xf1, 0x30, 0xc2, 0xc4,
+	0x51, 0x3e, 0x68, 0x50, 0x5c, 0xee, 0xfc, 0x01, 0x02, 0x40, 0xa1, 0x77, 0xb8, 0xd7, 0x6e, 0xbe,
+	0xaa, 0xbe, 0x83, 0xbf, 0x5f, 0xec, 0xb3, 0xdf, 0x16, 0x29, 0x43, 0xb1, 0x7f, 0xd8, 0xe9, 0x21,
+	0x90, 0x21, 0xab, 0x50, 0x7a, 0xd9, 0x69, 0xef, 0x71, 0x30, 0x4b, 0x2a, 0xb0, 0xd2, 0x7f, 0x7e,
+	0xe8, 0x30, 0x28, 0x87, 0x5f, 0x3d, 0x75, 0xba, 0xf8, 0x3b, 0x8f, 0x33, 0xbd, 0x66, 0xff, 0xd0,
+	0x41, 0xa8, 0xd0, 0x78, 0x09, 0xab, 0x5a, 0xa9, 0x9b, 0x6c, 0x42, 0xbd, 0xfb, 0xe2, 0x60, 0xdf,
+	0xe9, 0x1f, 0xed, 0x1f, 0xf6, 0x5b, 0xfb, 0x2f, 0x3a, 0x47, 0x87, 0x7b, 0xbd, 0x83, 0x4e, 0xab,
+	0xfb, 0xb4, 0xdb, 0x69, 0x57, 0xdf, 0x41, 0xa6, 0x2d, 0xa7, 0xd3, 0xec, 0x77, 0xda, 0x5c, 0x82,
+	0xc3, 0x83, 0x36, 0x03, 0x32, 0x48, 0xd8, 0xe9, 0xfc, 0xb0, 0xd3, 0x42, 0x28, 0xdb, 0x08, 0xf5,
+	0x0a, 0x0e, 0x8f, 0x39, 0x1b, 0x36, 0x9d, 0x4e, 0xaf, 0xe3, 0xfc, 0xa8, 0xd9, 0xef, 0xee, 0xef,
+	0x1d, 0xf5, 0xfa, 0xcd, 0xfe, 0x61, 0xcf, 0x60, 0xc0, 0xc8, 0x20, 0x0e, 0xe3, 0xb0, 0x0e, 0xe5,
+	0xd6, 0xf3, 0x4e, 0xeb, 0x8f, 0x3a, 0x6d, 0x94, 0x47, 0x72, 0xe9, 0x1f, 0x3a, 0x7b, 0xc8, 0x05,
+	0xb5, 0x6e, 0x35, 0xf7, 0x5a, 0x9d, 0xdd, 0xdd, 0x4e, 0xbb, 0x9a, 0x6b, 0x3c, 0x02, 0x48, 0x6a,
+	0x49, 0x84, 0xc0, 0x5a, 0x73, 0x77, 0xf7, 0x68, 0xdf, 0x39, 0xda, 0xdb, 0xef, 0x3f, 0xef, 0xee,
+	0x3d, 0xab, 0xbe, 0x83, 0xf4, 0x9e, 0x74, 0x7a, 0xfd, 0xa3, 0xce, 0xd3, 0xa7, 0xfb, 0x4e, 0xbf,
+	0x6a, 0x35, 0xfa, 0x50, 0x35, 0x6f, 0xb2, 0x48, 0xb5, 0xd7, 0x6f, 0x3a, 0xfd, 0xa3, 0x66, 0xaf,
+	0x55, 0x7d, 0x87, 0xac, 0x01, 0x70, 0xb0, 0xdd, 0xe9, 0xb5, 0x84, 0x4c, 0xdc, 0x04, 0x0c, 0x21,
+	0x43, 0xaa, 0x50, 0x91, 0x03, 0x0c, 0x25, 0xdb, 0xf8, 0x08, 0xca, 0xca, 0xd1, 0x14, 0x85, 0x6e,
+	0x77, 0x7b, 0x7d, 0x94, 0xb4, 0xfa, 0x0e, 0x29, 0x41, 0xfe, 0xc0, 0xe9, 0xb6, 0x3a, 0x55, 0x0b,
+	0xbf, 0xdc, 0xed, 0x3e, 0x71, 0x9a, 0xce, 0xab, 0xa3, 0xbd, 0xe6, 0x8b, 0x4e, 0x35, 0xd3, 0x78,
+	0x05, 0x90, 0x6c, 0xb6, 0xe4, 0x36, 0xdc, 0x7c, 0xbe, 0xbf, 0xdb, 0x4e, 0xb7, 0x54, 0x19, 0x8a,
+	0x2f, 0x9b, 0xdd, 0x3e, 0x2a, 0x66, 0xa1, 0xcc, 0x4f, 0x0f, 0x77, 0x9f, 0x76, 0x99, 0x25, 0x32,
+	0xa8, 0x3b, 0xfb, 0x30, 0xb1, 0x4e, 0xb6, 0xf1, 0x0a, 0xd6, 0xf4, 0x5c, 0x40, 0xee, 0xc2, 0xed,
+	0x76, 0x67, 0xb7, 0xfb, 0xa3, 0x8e, 0xf3, 0x6a, 0x2e, 0x8b, 0x83, 0xce, 0x5e, 0x3b, 0x66, 0x21,
+	0xb0, 0x19, 0x0b, 0x0c, 0xaa, 0x66, 0x97, 0x91, 0x7e, 0xfc, 0xef, 0xf7, 0xa1, 0xac, 0x56, 0xa5,
+	0x3f, 0x87, 0x55, 0xed, 0x35, 0x12, 0x31, 0x5b, 0x84, 0xc6, 0x53, 0xa5, 0x7a, 0xfa, 0x53, 0x1f,
+	0x7b, 0xf3, 0x2f, 0xff, 0xed, 0xbf, 0x7e, 0x9d, 0xa9, 0xd9, 0xab, 0xdb, 0xaf, 0x1f, 0x6d, 0xc7,
+	0xaf, 0x87, 0x76, 0xe2, 0xca, 0xc8, 0x8f, 0xd9, 0x96, 0x2d, 0x99, 0x18, 0x9d, 0x00, 0xed, 0x29,
+	0xd3, 0x3c, 0x0e, 0x75, 0xc6, 0xe1, 0x1a, 0x21, 0x1a, 0x87, 0xed, 0xaf, 0x86, 0xde, 0xd7, 0xe4,
+	0x53, 0xde, 0x1b, 0x8f, 0x5f, 0x3b, 0x91, 0xab, 0x3a, 0x0d, 0xf6, 0x6a, 0xae, 0xbe, 0x69, 0x12,
+	0xd6, 0xdf, 0x47, 0xd9, 0xd7, 0x19, 0x87, 0x75, 0xa2, 0xeb, 0x40, 0x42, 0x58, 0xd5, 0x5e, 0x40,
+	0x99, 0x26, 0x32, 0x9f, 0x47, 0xcd, 0x53, 0xe0, 0x7b, 0x8c, 0xfc, 0xfb, 0xf5, 0xba, 0xa1, 0x80,
+	0x30, 0xd1, 0xc3, 0xa1, 0xf7, 0x75, 0x62, 0xaf, 0xcf, 0x64, 0xd7, 0x6e, 0x0e, 0x53, 0xf3, 0x39,
+	0x55, 0x3d, 0x4d, 0x63, 0x69, 0xb3, 0x46, 0x9a, 0xcd, 0xde, 0xc2, 0xba, 0xf1, 0x4c, 0x87, 0x6c,
+	0xcd, 0xb8, 0xc5, 0x78, 0x91, 0x53, 0xaf, 0xa7, 0xbe, 0xb1, 0x61, 0xd3, 0xf6, 0x6f, 0x31, 0x66,
+	0xef, 0x92, 0xbb, 0xe9, 0xfa, 0x75, 0xbd, 0xaf, 0xb7, 0x4f, 0x19, 0x9b, 0xaf, 0x60, 0xbd, 0xb7,
+	0x98, 0x73, 0xef, 0x72, 0x9c, 0x1b, 0x8c, 0xf3, 0x7b, 0xf5, 0x65, 0x9c, 0x77, 0xac, 0x06, 0xf9,
+	0x8d, 0x05, 0x57, 0x66, 0x5e, 0x00, 0x11, 0x5b, 0xa7, 0x9e, 0xf6, 0x44, 0xa8, 0xbe, 0xf0, 0x3d,
+	0x90, 0xdd, 0x64, 0x32, 0x7c, 0x6c, 0x3f, 0x34, 0x64, 0x88, 0x9f, 0x09, 0x3d, 0x54, 0xa4, 0x89,
+	0x07, 0xc3, 0x9d, 0xe4, 0x1d, 0x11, 0xf9, 0xb9, 0x05, 0xd7, 0xd2, 0x1e, 0x12, 0x91, 0xf7, 0xd3,
+	0x7c, 0x3f, 0x2b, 0x60, 0x6a, 0x08, 0x3c, 0x62, 0x72, 0x7d, 0xaf, 0xf1, 0xc1, 0x7c, 0xdb, 0x24,
+	0xd2, 0xf0, 0xc8, 0xf8, 0x31, 0x94, 0x95, 0x8e, 0x3d, 0xd9, 0x98, 0x89, 0x0a, 0xe5, 0x61, 0x40,
+	0x7d, 0xd1, 0x6c, 0x68, 0x5f, 0x61, 0xdc, 0xcb, 0xa4, 0x84, 0xdc, 0xf9, 0xed, 0xfd, 0x8f, 0xa1,
+	0x28, 0xba, 0xf5, 0xa4, 0x36, 0xf3, 0xad, 0x28, 0xfb, 0xd4, 0x53, 0x6e, 0xff, 0x76, 0x8d, 0xd1,
+	0x22, 0xa4, 0x1a, 0xd3, 0xda, 0xfe, 0x0a, 0x0f, 0xf3, 0x5f, 0x93, 0x3d, 0x28, 0xf0, 0x24, 0x4e,
+	0x6e, 0xa6, 0x55, 0x1d, 0x90, 0xe0, 0x9c, 0x89, 0xd0, 0x26, 0x8c, 0x6a, 0x85, 0x00, 0x52, 0x0d,
+	0x39, 0x95, 0x3d, 0x28, 0x8a, 0x46, 0xbb, 0x29, 0x62, 0xd2, 0x7f, 0x4f, 0xb7, 0xf6, 0x35, 0x46,
+	0x6d, 0xcd, 0x4e, 0xf4, 0xc5, 0x98, 0x3b, 0x95, 0x8d, 0xef, 0x54, 0x83, 0xea, 0x8d, 0xf7, 0xfa,
+	0xa2, 0xd9, 0xd0, 0xbe, 0xcd, 0x18, 0x5c, 0xb7, 0x15, 0x23, 0x0c, 0x19, 0xc6, 0x8e, 0xd5, 0x78,
+	0x60, 0x91, 0x4f, 0x01, 0x92, 0x66, 0xbf, 0x99, 0x66, 0xb5, 0x67, 0x00, 0xe9, 0xf2, 0x0b, 0xf2,
+	0x8d, 0x19, 0x1b, 0xa3, 0x1a, 0x03, 0x66, 0x16, 0xf6, 0x3a, 0x6b, 0xd6, 0x2c, 0xe2, 0x61, 0x51,
+	0x3d, 0xe5, 0x15, 0x92, 0x5c, 0x9f, 0xf6, 0x86, 0x42, 0x15, 0xeb, 0x6b, 0x0f, 0x19, 0xe9, 0x6d,
+	0xfe, 0xe8, 0x64, 0x87, 0xbd, 0x85, 0x22, 0x27, 0x00, 0xc9, 0x33, 0x27, 0x53, 0x03, 0xed, 0x95,
+	0x54, 0x7d, 0xc1, 0x64, 0x68, 0xdf, 0x65, 0x3c, 0x6f, 0x91, 0x9b, 0xa6, 0x26, 0x82, 0x1d, 0x79,
+	0xcd, 0xf2, 0x9f, 0xf6, 0xcc, 0x66, 0x36, 0xff, 0x19, 0xef, 0xa1, 0xcc, 0x2c, 0xa4, 0x4e, 0xdb,
+	0xef, 0x33, 0x8e, 0x77, 0xc9, 0x9d, 0x19, 0x8e, 0xae, 0xca, 0xe4, 0xa5, 0x74, 0x11, 0x33, 0x64,
+	0xaa, 0x8b, 0xa4, 0x2d, 0x53, 0x5d, 0x74, 0x93, 0xb1, 0xb9, 0xd2, 0x58, 0x47, 0x36, 0x5c, 0x17,
+	0xbe, 0x6c, 0x7d, 0xb9, 0xb3, 0x73, 0xe7, 0x6f, 0xa4, 0x75, 0x01, 0x62, 0xef, 0x2f, 0xae, 0xac,
+	0xdb, 0xf7, 0x18, 0x93, 0x3b, 0xf5, 0xda, 0x8c, 0x2e, 0xfc, 0x33, 0x8a, 0xf1, 0xf0, 0x67, 0x50,
+	0x35, 0x5b, 0x40, 0xe4, 0xdd, 0x54, 0xae, 0x6a, 0x4f, 0xb4, 0xbe, 0x14, 0x25, 0xb4, 0x6d, 0xc6,
+	0x7e, 0xc3, 0x9e, 0x75, 0x1e, 0xef, 0x16, 0x21, 0x77, 0x17, 0x4a, 0x71, 0xd3, 0x87, 0xd4, 0x67,
+	0x3c, 0x17, 0xb7, 0x7a, 0xea, 0xcb, 0x5a, 0x50, 0xd2, 0xa2, 0x64, 0x9d, 0xa7, 0x80, 0x64, 0x8b,
+	0xfc, 0x12, 0x2a, 0x6a, 0xdf, 0x88, 0x18, 0x46, 0x33, 0x7a, 0x4a, 0xcb, 0x19, 0xbd, 0xcb, 0x18,
+	0xdd, 0xb6, 0x6f, 0x18, 0x8c, 0xb6, 0x79, 0x4b, 0x91, 0xa7, 0x8a, 0x8a, 0x5a, 0x02, 0x9f, 0x61,
+	0xa9, 0x97, 0xc7, 0xd3, 0x23, 0xe4, 0x3d, 0xc6, 0x66, 0xd3, 0xbe, 0x35, 0x1b, 0xfa, 0xe2, 0x73,
+	0xe4, 0xf4, 0x13, 0x80, 0xe4, 0x55, 0x93, 0x19, 0x87, 0xda, 0x6b, 0xa9, 0xfa, 0x82, 0xc9, 0x45,
+	0xbe, 0xe2, 0x7d, 0x75, 0xe4, 0xf5, 0xa7, 0x50, 0x8a, 0x4b, 0x44, 0xa6, 0xaf, 0xd4, 0xda, 0x51,
+	0x3d, 0xa5, 0xf0, 0xa2, 0x5b, 0x4d, 0x63, 0xc0, 0x2a, 0x32, 0x48, 0x7f, 0x9f, 0xed, 0x29, 0x8c,
+	0xfa, 0xec, 0x9e, 0xb2, 0x88, 0xf6, 0x0d, 0x46, 0xbb, 0x4a, 0xd6, 0x90, 0x36, 0x23, 0xc7, 0x3d,
+	0x3f, 0x80, 0x52, 0x5c, 0x1f, 0x32, 0x05, 0x56, 0x8b, 0x4b, 0xf5, 0xf9, 0x73, 0xa1, 0x3c, 0x13,
+	0x93, 0x39, 0x82, 0x93, 0x23, 0x80, 0xa4, 0x94, 0x64, 0x7a, 0x40, 0x2b, 0x32, 0xa5, 0xca, 0xbe,
+	0xc5, 0xc8, 0xd7, 0xed, 0xeb, 0xba, 0xec, 0x4a, 0x30, 0x51, 0x79, 0xb8, 0x97, 0xf5, 0xa8, 0xd4,
+	0xc3, 0x7d, 0x52, 0x22, 0xa9, 0xa7, 0x97, 0x55, 0xec, 0x3b, 0x8c, 0xd3, 0x4d, 0xbb, 0x82, 0x9c,
+	0x64, 0xa1, 0x66, 0x47, 0x16, 0x5b, 0x70, 0xd3, 0x49, 0x0a, 0x43, 0x29, 0x67, 0xfb, 0xe5, 0x0c,
+	0x6e, 0x31, 0x06, 0x57, 0xc9, 0x15, 0x95, 0x01, 0xf7, 0xc4, 0x9f, 0xf0, 0x4a, 0x9d, 0xc0, 0x9c,
+	0x73, 0xb2, 0xbf, 0x33, 0xeb, 0x05, 0xa5, 0x0a, 0x25, 0x77, 0x65, 0xa2, 0xc9, 0x4f, 0x02, 0x79,
+	0xae, 0x9f, 0x63, 0x1d, 0xb3, 0x0a, 0x35, 0x4f, 0x78, 0x79, 0xfa, 0xbc, 0xa5, 0x0b, 0x2f, 0x7e,
+	0xf1, 0x63, 0xbd, 0x00, 0xc8, 0x91, 0x3c, 0xd6, 0xcf, 0xe1, 0x69, 0x16, 0xad, 0xd2, 0x17, 0xb8,
+	0x30, 0x57, 0x23, 0xc5, 0x5c, 0x7f, 0x63, 0xc1, 0xf5, 0xd4, 0x4a, 0x07, 0xb9, 0x3f, 0xd7, 0x46,
+	0x5a, 0x91, 0xa5, 0x7e, 0x31, 0xbc, 0x50, 0x5e, 0x67, 0xc8, 0xbd, 0x54, 0xb5, 0xf1, 0x5c, 0x99,
+	0x14, 0x49, 0xc8, 0x67, 0x50, 0x51, 0x3b, 0x1f, 0x33, 0x69, 0x4d, 0xef, 0x8a, 0xd4, 0x53, 0x9b,
+	0x14, 0xf1, 0xd9, 0xa7, 0x8c, 0x1c, 0x79, 0xf9, 0x38, 0xdc, 0x11, 0x9d, 0x0b, 0xf2, 0x92, 0x6d,
+	0x07, 0x82, 0xfc, 0xec, 0x76, 0xb0, 0x8c, 0xb6, 0x76, 0xb8, 0x14, 0xb4, 0xb9, 0x45, 0x47, 0x50,
+	0x51, 0x7b, 0x2a, 0xa6, 0xe8, 0x46, 0xbf, 0x65, 0x0e, 0x79, 0x71, 0x37, 0xaa, 0xdf, 0xd4, 0xc8,
+	0xf3, 0x1f, 0x2c, 0x42, 0xa4, 0x1a, 0x14, 0x56, 0x64, 0xa7, 0x82, 0xdc, 0x9a, 0xf5, 0x84, 0x68,
+	0x76, 0xd4, 0xe7, 0x4e, 0x85, 0x72, 0xeb, 0x26, 0xb7, 0x53, 0x58, 0xa1, 0x57, 0x58, 0x6b, 0x23,
+	0xe0, 0x7f, 0x0f, 0x52, 0xbb, 0xf0, 0xe6, 0xd6, 0x9d, 0xf2, 0x1a, 0xa2, 0xbe, 0x14, 0x25, 0xd4,
+	0x0d, 0xa9, 0x62, 0x93, 0x01, 0x94, 0x95, 0xce, 0xbb, 0x79, 0x3e, 0xd1, 0x9b, 0xf2, 0x17, 0xe1,
+	0x74, 0x95, 0x71, 0x5a, 0x25, 0x2c, 0x1c, 0xc4, 0x13, 0x2f, 0xe2, 0xb3, 0xd7, 0xc6, 0x0a, 0x2a,
+	0xb9, 0x3b, 0x13, 0x0b, 0x7a, 0xe3, 0x7b, 0xd9, 0x51, 0x48, 0x24, 0x3f, 0x72, 0xdd, 0x54, 0x88,
+	0x87, 0xc7, 0xcf, 0x2d, 0xb8, 0x9a, 0xd2, 0xc1, 0x27, 0xef, 0xa5, 0x6f, 0xdc, 0x97, 0xe3, 0xfd,
+	0x01, 0xe3, 0x7d, 0xcf, 0xde, 0x4c, 0xe5, 0xad, 0x6d, 0xe7, 0x7f, 0x0e, 0x57, 0x66, 0x9e, 0x00,
+	0x98, 0xd7, 0xda, 0xb4, 0x37, 0x02, 0xcb, 0x44, 0x10, 0x91, 0xcb, 0xcf, 0xee, 0x29, 0x22, 0xc4,
+	0x9b, 0xcd, 0x5f, 0x5b, 0x70, 0x3d, 0xf5, 0x71, 0x81, 0x99, 0x79, 0xe6, 0xbd, 0x40, 0x58, 0x26,
+	0x89, 0x48, 0x38, 0xf6, 0x56, 0xba, 0x24, 0x41, 0x4c, 0x16, 0xa5, 0x19, 0x43, 0x29, 0x7e, 0x85,
+	0x60, 0xa6, 0x03, 0xf5, 0x79, 0xc2, 0x32, 0xa6, 0xf7, 0x19, 0xd3, 0x2d, 0xfb, 0xf6, 0x3c, 0xa6,
+	0x63, 0xfa, 0x66, 0xc7, 0x6a, 0x1c, 0x17, 0xd8, 0x3f, 0x34, 0x3f, 0xfc, 0xbf, 0x01, 0x00, 0xc8,
+	0xc1, 0xdd, 0xbc, 0xed, 0x39, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ReservationClient is the client API for Reservation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReservationClient interface {
+	CreateLibrary(ctx context.Context, in *CreateLibraryReq, opts ...grpc.CallOption) (*Library, error)
+	GetLibrary(ctx context.Context, in *GetLibraryReq, opts ...grpc.CallOption) (*Library, error)
+	ListLibraries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLibrariesRes, error)
+	// UpdateLibrary replaces every field of a library but its ID and creation time
+	UpdateLibrary(ctx context.Context, in *UpdateLibraryReq, opts ...grpc.CallOption) (*Library, error)
+	// DeleteLibrary deletes a library that holds no copies
+	DeleteLibrary(ctx context.Context, in *DeleteLibraryReq, opts ...grpc.CallOption) (*Empty, error)
+	// GetOpeningHours returns the weekly opening hours of a library and its
+	// exceptions over the coming weeks
+	GetOpeningHours(ctx context.Context, in *GetOpeningHoursReq, opts ...grpc.CallOption) (*OpeningHours, error)
+	// SetOpeningHours replaces the weekly opening hours of a library. A library
+	// without any is open at all times.
+	SetOpeningHours(ctx context.Context, in *SetOpeningHoursReq, opts ...grpc.CallOption) (*OpeningHours, error)
+	// AddHoursException closes a library, or changes its hours, on a date
+	AddHoursException(ctx context.Context, in *AddHoursExceptionReq, opts ...grpc.CallOption) (*HoursException, error)
+	DeleteHoursException(ctx context.Context, in *DeleteHoursExceptionReq, opts ...grpc.CallOption) (*Empty, error)
+	// GetAllBooks lists books a page at a time, ordered by ISBN
+	GetAllBooks(ctx context.Context, in *GetAllBooksReq, opts ...grpc.CallOption) (*GetAllBooksRes, error)
+	GetBook(ctx context.Context, in *GetBookReq, opts ...grpc.CallOption) (*Book, error)
+	// Search lists books free over a window at libraries within range a page at a
+	// time, nearest first unless orderBy says otherwise
+	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error)
+	AddBook(ctx context.Context, in *AddBookReq, opts ...grpc.CallOption) (*Empty, error)
+	// ImportBooks adds or updates a stream of books in batches, each in a transaction,
+	// and reports what happened to every row. Over REST the body is one JSON
+	// ImportBooksReq per line.
+	ImportBooks(ctx context.Context, opts ...grpc.CallOption) (Reservation_ImportBooksClient, error)
+	DeleteBook(ctx context.Context, in *DeleteBookReq, opts ...grpc.CallOption) (*Empty, error)
+	AddCopy(ctx context.Context, in *AddCopyReq, opts ...grpc.CallOption) (*Copy, error)
+	ListCopies(ctx context.Context, in *ListCopiesReq, opts ...grpc.CallOption) (*ListCopiesRes, error)
+	// GetAvailability returns when each copy of a book is free and when it is reserved
+	// over a window, for rendering as a calendar
+	GetAvailability(ctx context.Context, in *GetAvailabilityReq, opts ...grpc.CallOption) (*Availability, error)
+	// DeleteCopy deletes a copy that has never been reserved
+	DeleteCopy(ctx context.Context, in *DeleteCopyReq, opts ...grpc.CallOption) (*Empty, error)
+	ReserveBook(ctx context.Context, in *ReserveBookReq, opts ...grpc.CallOption) (*BookReservation, error)
+	// ReserveRecurring reserves a book for every occurrence of an RFC 5545 RRULE,
+	// repeating the window of the first occurrence at the same wall clock time in
+	// the series' time zone. The occurrences are reserved as one series that
+	// CancelSeries cancels at once.
+	ReserveRecurring(ctx context.Context, in *ReserveRecurringReq, opts ...grpc.CallOption) (*ReserveRecurringRes, error)
+	GetSeries(ctx context.Context, in *GetSeriesReq, opts ...grpc.CallOption) (*ReservationSeries, error)
+	// CancelSeries cancels a series along with its reservations that haven't been checked out
+	CancelSeries(ctx context.Context, in *CancelSeriesReq, opts ...grpc.CallOption) (*ReservationSeries, error)
+	CheckoutBook(ctx context.Context, in *CheckoutBookReq, opts ...grpc.CallOption) (*Empty, error)
+	// ReturnBook returns a checked out book, charging the patron who checked it out
+	// the library's late fee if it is returned after the reservation ends
+	ReturnBook(ctx context.Context, in *ReturnBookReq, opts ...grpc.CallOption) (*ReturnBookRes, error)
+	// PlaceHold queues a patron for the next window of the given number of days in
+	// which a copy of a book is free. Holds are promoted to reservations starting
+	// at the time a copy frees up, in the order they were placed, skipping holds
+	// whose window doesn't fit yet.
+	PlaceHold(ctx context.Context, in *PlaceHoldReq, opts ...grpc.CallOption) (*Hold, error)
+	// GetHold returns a hold and its position in the queue
+	GetHold(ctx context.Context, in *GetHoldReq, opts ...grpc.CallOption) (*Hold, error)
+	// ListHolds returns the hold queue of a book
+	ListHolds(ctx context.Context, in *ListHoldsReq, opts ...grpc.CallOption) (*ListHoldsRes, error)
+	// CancelHold takes a waiting hold out of its queue
+	CancelHold(ctx context.Context, in *CancelHoldReq, opts ...grpc.CallOption) (*Hold, error)
+	// CreateWebhook subscribes an HTTP endpoint to events. The response carries the
+	// secret deliveries are signed with, generated unless one is given.
+	CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	GetWebhook(ctx context.Context, in *GetWebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksRes, error)
+	// UpdateWebhook replaces the URL, events and active flag of a webhook, and its
+	// secret if one is given
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*Empty, error)
+	// ListWebhookDeliveries returns the delivery log of a webhook, newest first
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesRes, error)
+	CreatePatron(ctx context.Context, in *CreatePatronReq, opts ...grpc.CallOption) (*Patron, error)
+	GetPatron(ctx context.Context, in *GetPatronReq, opts ...grpc.CallOption) (*Patron, error)
+	// UpdatePatron replaces the name, email and phone of a patron
+	UpdatePatron(ctx context.Context, in *UpdatePatronReq, opts ...grpc.CallOption) (*Patron, error)
+	// ListFees lists the fees charged to a patron, newest first
+	ListFees(ctx context.Context, in *ListFeesReq, opts ...grpc.CallOption) (*ListFeesRes, error)
+	ListReservations(ctx context.Context, in *ListReservationsReq, opts ...grpc.CallOption) (*ListReservationsRes, error)
+	// ListOverdue lists the books still checked out after their reservation ended,
+	// ordered by the start of their reservation
+	ListOverdue(ctx context.Context, in *ListOverdueReq, opts ...grpc.CallOption) (*ListReservationsRes, error)
+	GetReservation(ctx context.Context, in *GetReservationReq, opts ...grpc.CallOption) (*BookReservation, error)
+	CheckoutReservation(ctx context.Context, in *CheckoutReservationReq, opts ...grpc.CallOption) (*BookReservation, error)
+	CancelReservation(ctx context.Context, in *CancelReservationReq, opts ...grpc.CallOption) (*BookReservation, error)
+	// RescheduleReservation atomically moves a reservation to a new window,
+	// keeping the old one if the new window is taken
+	RescheduleReservation(ctx context.Context, in *RescheduleReservationReq, opts ...grpc.CallOption) (*BookReservation, error)
+	// RenewLoan extends a checked out reservation to a later end, if no later
+	// reservation of the copy overlaps the extension and the library's renewal
+	// limits allow it. Overdue loans can't be renewed.
+	RenewLoan(ctx context.Context, in *RenewLoanReq, opts ...grpc.CallOption) (*BookReservation, error)
+}
+
+type reservationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReservationClient(cc grpc.ClientConnInterface) ReservationClient {
+	return &reservationClient{cc}
+}
+
+func (c *reservationClient) CreateLibrary(ctx context.Context, in *CreateLibraryReq, opts ...grpc.CallOption) (*Library, error) {
+	out := new(Library)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CreateLibrary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetLibrary(ctx context.Context, in *GetLibraryReq, opts ...grpc.CallOption) (*Library, error) {
+	out := new(Library)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetLibrary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListLibraries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLibrariesRes, error) {
+	out := new(ListLibrariesRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) UpdateLibrary(ctx context.Context, in *UpdateLibraryReq, opts ...grpc.CallOption) (*Library, error) {
+	out := new(Library)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/UpdateLibrary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) DeleteLibrary(ctx context.Context, in *DeleteLibraryReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteLibrary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetOpeningHours(ctx context.Context, in *GetOpeningHoursReq, opts ...grpc.CallOption) (*OpeningHours, error) {
+	out := new(OpeningHours)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetOpeningHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) SetOpeningHours(ctx context.Context, in *SetOpeningHoursReq, opts ...grpc.CallOption) (*OpeningHours, error) {
+	out := new(OpeningHours)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/SetOpeningHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) AddHoursException(ctx context.Context, in *AddHoursExceptionReq, opts ...grpc.CallOption) (*HoursException, error) {
+	out := new(HoursException)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/AddHoursException", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) DeleteHoursException(ctx context.Context, in *DeleteHoursExceptionReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteHoursException", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetAllBooks(ctx context.Context, in *GetAllBooksReq, opts ...grpc.CallOption) (*GetAllBooksRes, error) {
+	out := new(GetAllBooksRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetAllBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetBook(ctx context.Context, in *GetBookReq, opts ...grpc.CallOption) (*Book, error) {
+	out := new(Book)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error) {
+	out := new(SearchRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) AddBook(ctx context.Context, in *AddBookReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/AddBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ImportBooks(ctx context.Context, opts ...grpc.CallOption) (Reservation_ImportBooksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Reservation_serviceDesc.Streams[0], "/reservations.Reservation/ImportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reservationImportBooksClient{stream}
+	return x, nil
+}
+
+type Reservation_ImportBooksClient interface {
+	Send(*ImportBooksReq) error
+	CloseAndRecv() (*ImportBooksRes, error)
+	grpc.ClientStream
+}
+
+type reservationImportBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *reservationImportBooksClient) Send(m *ImportBooksReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reservationImportBooksClient) CloseAndRecv() (*ImportBooksRes, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportBooksRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *reservationClient) DeleteBook(ctx context.Context, in *DeleteBookReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) AddCopy(ctx context.Context, in *AddCopyReq, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/AddCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListCopies(ctx context.Context, in *ListCopiesReq, opts ...grpc.CallOption) (*ListCopiesRes, error) {
+	out := new(ListCopiesRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListCopies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetAvailability(ctx context.Context, in *GetAvailabilityReq, opts ...grpc.CallOption) (*Availability, error) {
+	out := new(Availability)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetAvailability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) DeleteCopy(ctx context.Context, in *DeleteCopyReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ReserveBook(ctx context.Context, in *ReserveBookReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ReserveBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ReserveRecurring(ctx context.Context, in *ReserveRecurringReq, opts ...grpc.CallOption) (*ReserveRecurringRes, error) {
+	out := new(ReserveRecurringRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ReserveRecurring", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetSeries(ctx context.Context, in *GetSeriesReq, opts ...grpc.CallOption) (*ReservationSeries, error) {
+	out := new(ReservationSeries)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CancelSeries(ctx context.Context, in *CancelSeriesReq, opts ...grpc.CallOption) (*ReservationSeries, error) {
+	out := new(ReservationSeries)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CancelSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CheckoutBook(ctx context.Context, in *CheckoutBookReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CheckoutBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ReturnBook(ctx context.Context, in *ReturnBookReq, opts ...grpc.CallOption) (*ReturnBookRes, error) {
+	out := new(ReturnBookRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ReturnBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) PlaceHold(ctx context.Context, in *PlaceHoldReq, opts ...grpc.CallOption) (*Hold, error) {
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/PlaceHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetHold(ctx context.Context, in *GetHoldReq, opts ...grpc.CallOption) (*Hold, error) {
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListHolds(ctx context.Context, in *ListHoldsReq, opts ...grpc.CallOption) (*ListHoldsRes, error) {
+	out := new(ListHoldsRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListHolds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CancelHold(ctx context.Context, in *CancelHoldReq, opts ...grpc.CallOption) (*Hold, error) {
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CancelHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetWebhook(ctx context.Context, in *GetWebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksRes, error) {
+	out := new(ListWebhooksRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/UpdateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesRes, error) {
+	out := new(ListWebhookDeliveriesRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CreatePatron(ctx context.Context, in *CreatePatronReq, opts ...grpc.CallOption) (*Patron, error) {
+	out := new(Patron)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CreatePatron", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetPatron(ctx context.Context, in *GetPatronReq, opts ...grpc.CallOption) (*Patron, error) {
+	out := new(Patron)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetPatron", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) UpdatePatron(ctx context.Context, in *UpdatePatronReq, opts ...grpc.CallOption) (*Patron, error) {
+	out := new(Patron)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/UpdatePatron", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListFees(ctx context.Context, in *ListFeesReq, opts ...grpc.CallOption) (*ListFeesRes, error) {
+	out := new(ListFeesRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListFees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListReservations(ctx context.Context, in *ListReservationsReq, opts ...grpc.CallOption) (*ListReservationsRes, error) {
+	out := new(ListReservationsRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListReservations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListOverdue(ctx context.Context, in *ListOverdueReq, opts ...grpc.CallOption) (*ListReservationsRes, error) {
+	out := new(ListReservationsRes)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/ListOverdue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) GetReservation(ctx context.Context, in *GetReservationReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CheckoutReservation(ctx context.Context, in *CheckoutReservationReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CheckoutReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CancelReservation(ctx context.Context, in *CancelReservationReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/CancelReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) RescheduleReservation(ctx context.Context, in *RescheduleReservationReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/RescheduleReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) RenewLoan(ctx context.Context, in *RenewLoanReq, opts ...grpc.CallOption) (*BookReservation, error) {
+	out := new(BookReservation)
+	err := c.cc.Invoke(ctx, "/reservations.Reservation/RenewLoan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReservationServer is the server API for Reservation service.
+type ReservationServer interface {
+	CreateLibrary(context.Context, *CreateLibraryReq) (*Library, error)
+	GetLibrary(context.Context, *GetLibraryReq) (*Library, error)
+	ListLibraries(context.Context, *Empty) (*ListLibrariesRes, error)
+	// UpdateLibrary replaces every field of a library but its ID and creation time
+	UpdateLibrary(context.Context, *UpdateLibraryReq) (*Library, error)
+	// DeleteLibrary deletes a library that holds no copies
+	DeleteLibrary(context.Context, *DeleteLibraryReq) (*Empty, error)
+	// GetOpeningHours returns the weekly opening hours of a library and its
+	// exceptions over the coming weeks
+	GetOpeningHours(context.Context, *GetOpeningHoursReq) (*OpeningHours, error)
+	// SetOpeningHours replaces the weekly opening hours of a library. A library
+	// without any is open at all times.
+	SetOpeningHours(context.Context, *SetOpeningHoursReq) (*OpeningHours, error)
+	// AddHoursException closes a library, or changes its hours, on a date
+	AddHoursException(context.Context, *AddHoursExceptionReq) (*HoursException, error)
+	DeleteHoursException(context.Context, *DeleteHoursExceptionReq) (*Empty, error)
+	// GetAllBooks lists books a page at a time, ordered by ISBN
+	GetAllBooks(context.Context, *GetAllBooksReq) (*GetAllBooksRes, error)
+	GetBook(context.Context, *GetBookReq) (*Book, error)
+	// Search lists books free over a window at libraries within range a page at a
+	// time, nearest first unless orderBy says otherwise
+	Search(context.Context, *SearchReq) (*SearchRes, error)
+	AddBook(context.Context, *AddBookReq) (*Empty, error)
+	// ImportBooks adds or updates a stream of books in batches, each in a transaction,
+	// and reports what happened to every row. Over REST the body is one JSON
+	// ImportBooksReq per line.
+	ImportBooks(Reservation_ImportBooksServer) error
+	DeleteBook(context.Context, *DeleteBookReq) (*Empty, error)
+	AddCopy(context.Context, *AddCopyReq) (*Copy, error)
+	ListCopies(context.Context, *ListCopiesReq) (*ListCopiesRes, error)
+	// GetAvailability returns when each copy of a book is free and when it is reserved
+	// over a window, for rendering as a calendar
+	GetAvailability(context.Context, *GetAvailabilityReq) (*Availability, error)
+	// DeleteCopy deletes a copy that has never been reserved
+	DeleteCopy(context.Context, *DeleteCopyReq) (*Empty, error)
+	ReserveBook(context.Context, *ReserveBookReq) (*BookReservation, error)
+	// ReserveRecurring reserves a book for every occurrence of an RFC 5545 RRULE,
+	// repeating the window of the first occurrence at the same wall clock time in
+	// the series' time zone. The occurrences are reserved as one series that
+	// CancelSeries cancels at once.
+	ReserveRecurring(context.Context, *ReserveRecurringReq) (*ReserveRecurringRes, error)
+	GetSeries(context.Context, *GetSeriesReq) (*ReservationSeries, error)
+	// CancelSeries cancels a series along with its reservations that haven't been checked out
+	CancelSeries(context.Context, *CancelSeriesReq) (*ReservationSeries, error)
+	CheckoutBook(context.Context, *CheckoutBookReq) (*Empty, error)
+	// ReturnBook returns a checked out book, charging the patron who checked it out
+	// the library's late fee if it is returned after the reservation ends
+	ReturnBook(context.Context, *ReturnBookReq) (*ReturnBookRes, error)
+	// PlaceHold queues a patron for the next window of the given number of days in
+	// which a copy of a book is free. Holds are promoted to reservations starting
+	// at the time a copy frees up, in the order they were placed, skipping holds
+	// whose window doesn't fit yet.
+	PlaceHold(context.Context, *PlaceHoldReq) (*Hold, error)
+	// GetHold returns a hold and its position in the queue
+	GetHold(context.Context, *GetHoldReq) (*Hold, error)
+	// ListHolds returns the hold queue of a book
+	ListHolds(context.Context, *ListHoldsReq) (*ListHoldsRes, error)
+	// CancelHold takes a waiting hold out of its queue
+	CancelHold(context.Context, *CancelHoldReq) (*Hold, error)
+	// CreateWebhook subscribes an HTTP endpoint to events. The response carries the
+	// secret deliveries are signed with, generated unless one is given.
+	CreateWebhook(context.Context, *CreateWebhookReq) (*Webhook, error)
+	GetWebhook(context.Context, *GetWebhookReq) (*Webhook, error)
+	ListWebhooks(context.Context, *Empty) (*ListWebhooksRes, error)
+	// UpdateWebhook replaces the URL, events and active flag of a webhook, and its
+	// secret if one is given
+	UpdateWebhook(context.Context, *UpdateWebhookReq) (*Webhook, error)
+	DeleteWebhook(context.Context, *DeleteWebhookReq) (*Empty, error)
+	// ListWebhookDeliveries returns the delivery log of a webhook, newest first
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesRes, error)
+	CreatePatron(context.Context, *CreatePatronReq) (*Patron, error)
+	GetPatron(context.Context, *GetPatronReq) (*Patron, error)
+	// UpdatePatron replaces the name, email and phone of a patron
+	UpdatePatron(context.Context, *UpdatePatronReq) (*Patron, error)
+	// ListFees lists the fees charged to a patron, newest first
+	ListFees(context.Context, *ListFeesReq) (*ListFeesRes, error)
+	ListReservations(context.Context, *ListReservationsReq) (*ListReservationsRes, error)
+	// ListOverdue lists the books still checked out after their reservation ended,
+	// ordered by the start of their reservation
+	ListOverdue(context.Context, *ListOverdueReq) (*ListReservationsRes, error)
+	GetReservation(context.Context, *GetReservationReq) (*BookReservation, error)
+	CheckoutReservation(context.Context, *CheckoutReservationReq) (*BookReservation, error)
+	CancelReservation(context.Context, *CancelReservationReq) (*BookReservation, error)
+	// RescheduleReservation atomically moves a reservation to a new window,
+	// keeping the old one if the new window is taken
+	RescheduleReservation(context.Context, *RescheduleReservationReq) (*BookReservation, error)
+	// RenewLoan extends a checked out reservation to a later end, if no later
+	// reservation of the copy overlaps the extension and the library's renewal
+	// limits allow it. Overdue loans can't be renewed.
+	RenewLoan(context.Context, *RenewLoanReq) (*BookReservation, error)
+}
+
+// UnimplementedReservationServer can be embedded to have forward compatible implementations.
+type UnimplementedReservationServer struct {
+}
+
+func (*UnimplementedReservationServer) CreateLibrary(ctx context.Context, req *CreateLibraryReq) (*Library, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLibrary not implemented")
+}
+func (*UnimplementedReservationServer) GetLibrary(ctx context.Context, req *GetLibraryReq) (*Library, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLibrary not implemented")
+}
+func (*UnimplementedReservationServer) ListLibraries(ctx context.Context, req *Empty) (*ListLibrariesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLibraries not implemented")
+}
+func (*UnimplementedReservationServer) UpdateLibrary(ctx context.Context, req *UpdateLibraryReq) (*Library, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLibrary not implemented")
+}
+func (*UnimplementedReservationServer) DeleteLibrary(ctx context.Context, req *DeleteLibraryReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLibrary not implemented")
+}
+func (*UnimplementedReservationServer) GetOpeningHours(ctx context.Context, req *GetOpeningHoursReq) (*OpeningHours, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpeningHours not implemented")
+}
+func (*UnimplementedReservationServer) SetOpeningHours(ctx context.Context, req *SetOpeningHoursReq) (*OpeningHours, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOpeningHours not implemented")
+}
+func (*UnimplementedReservationServer) AddHoursException(ctx context.Context, req *AddHoursExceptionReq) (*HoursException, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHoursException not implemented")
+}
+func (*UnimplementedReservationServer) DeleteHoursException(ctx context.Context, req *DeleteHoursExceptionReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHoursException not implemented")
+}
+func (*UnimplementedReservationServer) GetAllBooks(ctx context.Context, req *GetAllBooksReq) (*GetAllBooksRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllBooks not implemented")
+}
+func (*UnimplementedReservationServer) GetBook(ctx context.Context, req *GetBookReq) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
+}
+func (*UnimplementedReservationServer) Search(ctx context.Context, req *SearchReq) (*SearchRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedReservationServer) AddBook(ctx context.Context, req *AddBookReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBook not implemented")
+}
+func (*UnimplementedReservationServer) ImportBooks(srv Reservation_ImportBooksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportBooks not implemented")
+}
+func (*UnimplementedReservationServer) DeleteBook(ctx context.Context, req *DeleteBookReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
+}
+func (*UnimplementedReservationServer) AddCopy(ctx context.Context, req *AddCopyReq) (*Copy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCopy not implemented")
+}
+func (*UnimplementedReservationServer) ListCopies(ctx context.Context, req *ListCopiesReq) (*ListCopiesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCopies not implemented")
+}
+func (*UnimplementedReservationServer) GetAvailability(ctx context.Context, req *GetAvailabilityReq) (*Availability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailability not implemented")
+}
+func (*UnimplementedReservationServer) DeleteCopy(ctx context.Context, req *DeleteCopyReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCopy not implemented")
+}
+func (*UnimplementedReservationServer) ReserveBook(ctx context.Context, req *ReserveBookReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveBook not implemented")
+}
+func (*UnimplementedReservationServer) ReserveRecurring(ctx context.Context, req *ReserveRecurringReq) (*ReserveRecurringRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveRecurring not implemented")
+}
+func (*UnimplementedReservationServer) GetSeries(ctx context.Context, req *GetSeriesReq) (*ReservationSeries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (*UnimplementedReservationServer) CancelSeries(ctx context.Context, req *CancelSeriesReq) (*ReservationSeries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSeries not implemented")
+}
+func (*UnimplementedReservationServer) CheckoutBook(ctx context.Context, req *CheckoutBookReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckoutBook not implemented")
+}
+func (*UnimplementedReservationServer) ReturnBook(ctx context.Context, req *ReturnBookReq) (*ReturnBookRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnBook not implemented")
+}
+func (*UnimplementedReservationServer) PlaceHold(ctx context.Context, req *PlaceHoldReq) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceHold not implemented")
+}
+func (*UnimplementedReservationServer) GetHold(ctx context.Context, req *GetHoldReq) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHold not implemented")
+}
+func (*UnimplementedReservationServer) ListHolds(ctx context.Context, req *ListHoldsReq) (*ListHoldsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHolds not implemented")
+}
+func (*UnimplementedReservationServer) CancelHold(ctx context.Context, req *CancelHoldReq) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelHold not implemented")
+}
+func (*UnimplementedReservationServer) CreateWebhook(ctx context.Context, req *CreateWebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (*UnimplementedReservationServer) GetWebhook(ctx context.Context, req *GetWebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (*UnimplementedReservationServer) ListWebhooks(ctx context.Context, req *Empty) (*ListWebhooksRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedReservationServer) UpdateWebhook(ctx context.Context, req *UpdateWebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (*UnimplementedReservationServer) DeleteWebhook(ctx context.Context, req *DeleteWebhookReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (*UnimplementedReservationServer) ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (*UnimplementedReservationServer) CreatePatron(ctx context.Context, req *CreatePatronReq) (*Patron, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePatron not implemented")
+}
+func (*UnimplementedReservationServer) GetPatron(ctx context.Context, req *GetPatronReq) (*Patron, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPatron not implemented")
+}
+func (*UnimplementedReservationServer) UpdatePatron(ctx context.Context, req *UpdatePatronReq) (*Patron, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePatron not implemented")
+}
+func (*UnimplementedReservationServer) ListFees(ctx context.Context, req *ListFeesReq) (*ListFeesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFees not implemented")
+}
+func (*UnimplementedReservationServer) ListReservations(ctx context.Context, req *ListReservationsReq) (*ListReservationsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (*UnimplementedReservationServer) ListOverdue(ctx context.Context, req *ListOverdueReq) (*ListReservationsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdue not implemented")
+}
+func (*UnimplementedReservationServer) GetReservation(ctx context.Context, req *GetReservationReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (*UnimplementedReservationServer) CheckoutReservation(ctx context.Context, req *CheckoutReservationReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckoutReservation not implemented")
+}
+func (*UnimplementedReservationServer) CancelReservation(ctx context.Context, req *CancelReservationReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (*UnimplementedReservationServer) RescheduleReservation(ctx context.Context, req *RescheduleReservationReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescheduleReservation not implemented")
+}
+func (*UnimplementedReservationServer) RenewLoan(ctx context.Context, req *RenewLoanReq) (*BookReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLoan not implemented")
+}
+
+func RegisterReservationServer(s *grpc.Server, srv ReservationServer) {
+	s.RegisterService(&_Reservation_serviceDesc, srv)
+}
+
+func _Reservation_CreateLibrary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLibraryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CreateLibrary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CreateLibrary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CreateLibrary(ctx, req.(*CreateLibraryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetLibrary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLibraryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetLibrary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetLibrary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetLibrary(ctx, req.(*GetLibraryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ListLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ListLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ListLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListLibraries(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_UpdateLibrary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLibraryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).UpdateLibrary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/UpdateLibrary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).UpdateLibrary(ctx, req.(*UpdateLibraryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_DeleteLibrary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLibraryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).DeleteLibrary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/DeleteLibrary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DeleteLibrary(ctx, req.(*DeleteLibraryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpeningHoursReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetOpeningHours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetOpeningHours(ctx, req.(*GetOpeningHoursReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_SetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOpeningHoursReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).SetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/SetOpeningHours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).SetOpeningHours(ctx, req.(*SetOpeningHoursReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_AddHoursException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHoursExceptionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).AddHoursException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/AddHoursException",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).AddHoursException(ctx, req.(*AddHoursExceptionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_DeleteHoursException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHoursExceptionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).DeleteHoursException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/DeleteHoursException",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DeleteHoursException(ctx, req.(*DeleteHoursExceptionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetAllBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllBooksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetAllBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetAllBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetAllBooks(ctx, req.(*GetAllBooksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetBook(ctx, req.(*GetBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).Search(ctx, req.(*SearchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_AddBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).AddBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/AddBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).AddBook(ctx, req.(*AddBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ImportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReservationServer).ImportBooks(&reservationImportBooksServer{stream})
+}
+
+type Reservation_ImportBooksServer interface {
+	SendAndClose(*ImportBooksRes) error
+	Recv() (*ImportBooksReq, error)
+	grpc.ServerStream
+}
+
+type reservationImportBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *reservationImportBooksServer) SendAndClose(m *ImportBooksRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reservationImportBooksServer) Recv() (*ImportBooksReq, error) {
+	m := new(ImportBooksReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Reservation_DeleteBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).DeleteBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/DeleteBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DeleteBook(ctx, req.(*DeleteBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_AddCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCopyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).AddCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/AddCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).AddCopy(ctx, req.(*AddCopyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ListCopies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCopiesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ListCopies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ListCopies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListCopies(ctx, req.(*ListCopiesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailabilityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetAvailability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetAvailability(ctx, req.(*GetAvailabilityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_DeleteCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCopyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).DeleteCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/DeleteCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DeleteCopy(ctx, req.(*DeleteCopyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ReserveBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ReserveBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ReserveBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ReserveBook(ctx, req.(*ReserveBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ReserveRecurring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRecurringReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ReserveRecurring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ReserveRecurring",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ReserveRecurring(ctx, req.(*ReserveRecurringReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetSeries(ctx, req.(*GetSeriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_CancelSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSeriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CancelSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CancelSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CancelSeries(ctx, req.(*CancelSeriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_CheckoutBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CheckoutBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CheckoutBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CheckoutBook(ctx, req.(*CheckoutBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ReturnBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnBookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ReturnBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ReturnBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ReturnBook(ctx, req.(*ReturnBookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_PlaceHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceHoldReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).PlaceHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/PlaceHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).PlaceHold(ctx, req.(*PlaceHoldReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHoldReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetHold(ctx, req.(*GetHoldReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ListHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ListHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ListHolds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListHolds(ctx, req.(*ListHoldsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_CancelHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelHoldReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CancelHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CancelHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CancelHold(ctx, req.(*CancelHoldReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CreateWebhook(ctx, req.(*CreateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetWebhook",
 	}
-	return out, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetWebhook(ctx, req.(*GetWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) GetBook(ctx context.Context, in *GetBookReq, opts ...grpc.CallOption) (*Book, error) {
-	out := new(Book)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/GetBook", in, out, opts...)
-	if err != nil {
+func _Reservation_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListWebhooks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error) {
-	out := new(SearchRes)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/Search", in, out, opts...)
-	if err != nil {
+func _Reservation_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/UpdateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).UpdateWebhook(ctx, req.(*UpdateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) AddBook(ctx context.Context, in *AddBookReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/AddBook", in, out, opts...)
-	if err != nil {
+func _Reservation_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DeleteWebhook(ctx, req.(*DeleteWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) DeleteBook(ctx context.Context, in *DeleteBookReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/DeleteBook", in, out, opts...)
-	if err != nil {
+func _Reservation_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) ReserveBook(ctx context.Context, in *ReserveBookReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/ReserveBook", in, out, opts...)
-	if err != nil {
+func _Reservation_CreatePatron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePatronReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).CreatePatron(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/CreatePatron",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CreatePatron(ctx, req.(*CreatePatronReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) CheckoutBook(ctx context.Context, in *CheckoutBookReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/CheckoutBook", in, out, opts...)
-	if err != nil {
+func _Reservation_GetPatron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPatronReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ReservationServer).GetPatron(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/GetPatron",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).GetPatron(ctx, req.(*GetPatronReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *reservationClient) ReturnBook(ctx context.Context, in *ReturnBookReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/reservations.Reservation/ReturnBook", in, out, opts...)
-	if err != nil {
+func _Reservation_UpdatePatron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePatronReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ReservationServer is the server API for Reservation service.
-type ReservationServer interface {
-	GetAllBooks(context.Context, *Empty) (*GetAllBooksRes, error)
-	GetBook(context.Context, *GetBookReq) (*Book, error)
-	Search(context.Context, *SearchReq) (*SearchRes, error)
-	AddBook(context.Context, *AddBookReq) (*Empty, error)
-	DeleteBook(context.Context, *DeleteBookReq) (*Empty, error)
-	ReserveBook(context.Context, *ReserveBookReq) (*Empty, error)
-	CheckoutBook(context.Context, *CheckoutBookReq) (*Empty, error)
-	ReturnBook(context.Context, *ReturnBookReq) (*Empty, error)
-}
-
-// UnimplementedReservationServer can be embedded to have forward compatible implementations.
-type UnimplementedReservationServer struct {
-}
-
-func (*UnimplementedReservationServer) GetAllBooks(ctx context.Context, req *Empty) (*GetAllBooksRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllBooks not implemented")
-}
-func (*UnimplementedReservationServer) GetBook(ctx context.Context, req *GetBookReq) (*Book, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
-}
-func (*UnimplementedReservationServer) Search(ctx context.Context, req *SearchReq) (*SearchRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
-}
-func (*UnimplementedReservationServer) AddBook(ctx context.Context, req *AddBookReq) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddBook not implemented")
-}
-func (*UnimplementedReservationServer) DeleteBook(ctx context.Context, req *DeleteBookReq) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
-}
-func (*UnimplementedReservationServer) ReserveBook(ctx context.Context, req *ReserveBookReq) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReserveBook not implemented")
-}
-func (*UnimplementedReservationServer) CheckoutBook(ctx context.Context, req *CheckoutBookReq) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckoutBook not implemented")
-}
-func (*UnimplementedReservationServer) ReturnBook(ctx context.Context, req *ReturnBookReq) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReturnBook not implemented")
-}
-
-func RegisterReservationServer(s *grpc.Server, srv ReservationServer) {
-	s.RegisterService(&_Reservation_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(ReservationServer).UpdatePatron(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservations.Reservation/UpdatePatron",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).UpdatePatron(ctx, req.(*UpdatePatronReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_GetAllBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Reservation_ListFees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).GetAllBooks(ctx, in)
+		return srv.(ReservationServer).ListFees(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/GetAllBooks",
+		FullMethod: "/reservations.Reservation/ListFees",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).GetAllBooks(ctx, req.(*Empty))
+		return srv.(ReservationServer).ListFees(ctx, req.(*ListFeesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBookReq)
+func _Reservation_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).GetBook(ctx, in)
+		return srv.(ReservationServer).ListReservations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/GetBook",
+		FullMethod: "/reservations.Reservation/ListReservations",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).GetBook(ctx, req.(*GetBookReq))
+		return srv.(ReservationServer).ListReservations(ctx, req.(*ListReservationsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchReq)
+func _Reservation_ListOverdue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).Search(ctx, in)
+		return srv.(ReservationServer).ListOverdue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/Search",
+		FullMethod: "/reservations.Reservation/ListOverdue",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).Search(ctx, req.(*SearchReq))
+		return srv.(ReservationServer).ListOverdue(ctx, req.(*ListOverdueReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_AddBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddBookReq)
+func _Reservation_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).AddBook(ctx, in)
+		return srv.(ReservationServer).GetReservation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/AddBook",
+		FullMethod: "/reservations.Reservation/GetReservation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).AddBook(ctx, req.(*AddBookReq))
+		return srv.(ReservationServer).GetReservation(ctx, req.(*GetReservationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_DeleteBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBookReq)
+func _Reservation_CheckoutReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutReservationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).DeleteBook(ctx, in)
+		return srv.(ReservationServer).CheckoutReservation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/DeleteBook",
+		FullMethod: "/reservations.Reservation/CheckoutReservation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).DeleteBook(ctx, req.(*DeleteBookReq))
+		return srv.(ReservationServer).CheckoutReservation(ctx, req.(*CheckoutReservationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_ReserveBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveBookReq)
+func _Reservation_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).ReserveBook(ctx, in)
+		return srv.(ReservationServer).CancelReservation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/ReserveBook",
+		FullMethod: "/reservations.Reservation/CancelReservation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).ReserveBook(ctx, req.(*ReserveBookReq))
+		return srv.(ReservationServer).CancelReservation(ctx, req.(*CancelReservationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_CheckoutBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckoutBookReq)
+func _Reservation_RescheduleReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleReservationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).CheckoutBook(ctx, in)
+		return srv.(ReservationServer).RescheduleReservation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/CheckoutBook",
+		FullMethod: "/reservations.Reservation/RescheduleReservation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).CheckoutBook(ctx, req.(*CheckoutBookReq))
+		return srv.(ReservationServer).RescheduleReservation(ctx, req.(*RescheduleReservationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Reservation_ReturnBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReturnBookReq)
+func _Reservation_RenewLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLoanReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReservationServer).ReturnBook(ctx, in)
+		return srv.(ReservationServer).RenewLoan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reservations.Reservation/ReturnBook",
+		FullMethod: "/reservations.Reservation/RenewLoan",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReservationServer).ReturnBook(ctx, req.(*ReturnBookReq))
+		return srv.(ReservationServer).RenewLoan(ctx, req.(*RenewLoanReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -900,6 +6903,42 @@ var _Reservation_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "reservations.Reservation",
 	HandlerType: (*ReservationServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLibrary",
+			Handler:    _Reservation_CreateLibrary_Handler,
+		},
+		{
+			MethodName: "GetLibrary",
+			Handler:    _Reservation_GetLibrary_Handler,
+		},
+		{
+			MethodName: "ListLibraries",
+			Handler:    _Reservation_ListLibraries_Handler,
+		},
+		{
+			MethodName: "UpdateLibrary",
+			Handler:    _Reservation_UpdateLibrary_Handler,
+		},
+		{
+			MethodName: "DeleteLibrary",
+			Handler:    _Reservation_DeleteLibrary_Handler,
+		},
+		{
+			MethodName: "GetOpeningHours",
+			Handler:    _Reservation_GetOpeningHours_Handler,
+		},
+		{
+			MethodName: "SetOpeningHours",
+			Handler:    _Reservation_SetOpeningHours_Handler,
+		},
+		{
+			MethodName: "AddHoursException",
+			Handler:    _Reservation_AddHoursException_Handler,
+		},
+		{
+			MethodName: "DeleteHoursException",
+			Handler:    _Reservation_DeleteHoursException_Handler,
+		},
 		{
 			MethodName: "GetAllBooks",
 			Handler:    _Reservation_GetAllBooks_Handler,
@@ -920,10 +6959,38 @@ var _Reservation_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBook",
 			Handler:    _Reservation_DeleteBook_Handler,
 		},
+		{
+			MethodName: "AddCopy",
+			Handler:    _Reservation_AddCopy_Handler,
+		},
+		{
+			MethodName: "ListCopies",
+			Handler:    _Reservation_ListCopies_Handler,
+		},
+		{
+			MethodName: "GetAvailability",
+			Handler:    _Reservation_GetAvailability_Handler,
+		},
+		{
+			MethodName: "DeleteCopy",
+			Handler:    _Reservation_DeleteCopy_Handler,
+		},
 		{
 			MethodName: "ReserveBook",
 			Handler:    _Reservation_ReserveBook_Handler,
 		},
+		{
+			MethodName: "ReserveRecurring",
+			Handler:    _Reservation_ReserveRecurring_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _Reservation_GetSeries_Handler,
+		},
+		{
+			MethodName: "CancelSeries",
+			Handler:    _Reservation_CancelSeries_Handler,
+		},
 		{
 			MethodName: "CheckoutBook",
 			Handler:    _Reservation_CheckoutBook_Handler,
@@ -932,7 +6999,97 @@ var _Reservation_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReturnBook",
 			Handler:    _Reservation_ReturnBook_Handler,
 		},
+		{
+			MethodName: "PlaceHold",
+			Handler:    _Reservation_PlaceHold_Handler,
+		},
+		{
+			MethodName: "GetHold",
+			Handler:    _Reservation_GetHold_Handler,
+		},
+		{
+			MethodName: "ListHolds",
+			Handler:    _Reservation_ListHolds_Handler,
+		},
+		{
+			MethodName: "CancelHold",
+			Handler:    _Reservation_CancelHold_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Reservation_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _Reservation_GetWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Reservation_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _Reservation_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Reservation_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Reservation_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "CreatePatron",
+			Handler:    _Reservation_CreatePatron_Handler,
+		},
+		{
+			MethodName: "GetPatron",
+			Handler:    _Reservation_GetPatron_Handler,
+		},
+		{
+			MethodName: "UpdatePatron",
+			Handler:    _Reservation_UpdatePatron_Handler,
+		},
+		{
+			MethodName: "ListFees",
+			Handler:    _Reservation_ListFees_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _Reservation_ListReservations_Handler,
+		},
+		{
+			MethodName: "ListOverdue",
+			Handler:    _Reservation_ListOverdue_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _Reservation_GetReservation_Handler,
+		},
+		{
+			MethodName: "CheckoutReservation",
+			Handler:    _Reservation_CheckoutReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _Reservation_CancelReservation_Handler,
+		},
+		{
+			MethodName: "RescheduleReservation",
+			Handler:    _Reservation_RescheduleReservation_Handler,
+		},
+		{
+			MethodName: "RenewLoan",
+			Handler:    _Reservation_RenewLoan_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportBooks",
+			Handler:       _Reservation_ImportBooks_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "protobufs/reservations.proto",
 }
@@ -8,27 +8,60 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
 
+	"github.com/pmaroli/scheduling-rpc/config"
 	pb "github.com/pmaroli/scheduling-rpc/protobufs"
 )
 
-// Start the REST reverse proxy
-func Start() error {
-	fmt.Println("Starting the reverse proxy")
-	// Register gRPC server endpoint
-	// Note: Make sure the gRPC server is running properly and accessible
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+// Gateway is the REST reverse proxy in front of the gRPC server
+type Gateway struct {
+	server *http.Server
+	// cancel closes the connection to the gRPC server
+	cancel context.CancelFunc
+}
 
-	err := pb.RegisterReservationHandlerFromEndpoint(ctx, mux, "localhost:5001", opts)
+// New registers the gRPC server endpoint with the REST reverse proxy
+func New(cfg config.Config) (*Gateway, error) {
+	// Note: the connection to the gRPC server is established lazily, so it doesn't have to be up yet
+	ctx, cancel := context.WithCancel(context.Background())
 
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(headerMatcher))
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+
+	err := pb.RegisterReservationHandlerFromEndpoint(ctx, mux, cfg.HTTP.GatewayTarget, opts)
 	if err != nil {
-		panic(err)
+		cancel()
+		return nil, err
+	}
+
+	return &Gateway{
+		server: &http.Server{Addr: cfg.HTTP.Addr(), Handler: mux},
+		cancel: cancel,
+	}, nil
+}
+
+// headerMatcher forwards the Authorization header to the gRPC server as the
+// "authorization" metadata the auth interceptor reads. The default matcher would
+// also send it a second time as "grpcgateway-authorization".
+func headerMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "Authorization" {
+		return "authorization", true
 	}
+	return runtime.DefaultHeaderMatcher(key)
+}
 
+// Start the REST reverse proxy
+func (g *Gateway) Start() error {
+	fmt.Println("Starting the reverse proxy on", g.server.Addr)
 	// Start HTTP server (and proxy calls to gRPC server endpoint)
-	return http.ListenAndServe(":8080", mux)
+	err := g.server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Stop the REST reverse proxy, waiting for in-flight requests to finish until ctx is done
+func (g *Gateway) Stop(ctx context.Context) error {
+	defer g.cancel()
+	return g.server.Shutdown(ctx)
 }
@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// maxAvailabilityWindow bounds the window GetAvailability computes a calendar for
+const maxAvailabilityWindow = 366 * 24 * time.Hour
+
+// interval is a span of time. Reservations and free slots are half-open [start, end),
+// while opening periods include their closing time.
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// calendar is when a copy is reserved and free over a window
+type calendar struct {
+	copy store.Copy
+	busy []interval
+	// free is trimmed to start and end while the library is open
+	free []interval
+	// open is when the library is open from the day the window starts to the day it ends
+	open []interval
+}
+
+// GetAvailability returns when each copy of a book is free and reserved over a window
+func (s ReservationServer) GetAvailability(ctx context.Context, req *pb.GetAvailabilityReq) (*pb.Availability, error) {
+	if req.GetFrom() == "" {
+		return nil, invalidArgument("from", "`from` is required")
+	}
+	if req.GetTo() == "" {
+		return nil, invalidArgument("to", "`to` is required")
+	}
+	from, err := parseOptionalTime("from", req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseOptionalTime("to", req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, invalidArgument("to", "`to` must be after `from`")
+	}
+	if to.Sub(from) > maxAvailabilityWindow {
+		return nil, invalidArgument("to", "the window from `from` to `to` can be at most 366 days long")
+	}
+	if req.GetMinDurationMinutes() < 0 {
+		return nil, invalidArgument("minDurationMinutes", "`minDurationMinutes` must not be negative")
+	}
+	minDuration := time.Duration(req.GetMinDurationMinutes()) * time.Minute
+
+	book, err := s.Store.GetBook(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, err
+	}
+	copies, err := s.Store.ListCopies(ctx, book.ISBN)
+	if err != nil {
+		return nil, err
+	}
+	var atLibrary []store.Copy
+	for _, copy := range copies {
+		if req.GetLibraryId() == 0 || copy.LibraryID == req.GetLibraryId() {
+			atLibrary = append(atLibrary, copy)
+		}
+	}
+
+	calendars, err := s.calendars(ctx, book.ISBN, atLibrary, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.Availability{Isbn: book.ISBN, From: from.Format(timeFormat), To: to.Format(timeFormat)}
+	for _, calendar := range calendars {
+		availability := &pb.CopyAvailability{
+			CopyId:    calendar.copy.ID,
+			LibraryId: calendar.copy.LibraryID,
+			Library:   calendar.copy.Library,
+		}
+		for _, reserved := range calendar.busy {
+			availability.Busy = append(availability.Busy, toPBInterval(reserved))
+		}
+		for _, free := range calendar.free {
+			if free.end.Sub(free.start) >= minDuration {
+				availability.Free = append(availability.Free, toPBInterval(free))
+			}
+		}
+		res.Copies = append(res.Copies, availability)
+	}
+
+	return res, nil
+}
+
+// calendars returns the calendar of each of the copies of a book over [from, to)
+func (s ReservationServer) calendars(ctx context.Context, isbn string, copies []store.Copy, from, to time.Time) ([]calendar, error) {
+	busy, err := s.busyIntervals(ctx, isbn, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var calendars []calendar
+	openings := make(map[int64][]interval)
+	for _, copy := range copies {
+		open, ok := openings[copy.LibraryID]
+		if !ok {
+			if open, err = s.openPeriods(ctx, copy.LibraryID, from, to); err != nil {
+				return nil, err
+			}
+			openings[copy.LibraryID] = open
+		}
+
+		calendar := calendar{copy: copy, busy: busy[copy.ID], open: open}
+		for _, free := range freeIntervals(from, to, busy[copy.ID]) {
+			if free, ok := clipToOpen(free, open); ok {
+				calendar.free = append(calendar.free, free)
+			}
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+// busyIntervals returns the windows of the live reservations of each copy of a book
+// overlapping [from, to), clipped to it and ordered by start
+func (s ReservationServer) busyIntervals(ctx context.Context, isbn string, from, to time.Time) (map[int64][]interval, error) {
+	query := store.ListReservationsQuery{
+		ISBN:     isbn,
+		Start:    from,
+		End:      to,
+		Statuses: []store.ReservationStatus{store.StatusReserved, store.StatusCheckedOut},
+		Order:    store.OrderByStart,
+		Limit:    maxPageSize,
+	}
+
+	busy := make(map[int64][]interval)
+	for {
+		reservations, err := s.Store.ListReservations(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reservation := range reservations {
+			reserved := interval{start: reservation.Start, end: reservation.End}
+			if reserved.start.Before(from) {
+				reserved.start = from
+			}
+			if reserved.end.After(to) {
+				reserved.end = to
+			}
+			busy[reservation.CopyID] = append(busy[reservation.CopyID], reserved)
+		}
+
+		if len(reservations) < query.Limit {
+			return busy, nil
+		}
+		last := reservations[len(reservations)-1]
+		query.After = &store.ReservationCursor{Time: last.Start, ID: last.ID}
+	}
+}
+
+// openPeriods returns the periods a library is open from the day of from to the day of
+// to, in order, merging periods that run into each other such as over midnight. Days
+// without opening hours, because the library has no weekly hours, are open all day.
+func (s ReservationServer) openPeriods(ctx context.Context, libraryID int64, from, to time.Time) ([]interval, error) {
+	library, err := s.Store.GetLibrary(ctx, libraryID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.Store.GetOpeningHours(ctx, libraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	location := libraryLocation(library)
+	first, last := localDate(from.In(location)), localDate(to.In(location))
+	exceptions, err := s.Store.ListHoursExceptions(ctx, libraryID, first, last)
+	if err != nil {
+		return nil, err
+	}
+	// Exceptions are looked up by date rather than time, whose location depends on the store
+	exceptionsOn := make(map[string]store.HoursException)
+	for _, exception := range exceptions {
+		exceptionsOn[exception.Date.Format(dateFormat)] = exception
+	}
+
+	var periods []interval
+	add := func(date time.Time, opens, closes store.TimeOfDay) {
+		// time.Date normalizes 24:00 to midnight of the next day
+		at := func(t store.TimeOfDay) time.Time {
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, int(t), 0, 0, location)
+		}
+		period := interval{start: at(opens), end: at(closes)}
+		if n := len(periods); n > 0 && !period.start.After(periods[n-1].end) {
+			if period.end.After(periods[n-1].end) {
+				periods[n-1].end = period.end
+			}
+			return
+		}
+		periods = append(periods, period)
+	}
+
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		if exception, ok := exceptionsOn[date.Format(dateFormat)]; ok {
+			if !exception.Closed {
+				add(date, exception.Opens, exception.Closes)
+			}
+			continue
+		}
+		if len(hours) == 0 {
+			add(date, 0, 24*60)
+			continue
+		}
+		for _, period := range hours {
+			if period.Weekday == date.Weekday() {
+				add(date, period.Opens, period.Closes)
+			}
+		}
+	}
+
+	return periods, nil
+}
+
+// freeIntervals returns the gaps between the ordered busy intervals within [from, to)
+func freeIntervals(from, to time.Time, busy []interval) []interval {
+	var free []interval
+	cursor := from
+	for _, reserved := range busy {
+		if reserved.start.After(cursor) {
+			free = append(free, interval{start: cursor, end: reserved.start})
+		}
+		if reserved.end.After(cursor) {
+			cursor = reserved.end
+		}
+	}
+	if cursor.Before(to) {
+		free = append(free, interval{start: cursor, end: to})
+	}
+	return free
+}
+
+// clipToOpen moves the start of a free interval forward and its end back to when the
+// library is open, as reservations must start and end during opening hours. It reports
+// false if nothing of the interval is left.
+func clipToOpen(free interval, open []interval) (interval, bool) {
+	clipped, started := interval{}, false
+	for _, period := range open {
+		if period.end.Before(free.start) {
+			continue
+		}
+		if !period.start.Before(free.end) && !period.start.Equal(free.end) {
+			break
+		}
+
+		if !started {
+			clipped.start, started = free.start, true
+			if period.start.After(free.start) {
+				clipped.start = period.start
+			}
+		}
+		clipped.end = free.end
+		if period.end.Before(free.end) {
+			clipped.end = period.end
+		}
+	}
+	return clipped, started && clipped.start.Before(clipped.end)
+}
+
+func toPBInterval(i interval) *pb.Interval {
+	return &pb.Interval{Start: i.start.Format(timeFormat), End: i.end.Format(timeFormat)}
+}
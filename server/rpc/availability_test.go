@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// pqDateStore returns exception dates in an unnamed zone, as lib/pq decodes DATE columns
+type pqDateStore struct {
+	store.Store
+}
+
+func (s pqDateStore) ListHoursExceptions(ctx context.Context, libraryID int64, from, to time.Time) ([]store.HoursException, error) {
+	exceptions, err := s.Store.ListHoursExceptions(ctx, libraryID, from, to)
+	for i := range exceptions {
+		exceptions[i].Date = exceptions[i].Date.In(time.FixedZone("", 0))
+	}
+	return exceptions, err
+}
+
+func TestOpenPeriodsSkipsClosuresDatedOutsideUTC(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	library, err := st.CreateLibrary(ctx, store.Library{Name: "Central"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	holiday := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	if _, err = st.AddHoursException(ctx, store.HoursException{LibraryID: library.ID, Date: holiday, Closed: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := ReservationServer{Store: pqDateStore{Store: st}}
+	periods, err := s.openPeriods(ctx, library.ID, holiday.AddDate(0, 0, -1), holiday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without weekly hours the library is open all day, except on the holiday
+	want := []interval{
+		{holiday.AddDate(0, 0, -1), holiday},
+		{holiday.AddDate(0, 0, 1), holiday.AddDate(0, 0, 2)},
+	}
+	if len(periods) != len(want) {
+		t.Fatalf("got %d periods, want %d: %v", len(periods), len(want), periods)
+	}
+	for i, period := range periods {
+		if !period.start.Equal(want[i].start) || !period.end.Equal(want[i].end) {
+			t.Errorf("period %d: got %s-%s, want %s-%s", i, period.start, period.end, want[i].start, want[i].end)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// AddCopy adds a physical copy of an existing book
+func (s ReservationServer) AddCopy(ctx context.Context, req *pb.AddCopyReq) (*pb.Copy, error) {
+	newCopy := req.GetCopy()
+	barcode := strings.TrimSpace(newCopy.GetBarcode())
+	if barcode == "" {
+		return nil, invalidArgument("copy.barcode", "`copy.barcode` is required")
+	}
+
+	libraryID, err := resolveLibraryID(ctx, s.Store, newCopy.GetLibraryId(), strings.TrimSpace(newCopy.GetLibrary()))
+	if err != nil {
+		return nil, err
+	}
+	if libraryID == 0 {
+		return nil, invalidArgument("copy.libraryId", "`copy.libraryId` is required")
+	}
+
+	copy, err := s.Store.AddCopy(ctx, store.Copy{
+		ISBN:      newCopy.GetIsbn(),
+		LibraryID: libraryID,
+		Location:  strings.TrimSpace(newCopy.GetLocation()),
+		Barcode:   barcode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Added copy %d of %s", copy.ID, copy.ISBN))
+	s.promoteHolds(ctx, copy.ISBN)
+	return toPBCopy(copy), nil
+}
+
+// ListCopies returns the copies of a book
+func (s ReservationServer) ListCopies(ctx context.Context, req *pb.ListCopiesReq) (*pb.ListCopiesRes, error) {
+	copies, err := s.Store.ListCopies(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListCopiesRes{}
+	for _, copy := range copies {
+		res.Copies = append(res.Copies, toPBCopy(copy))
+	}
+	return res, nil
+}
+
+// DeleteCopy deletes a copy that has never been reserved
+func (s ReservationServer) DeleteCopy(ctx context.Context, req *pb.DeleteCopyReq) (*pb.Empty, error) {
+	err := s.Store.DeleteCopy(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Deleted copy %d", req.GetId()))
+	return &pb.Empty{}, nil
+}
+
+func toPBCopy(copy store.Copy) *pb.Copy {
+	return &pb.Copy{
+		Id:        copy.ID,
+		Isbn:      copy.ISBN,
+		LibraryId: copy.LibraryID,
+		Library:   copy.Library,
+		Location:  copy.Location,
+		Barcode:   copy.Barcode,
+		Lat:       float32(copy.Lat),
+		Lng:       float32(copy.Lng),
+	}
+}
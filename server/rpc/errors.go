@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the ErrorInfo domain of every error reason raised by the service
+const errorDomain = "reservations.scheduling-rpc"
+
+// storeError describes how a store error is surfaced to clients
+type storeError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// storeErrors maps the errors returned by the store onto gRPC codes and
+// ErrorInfo reasons that clients can branch on
+var storeErrors = []storeError{
+	{store.ErrLibraryNotFound, codes.NotFound, "LIBRARY_NOT_FOUND"},
+	{store.ErrBookNotFound, codes.NotFound, "BOOK_NOT_FOUND"},
+	{store.ErrCopyNotFound, codes.NotFound, "COPY_NOT_FOUND"},
+	{store.ErrReservationNotFound, codes.NotFound, "RESERVATION_NOT_FOUND"},
+	{store.ErrPatronNotFound, codes.NotFound, "PATRON_NOT_FOUND"},
+	{store.ErrHoursExceptionNotFound, codes.NotFound, "HOURS_EXCEPTION_NOT_FOUND"},
+	{store.ErrHoldNotFound, codes.NotFound, "HOLD_NOT_FOUND"},
+	{store.ErrWebhookNotFound, codes.NotFound, "WEBHOOK_NOT_FOUND"},
+	{store.ErrSeriesNotFound, codes.NotFound, "SERIES_NOT_FOUND"},
+	{store.ErrLibraryExists, codes.AlreadyExists, "LIBRARY_EXISTS"},
+	{store.ErrBookExists, codes.AlreadyExists, "BOOK_EXISTS"},
+	{store.ErrCopyExists, codes.AlreadyExists, "COPY_EXISTS"},
+	{store.ErrPatronExists, codes.AlreadyExists, "PATRON_EXISTS"},
+	{store.ErrHoursExceptionExists, codes.AlreadyExists, "HOURS_EXCEPTION_EXISTS"},
+	{store.ErrHoldExists, codes.AlreadyExists, "HOLD_EXISTS"},
+	{store.ErrOverlap, codes.AlreadyExists, "RESERVATION_OVERLAP"},
+	{store.ErrSeriesConflict, codes.AlreadyExists, "SERIES_CONFLICT"},
+	{store.ErrInvalidRange, codes.InvalidArgument, "INVALID_RANGE"},
+	{store.ErrCopyRequired, codes.InvalidArgument, "COPY_REQUIRED"},
+	{store.ErrLibraryInUse, codes.FailedPrecondition, "LIBRARY_IN_USE"},
+	{store.ErrBookInUse, codes.FailedPrecondition, "BOOK_IN_USE"},
+	{store.ErrCopyInUse, codes.FailedPrecondition, "COPY_IN_USE"},
+	{store.ErrReservationClosed, codes.FailedPrecondition, "RESERVATION_CLOSED"},
+	{store.ErrAlreadyCheckedOut, codes.FailedPrecondition, "ALREADY_CHECKED_OUT"},
+	{store.ErrNotCheckedOut, codes.FailedPrecondition, "NOT_CHECKED_OUT"},
+	{store.ErrHoldClosed, codes.FailedPrecondition, "HOLD_CLOSED"},
+	{store.ErrRenewalLimit, codes.FailedPrecondition, "RENEWAL_LIMIT_REACHED"},
+	{store.ErrLoanTooLong, codes.FailedPrecondition, "LOAN_TOO_LONG"},
+	{store.ErrLoanOverdue, codes.FailedPrecondition, "LOAN_OVERDUE"},
+	{store.ErrSeriesCancelled, codes.FailedPrecondition, "SERIES_CANCELLED"},
+}
+
+// errorInterceptor converts every error returned by a handler into a gRPC status
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(info.FullMethod, err)
+	}
+	return res, nil
+}
+
+// errorStreamInterceptor converts every error returned by a streaming handler into a gRPC status
+func errorStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return toStatus(info.FullMethod, err)
+	}
+	return nil
+}
+
+// toStatus maps err onto a gRPC status. Errors that already carry a status are
+// returned as they are, and unexpected errors are logged and hidden behind codes.Internal.
+func toStatus(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range storeErrors {
+		if errors.Is(err, e.err) {
+			return withDetails(status.New(e.code, err.Error()), &errdetails.ErrorInfo{
+				Reason: e.reason,
+				Domain: errorDomain,
+			})
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	fmt.Printf("%s: internal error: %v\n", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// errorReason returns the ErrorInfo reason of a store error, or an empty string for other errors
+func errorReason(err error) string {
+	for _, e := range storeErrors {
+		if errors.Is(err, e.err) {
+			return e.reason
+		}
+	}
+	return ""
+}
+
+// invalidArgument returns an InvalidArgument status describing a single bad request field
+func invalidArgument(field, description string) error {
+	return withDetails(status.New(codes.InvalidArgument, description), &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+}
+
+// withDetails attaches details to st, falling back to st alone if they can't be marshalled
+func withDetails(st *status.Status, details ...proto.Message) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/lifecycle"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+)
+
+// maxHoldDays is the longest reservation a hold can wait for
+const maxHoldDays = 90
+
+// holdStartHorizon is how far ahead a promoted hold's reservation can start, waiting for
+// its library to open
+const holdStartHorizon = 7 * 24 * time.Hour
+
+// holdWindow is the window a hold would be promoted to at a library
+type holdWindow struct {
+	libraryID int64
+	interval
+}
+
+// PlaceHold queues a patron for the next window in which a copy of a book is free
+func (s ReservationServer) PlaceHold(ctx context.Context, req *pb.PlaceHoldReq) (*pb.Hold, error) {
+	if req.GetPatronId() == 0 {
+		return nil, invalidArgument("patronId", "`patronId` is required")
+	}
+	if req.GetDays() < 1 || req.GetDays() > maxHoldDays {
+		return nil, invalidArgument("days", fmt.Sprintf("`days` must be between 1 and %d", maxHoldDays))
+	}
+
+	hold, err := s.Store.PlaceHold(ctx, store.Hold{
+		ISBN:      req.GetIsbn(),
+		PatronID:  req.GetPatronId(),
+		LibraryID: req.GetLibraryId(),
+		Days:      int(req.GetDays()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(fmt.Sprintf("Placed hold %d on %s", hold.ID, hold.ISBN))
+
+	// A copy may already be free, in which case the hold is fulfilled straight away
+	s.promoteHolds(ctx, hold.ISBN)
+	hold, err = s.Store.GetHold(ctx, hold.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBHold(hold), nil
+}
+
+// GetHold returns a hold and its position in the queue
+func (s ReservationServer) GetHold(ctx context.Context, req *pb.GetHoldReq) (*pb.Hold, error) {
+	hold, err := s.Store.GetHold(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBHold(hold), nil
+}
+
+// ListHolds returns the hold queue of a book
+func (s ReservationServer) ListHolds(ctx context.Context, req *pb.ListHoldsReq) (*pb.ListHoldsRes, error) {
+	holds, err := s.Store.ListHolds(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListHoldsRes{}
+	for _, hold := range holds {
+		res.Holds = append(res.Holds, toPBHold(hold))
+	}
+	return res, nil
+}
+
+// CancelHold takes a waiting hold out of its queue
+func (s ReservationServer) CancelHold(ctx context.Context, req *pb.CancelHoldReq) (*pb.Hold, error) {
+	hold, err := s.Store.CancelHold(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Cancelled hold %d", hold.ID))
+	return toPBHold(hold), nil
+}
+
+// promoteHolds turns the waiting holds of a book into reservations starting as soon as
+// a library holding it is open, in queue order, skipping holds whose window isn't free
+// yet. It is called whenever a copy may have freed up, after the change that freed it
+// has been made, and periodically by the hold worker, so failures are logged rather
+// than returned.
+func (s ReservationServer) promoteHolds(ctx context.Context, isbn string) {
+	holds, err := s.Store.ListHolds(ctx, isbn)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("Failed to list the holds on %s: %v", isbn, err))
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, hold := range holds {
+		windows, err := s.holdWindows(ctx, hold, now)
+		if err != nil {
+			fmt.Println(fmt.Sprintf("Failed to find a window for hold %d: %v", hold.ID, err))
+			continue
+		}
+
+		for _, window := range windows {
+			var fulfilled store.Hold
+			reservation, err := s.reserve(ctx, store.Reservation{
+				ISBN:      hold.ISBN,
+				LibraryID: window.libraryID,
+				PatronID:  hold.PatronID,
+				Start:     window.start,
+				End:       window.end,
+			}, func(reservation store.Reservation) (store.Reservation, error) {
+				var err error
+				fulfilled, reservation, err = s.Store.FulfillHold(ctx, hold.ID, reservation)
+				return reservation, err
+			})
+			if err != nil {
+				continue
+			}
+
+			fmt.Println(fmt.Sprintf("Promoted hold %d to reservation %d", hold.ID, reservation.ID))
+			s.publish(ctx, webhook.ReservationCreated, toPBReservation(reservation))
+			s.publish(ctx, webhook.HoldPromoted, toPBHold(fulfilled))
+			break
+		}
+	}
+}
+
+// holdWindows returns the earliest window of a hold's length starting and ending while
+// each library that could fulfil it is open, earliest first. Libraries with no such
+// window within holdStartHorizon are left out.
+func (s ReservationServer) holdWindows(ctx context.Context, hold store.Hold, now time.Time) ([]holdWindow, error) {
+	libraryIDs := []int64{hold.LibraryID}
+	if hold.LibraryID == 0 {
+		var err error
+		if libraryIDs, err = s.candidateLibraries(ctx, hold.ISBN, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	length := now.AddDate(0, 0, hold.Days).Sub(now)
+	horizon := interval{start: now, end: now.Add(holdStartHorizon + length)}
+
+	var windows []holdWindow
+	for _, libraryID := range libraryIDs {
+		open, err := s.openPeriods(ctx, libraryID, horizon.start, horizon.end)
+		if err != nil {
+			return nil, err
+		}
+		if slot, ok := fitSlot(horizon, open, length); ok {
+			windows = append(windows, holdWindow{libraryID: libraryID, interval: slot})
+		}
+	}
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].start.Before(windows[j].start) })
+	return windows, nil
+}
+
+// NewHoldWorker returns a worker that retries the waiting holds of every book every
+// interval, promoting those whose copies freed up while their libraries were closed
+func NewHoldWorker(st store.Store, interval time.Duration) *lifecycle.Worker {
+	s := ReservationServer{Store: st, Webhooks: webhook.NewPublisher(st)}
+	return lifecycle.NewWorker("hold promotions", interval, s.promoteAllHolds)
+}
+
+func (s ReservationServer) promoteAllHolds(ctx context.Context) error {
+	isbns, err := s.Store.HeldBooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, isbn := range isbns {
+		s.promoteHolds(ctx, isbn)
+	}
+	return nil
+}
+
+var pbHoldStatuses = map[store.HoldStatus]pb.HoldStatus{
+	store.HoldWaiting:   pb.HoldStatus_WAITING,
+	store.HoldFulfilled: pb.HoldStatus_FULFILLED,
+	store.HoldCancelled: pb.HoldStatus_HOLD_CANCELLED,
+}
+
+func toPBHold(hold store.Hold) *pb.Hold {
+	return &pb.Hold{
+		Id:            hold.ID,
+		Isbn:          hold.ISBN,
+		PatronId:      hold.PatronID,
+		LibraryId:     hold.LibraryID,
+		Days:          int32(hold.Days),
+		Status:        pbHoldStatuses[hold.Status],
+		ReservationId: hold.ReservationID,
+		CreatedAt:     hold.CreatedAt.Format(timeFormat),
+		Position:      int32(hold.Position),
+	}
+}
@@ -0,0 +1,277 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dateFormat is the format of HoursException dates
+const dateFormat = "2006-01-02"
+
+// GetOpeningHours returns the weekly opening hours of a library and its exceptions from today on
+func (s ReservationServer) GetOpeningHours(ctx context.Context, req *pb.GetOpeningHoursReq) (*pb.OpeningHours, error) {
+	library, err := s.Store.GetLibrary(ctx, req.GetLibraryId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openingHours(ctx, library)
+}
+
+// SetOpeningHours replaces the weekly opening hours of a library
+func (s ReservationServer) SetOpeningHours(ctx context.Context, req *pb.SetOpeningHoursReq) (*pb.OpeningHours, error) {
+	hours, err := toStoreOpeningHours(req.GetWeekly())
+	if err != nil {
+		return nil, err
+	}
+
+	library, err := s.Store.GetLibrary(ctx, req.GetLibraryId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.Store.SetOpeningHours(ctx, library.ID, hours); err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Set the opening hours of library %d", library.ID))
+	return s.openingHours(ctx, library)
+}
+
+// AddHoursException closes a library, or changes its hours, on a date
+func (s ReservationServer) AddHoursException(ctx context.Context, req *pb.AddHoursExceptionReq) (*pb.HoursException, error) {
+	exception, err := toStoreHoursException(req.GetException())
+	if err != nil {
+		return nil, err
+	}
+
+	exception, err = s.Store.AddHoursException(ctx, exception)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Added opening hours exception %d to library %d", exception.ID, exception.LibraryID))
+	return toPBHoursException(exception), nil
+}
+
+// DeleteHoursException deletes an opening hours exception of a library
+func (s ReservationServer) DeleteHoursException(ctx context.Context, req *pb.DeleteHoursExceptionReq) (*pb.Empty, error) {
+	err := s.Store.DeleteHoursException(ctx, req.GetLibraryId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Deleted opening hours exception %d of library %d", req.GetId(), req.GetLibraryId()))
+	return &pb.Empty{}, nil
+}
+
+func (s ReservationServer) openingHours(ctx context.Context, library store.Library) (*pb.OpeningHours, error) {
+	hours, err := s.Store.GetOpeningHours(ctx, library.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().In(libraryLocation(library))
+	exceptions, err := s.Store.ListHoursExceptions(ctx, library.ID, localDate(today), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.OpeningHours{LibraryId: library.ID, Timezone: library.Timezone}
+	for _, period := range hours {
+		res.Weekly = append(res.Weekly, &pb.OpeningPeriod{
+			Weekday: pb.Weekday(period.Weekday),
+			Opens:   period.Opens.String(),
+			Closes:  period.Closes.String(),
+		})
+	}
+	for _, exception := range exceptions {
+		res.Exceptions = append(res.Exceptions, toPBHoursException(exception))
+	}
+	return res, nil
+}
+
+// checkOpen returns a FailedPrecondition status unless the library is open at t.
+// when says what happens at t, e.g. "the reservation starts", for the error message.
+func (s ReservationServer) checkOpen(ctx context.Context, libraryID int64, t time.Time, when string) error {
+	library, err := s.Store.GetLibrary(ctx, libraryID)
+	if err != nil {
+		return err
+	}
+	hours, err := s.Store.GetOpeningHours(ctx, libraryID)
+	if err != nil {
+		return err
+	}
+
+	local := t.In(libraryLocation(library))
+	date, minute := localDate(local), store.TimeOfDayOf(local)
+	open, hoursOn, err := s.openAt(ctx, libraryID, hours, date, minute)
+	if err != nil || open {
+		return err
+	}
+	// Midnight is also the end of the previous day, so hours closing at 24:00 include it
+	if minute == 0 {
+		open, _, err = s.openAt(ctx, libraryID, hours, date.AddDate(0, 0, -1), 24*60)
+		if err != nil || open {
+			return err
+		}
+	}
+
+	description := fmt.Sprintf("the library %s is closed at %s %s when %s; %s",
+		library.Name, local.Format("Mon 2006-01-02 15:04"), library.Timezone, when, hoursOn)
+	return withDetails(status.New(codes.FailedPrecondition, description), &errdetails.ErrorInfo{
+		Reason: "LIBRARY_CLOSED",
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"libraryId": fmt.Sprint(library.ID),
+			"time":      local.Format(timeFormat),
+		},
+	})
+}
+
+// openAt reports whether a library with the weekly hours is open at minute on date, and
+// describes its hours on the date. An exception on the date takes precedence over the
+// weekly hours, and a library without any weekly hours is always open.
+func (s ReservationServer) openAt(ctx context.Context, libraryID int64, hours []store.OpeningHours, date time.Time, minute store.TimeOfDay) (bool, string, error) {
+	exceptions, err := s.Store.ListHoursExceptions(ctx, libraryID, date, date)
+	if err != nil {
+		return false, "", err
+	}
+	if len(exceptions) > 0 {
+		exception := exceptions[0]
+		reason := ""
+		if exception.Reason != "" {
+			reason = " (" + exception.Reason + ")"
+		}
+		if exception.Closed {
+			return false, fmt.Sprintf("it is closed all day on %s%s", date.Format(dateFormat), reason), nil
+		}
+		open := exception.Opens <= minute && minute <= exception.Closes
+		return open, fmt.Sprintf("it opens %s-%s on %s%s", exception.Opens, exception.Closes, date.Format(dateFormat), reason), nil
+	}
+
+	if len(hours) == 0 {
+		return true, "", nil
+	}
+
+	var periods []string
+	for _, period := range hours {
+		if period.Weekday != date.Weekday() {
+			continue
+		}
+		if period.Opens <= minute && minute <= period.Closes {
+			return true, "", nil
+		}
+		periods = append(periods, fmt.Sprintf("%s-%s", period.Opens, period.Closes))
+	}
+	if len(periods) == 0 {
+		return false, fmt.Sprintf("it is closed on %ss", date.Weekday()), nil
+	}
+	return false, fmt.Sprintf("on %ss it opens %s", date.Weekday(), strings.Join(periods, ", ")), nil
+}
+
+// toStoreOpeningHours validates weekly opening hours, which mustn't overlap on any weekday
+func toStoreOpeningHours(weekly []*pb.OpeningPeriod) ([]store.OpeningHours, error) {
+	var hours []store.OpeningHours
+	for i, period := range weekly {
+		field := fmt.Sprintf("weekly[%d]", i)
+		if _, ok := pb.Weekday_name[int32(period.GetWeekday())]; !ok {
+			return nil, invalidArgument(field+".weekday", "unknown `"+field+".weekday`")
+		}
+		opens, closes, err := parseOpeningTimes(field, period.GetOpens(), period.GetCloses())
+		if err != nil {
+			return nil, err
+		}
+		hours = append(hours, store.OpeningHours{Weekday: time.Weekday(period.GetWeekday()), Opens: opens, Closes: closes})
+	}
+
+	sorted := append([]store.OpeningHours(nil), hours...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weekday != sorted[j].Weekday {
+			return sorted[i].Weekday < sorted[j].Weekday
+		}
+		return sorted[i].Opens < sorted[j].Opens
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Weekday == sorted[i-1].Weekday && sorted[i].Opens < sorted[i-1].Closes {
+			return nil, invalidArgument("weekly", fmt.Sprintf("the opening hours on %s overlap", sorted[i].Weekday))
+		}
+	}
+
+	return hours, nil
+}
+
+// toStoreHoursException validates an opening hours exception, which closes the
+// library all day unless both opens and closes are set
+func toStoreHoursException(exception *pb.HoursException) (store.HoursException, error) {
+	date, err := time.Parse(dateFormat, exception.GetDate())
+	if err != nil {
+		return store.HoursException{}, invalidArgument("exception.date", "`exception.date` was not formatted as YYYY-MM-DD")
+	}
+
+	hours := store.HoursException{
+		LibraryID: exception.GetLibraryId(),
+		Date:      date,
+		Reason:    strings.TrimSpace(exception.GetReason()),
+	}
+	if exception.GetOpens() == "" && exception.GetCloses() == "" {
+		hours.Closed = true
+		return hours, nil
+	}
+
+	hours.Opens, hours.Closes, err = parseOpeningTimes("exception", exception.GetOpens(), exception.GetCloses())
+	return hours, err
+}
+
+// parseOpeningTimes parses the opening and closing times of field, which must open before it closes
+func parseOpeningTimes(field, opensString, closesString string) (store.TimeOfDay, store.TimeOfDay, error) {
+	opens, err := store.ParseTimeOfDay(opensString)
+	if err != nil {
+		return 0, 0, invalidArgument(field+".opens", "`"+field+".opens` was not formatted as HH:MM")
+	}
+	closes, err := store.ParseTimeOfDay(closesString)
+	if err != nil {
+		return 0, 0, invalidArgument(field+".closes", "`"+field+".closes` was not formatted as HH:MM")
+	}
+	if opens >= closes {
+		return 0, 0, invalidArgument(field+".closes", "`"+field+".closes` must be after `"+field+".opens`")
+	}
+	return opens, closes, nil
+}
+
+func toPBHoursException(exception store.HoursException) *pb.HoursException {
+	res := &pb.HoursException{
+		Id:        exception.ID,
+		LibraryId: exception.LibraryID,
+		Date:      exception.Date.Format(dateFormat),
+		Reason:    exception.Reason,
+	}
+	if !exception.Closed {
+		res.Opens, res.Closes = exception.Opens.String(), exception.Closes.String()
+	}
+	return res
+}
+
+// libraryLocation returns the time zone of a library, falling back to UTC
+func libraryLocation(library store.Library) *time.Location {
+	location, err := time.LoadLocation(library.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// localDate returns midnight UTC of the date of t in its location, as store dates are kept
+func localDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// importBatchSize is how many rows ImportBooks imports per transaction
+const importBatchSize = 500
+
+// importCoordinateTolerance is how many degrees, roughly a kilometre, an imported row's
+// coordinates can be from its library's
+const importCoordinateTolerance = 0.01
+
+// invalidRow is why an imported row failed validation
+type invalidRow string
+
+func (e invalidRow) Error() string { return string(e) }
+
+var pbImportOutcomes = map[store.ImportOutcome]pb.ImportOutcome{
+	store.ImportCreated:  pb.ImportOutcome_CREATED,
+	store.ImportUpdated:  pb.ImportOutcome_UPDATED,
+	store.ImportRejected: pb.ImportOutcome_REJECTED,
+}
+
+// ImportBooks adds or updates a stream of books in batches and reports on every row
+func (s ReservationServer) ImportBooks(stream pb.Reservation_ImportBooksServer) error {
+	ctx := stream.Context()
+
+	libraries, err := s.Store.ListLibraries(ctx)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]store.Library)
+	byID := make(map[int64]store.Library)
+	for _, library := range libraries {
+		byName[library.Name] = library
+		byID[library.ID] = library
+	}
+
+	res := &pb.ImportBooksRes{}
+	var (
+		batch    []store.ImportRow
+		numbers  []int32
+		imported = make(map[string]bool)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		rows, err := s.Store.ImportBooks(ctx, batch, imported, res.DryRun)
+		if err != nil {
+			return err
+		}
+		for i, row := range rows {
+			addImportedRow(res, numbers[i], row)
+		}
+		batch, numbers = batch[:0], numbers[:0]
+		return nil
+	}
+
+	for position := int32(1); ; position++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if position == 1 {
+			res.DryRun = req.GetDryRun()
+		}
+
+		number := req.GetRow()
+		if number == 0 {
+			number = position
+		}
+		book, err := toImportBook(req.GetBook(), byName, byID)
+		batch = append(batch, store.ImportRow{Book: book, Err: err})
+		numbers = append(numbers, number)
+
+		if len(batch) == importBatchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+
+	fmt.Println(fmt.Sprintf("Imported books: %d created, %d updated, %d rejected (dry run: %t)",
+		res.Created, res.Updated, res.Rejected, res.DryRun))
+	return stream.SendAndClose(res)
+}
+
+// toImportBook validates an imported book, resolving its library by ID or name. Unlike
+// AddBook, coordinates aren't ignored: when set they must be those of the library.
+func toImportBook(book *pb.Book, byName map[string]store.Library, byID map[int64]store.Library) (store.Book, error) {
+	imported := store.Book{ISBN: strings.TrimSpace(book.GetIsbn()), Price: float64(book.GetPrice())}
+	switch {
+	case imported.ISBN == "":
+		return imported, invalidRow("`isbn` is required")
+	case book.GetPrice() < 0:
+		return imported, invalidRow("`price` can't be negative")
+	case book.GetLat() < -90 || book.GetLat() > 90:
+		return imported, invalidRow("`lat` must be between -90 and 90")
+	case book.GetLng() < -180 || book.GetLng() > 180:
+		return imported, invalidRow("`lng` must be between -180 and 180")
+	}
+
+	var (
+		library store.Library
+		ok      bool
+	)
+	name := strings.TrimSpace(book.GetLibrary())
+	switch {
+	case book.GetLibraryId() != 0:
+		library, ok = byID[book.GetLibraryId()]
+	case name != "":
+		library, ok = byName[name]
+	default:
+		if book.GetLat() != 0 || book.GetLng() != 0 {
+			return imported, invalidRow("`lat` and `lng` need a `library`")
+		}
+		return imported, nil
+	}
+	if !ok {
+		return imported, store.ErrLibraryNotFound
+	}
+
+	lat, lng := float64(book.GetLat()), float64(book.GetLng())
+	if (lat != 0 || lng != 0) &&
+		(math.Abs(lat-library.Lat) > importCoordinateTolerance || math.Abs(lng-library.Lng) > importCoordinateTolerance) {
+		return imported, invalidRow(fmt.Sprintf("`lat` and `lng` aren't the location of library %q", library.Name))
+	}
+	imported.LibraryID = library.ID
+	return imported, nil
+}
+
+func addImportedRow(res *pb.ImportBooksRes, number int32, row store.ImportRow) {
+	imported := &pb.ImportedRow{Row: number, Isbn: row.Book.ISBN, Outcome: pbImportOutcomes[row.Outcome]}
+	switch row.Outcome {
+	case store.ImportCreated:
+		res.Created++
+	case store.ImportUpdated:
+		res.Updated++
+	case store.ImportRejected:
+		res.Rejected++
+		imported.Error, imported.Reason = row.Err.Error(), errorReason(row.Err)
+		if _, ok := row.Err.(invalidRow); ok {
+			imported.Reason = "INVALID_ROW"
+		}
+	}
+	res.Rows = append(res.Rows, imported)
+}
@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// CreateLibrary registers a new library
+func (s ReservationServer) CreateLibrary(ctx context.Context, req *pb.CreateLibraryReq) (*pb.Library, error) {
+	library, err := toStoreLibrary(req.GetLibrary())
+	if err != nil {
+		return nil, err
+	}
+
+	library, err = s.Store.CreateLibrary(ctx, library)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Created library %d", library.ID))
+	return toPBLibrary(library), nil
+}
+
+// GetLibrary returns the library with the matching ID
+func (s ReservationServer) GetLibrary(ctx context.Context, req *pb.GetLibraryReq) (*pb.Library, error) {
+	library, err := s.Store.GetLibrary(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBLibrary(library), nil
+}
+
+// ListLibraries returns every library ordered by name
+func (s ReservationServer) ListLibraries(ctx context.Context, req *pb.Empty) (*pb.ListLibrariesRes, error) {
+	libraries, err := s.Store.ListLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListLibrariesRes{}
+	for _, library := range libraries {
+		res.Libraries = append(res.Libraries, toPBLibrary(library))
+	}
+	return res, nil
+}
+
+// UpdateLibrary replaces every field of a library but its ID and creation time
+func (s ReservationServer) UpdateLibrary(ctx context.Context, req *pb.UpdateLibraryReq) (*pb.Library, error) {
+	library, err := toStoreLibrary(req.GetLibrary())
+	if err != nil {
+		return nil, err
+	}
+	library.ID = req.GetLibrary().GetId()
+
+	library, err = s.Store.UpdateLibrary(ctx, library)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Updated library %d", library.ID))
+	return toPBLibrary(library), nil
+}
+
+// DeleteLibrary deletes a library that holds no copies
+func (s ReservationServer) DeleteLibrary(ctx context.Context, req *pb.DeleteLibraryReq) (*pb.Empty, error) {
+	err := s.Store.DeleteLibrary(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Deleted library %d", req.GetId()))
+	return &pb.Empty{}, nil
+}
+
+// resolveLibraryID returns id, or the ID of the library called name if id is 0.
+// It returns 0 if neither is set.
+func resolveLibraryID(ctx context.Context, st store.Store, id int64, name string) (int64, error) {
+	if id != 0 || name == "" {
+		return id, nil
+	}
+
+	libraries, err := st.ListLibraries(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, library := range libraries {
+		if library.Name == name {
+			return library.ID, nil
+		}
+	}
+	return 0, store.ErrLibraryNotFound
+}
+
+// toStoreLibrary validates the writable fields of a library
+func toStoreLibrary(library *pb.Library) (store.Library, error) {
+	name := strings.TrimSpace(library.GetName())
+	if name == "" {
+		return store.Library{}, invalidArgument("library.name", "`library.name` is required")
+	}
+
+	lat, lng := float64(library.GetLat()), float64(library.GetLng())
+	if lat < -90 || lat > 90 {
+		return store.Library{}, invalidArgument("library.lat", "`library.lat` must be between -90 and 90")
+	}
+	if lng < -180 || lng > 180 {
+		return store.Library{}, invalidArgument("library.lng", "`library.lng` must be between -180 and 180")
+	}
+
+	timezone := library.GetTimezone()
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return store.Library{}, invalidArgument("library.timezone", "`library.timezone` is not an IANA time zone")
+	}
+
+	email := strings.TrimSpace(library.GetEmail())
+	if email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil {
+			return store.Library{}, invalidArgument("library.email", "`library.email` is not a valid email address")
+		}
+		email = address.Address
+	}
+
+	fees := library.GetLateFeePolicy()
+	switch {
+	case fees.GetPerDay() < 0:
+		return store.Library{}, invalidArgument("library.lateFeePolicy.perDay", "`library.lateFeePolicy.perDay` can't be negative")
+	case fees.GetPercentPerDay() < 0 || fees.GetPercentPerDay() > 100:
+		return store.Library{}, invalidArgument("library.lateFeePolicy.percentPerDay", "`library.lateFeePolicy.percentPerDay` must be between 0 and 100")
+	case fees.GetMax() < 0:
+		return store.Library{}, invalidArgument("library.lateFeePolicy.max", "`library.lateFeePolicy.max` can't be negative")
+	}
+	if library.GetMaxRenewals() < 0 {
+		return store.Library{}, invalidArgument("library.maxRenewals", "`library.maxRenewals` can't be negative")
+	}
+	if library.GetMaxLoanDays() < 0 {
+		return store.Library{}, invalidArgument("library.maxLoanDays", "`library.maxLoanDays` can't be negative")
+	}
+
+	return store.Library{
+		Name:     name,
+		Address:  strings.TrimSpace(library.GetAddress()),
+		Lat:      lat,
+		Lng:      lng,
+		Timezone: timezone,
+		Email:    email,
+		Phone:    strings.TrimSpace(library.GetPhone()),
+		LateFees: store.LateFeePolicy{
+			PerDay:        float64(fees.GetPerDay()),
+			PercentPerDay: float64(fees.GetPercentPerDay()),
+			Max:           float64(fees.GetMax()),
+		},
+		MaxRenewals: int(library.GetMaxRenewals()),
+		MaxLoanDays: int(library.GetMaxLoanDays()),
+	}, nil
+}
+
+func toPBLibrary(library store.Library) *pb.Library {
+	return &pb.Library{
+		Id:        library.ID,
+		Name:      library.Name,
+		Address:   library.Address,
+		Lat:       float32(library.Lat),
+		Lng:       float32(library.Lng),
+		Timezone:  library.Timezone,
+		Email:     library.Email,
+		Phone:     library.Phone,
+		CreatedAt: library.CreatedAt.Format(timeFormat),
+		LateFeePolicy: &pb.LateFeePolicy{
+			PerDay:        float32(library.LateFees.PerDay),
+			PercentPerDay: float32(library.LateFees.PercentPerDay),
+			Max:           float32(library.LateFees.Max),
+		},
+		MaxRenewals: int32(library.MaxRenewals),
+		MaxLoanDays: int32(library.MaxLoanDays),
+	}
+}
@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/lifecycle"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+)
+
+// ListOverdue lists the books still checked out after their reservation ended
+func (s ReservationServer) ListOverdue(ctx context.Context, req *pb.ListOverdueReq) (*pb.ListReservationsRes, error) {
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	query := store.ListReservationsQuery{
+		LibraryID: req.GetLibraryId(),
+		Statuses:  []store.ReservationStatus{store.StatusCheckedOut},
+		EndBefore: time.Now(),
+		Order:     store.OrderByStart,
+		// Fetch one more than requested to find out whether there is another page
+		Limit: limit + 1,
+	}
+
+	if req.GetPageToken() != "" {
+		var token reservationPageToken
+		if err = decodePageToken(req.GetPageToken(), &token); err != nil {
+			return nil, err
+		}
+		query.After = &store.ReservationCursor{Time: token.Time, ID: token.ID}
+	}
+
+	reservations, err := s.Store.ListReservations(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListReservationsRes{}
+	if len(reservations) > limit {
+		reservations = reservations[:limit]
+
+		last := reservations[limit-1]
+		res.NextPageToken = encodePageToken(reservationPageToken{Time: last.Start, ID: last.ID})
+	}
+	for _, reservation := range reservations {
+		res.Reservations = append(res.Reservations, toPBReservation(reservation))
+	}
+
+	return res, nil
+}
+
+// NewOverdueWorker returns a worker that flags overdue checkouts every interval,
+// publishing a book.overdue event for each
+func NewOverdueWorker(st store.Store, interval time.Duration) *lifecycle.Worker {
+	s := ReservationServer{Store: st, Webhooks: webhook.NewPublisher(st)}
+	return lifecycle.NewWorker("overdue checks", interval, s.flagOverdue)
+}
+
+func (s ReservationServer) flagOverdue(ctx context.Context) error {
+	reservations, err := s.Store.FlagOverdue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		fmt.Println(fmt.Sprintf("Reservation %d of book with ISBN: %s is overdue", reservation.ID, reservation.ISBN))
+		s.publish(ctx, webhook.BookOverdue, toPBReservation(reservation))
+	}
+	return nil
+}
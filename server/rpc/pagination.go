@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// pageSize validates a requested page size, applying the default when it is unset
+func pageSize(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, invalidArgument("pageSize", "`pageSize` must not be negative")
+	case requested == 0:
+		return defaultPageSize, nil
+	case requested > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(requested), nil
+}
+
+// encodePageToken serializes the sort key of the last item of a page into an opaque token
+func encodePageToken(cursor interface{}) string {
+	contents, err := json.Marshal(cursor)
+	if err != nil {
+		panic(fmt.Sprintf("page cursor %T is not serializable: %v", cursor, err))
+	}
+	return base64.RawURLEncoding.EncodeToString(contents)
+}
+
+// decodePageToken is the inverse of encodePageToken
+func decodePageToken(token string, cursor interface{}) error {
+	contents, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(contents, cursor)
+	}
+	if err != nil {
+		return invalidArgument("pageToken", "invalid `pageToken`")
+	}
+	return nil
+}
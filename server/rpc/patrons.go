@@ -0,0 +1,110 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// CreatePatron registers a new patron
+func (s ReservationServer) CreatePatron(ctx context.Context, req *pb.CreatePatronReq) (*pb.Patron, error) {
+	patron, err := toStorePatron(req.GetPatron())
+	if err != nil {
+		return nil, err
+	}
+
+	patron, err = s.Store.CreatePatron(ctx, patron)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Created patron %d", patron.ID))
+	return toPBPatron(patron), nil
+}
+
+// GetPatron returns the patron with the matching ID
+func (s ReservationServer) GetPatron(ctx context.Context, req *pb.GetPatronReq) (*pb.Patron, error) {
+	patron, err := s.Store.GetPatron(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBPatron(patron), nil
+}
+
+// UpdatePatron replaces the name, email and phone of a patron
+func (s ReservationServer) UpdatePatron(ctx context.Context, req *pb.UpdatePatronReq) (*pb.Patron, error) {
+	patron, err := toStorePatron(req.GetPatron())
+	if err != nil {
+		return nil, err
+	}
+	patron.ID = req.GetPatron().GetId()
+
+	patron, err = s.Store.UpdatePatron(ctx, patron)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Updated patron %d", patron.ID))
+	return toPBPatron(patron), nil
+}
+
+// ListFees lists the fees charged to a patron, newest first
+func (s ReservationServer) ListFees(ctx context.Context, req *pb.ListFeesReq) (*pb.ListFeesRes, error) {
+	patron, err := s.Store.GetPatron(ctx, req.GetPatronId())
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := s.Store.ListFees(ctx, patron.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListFeesRes{Balance: float32(patron.Balance)}
+	for _, fee := range fees {
+		res.Fees = append(res.Fees, &pb.Fee{
+			Id:            fee.ID,
+			PatronId:      fee.PatronID,
+			ReservationId: fee.ReservationID,
+			Amount:        float32(fee.Amount),
+			DaysLate:      int32(fee.DaysLate),
+			CreatedAt:     fee.CreatedAt.Format(timeFormat),
+		})
+	}
+	return res, nil
+}
+
+// toStorePatron validates the writable fields of a patron
+func toStorePatron(patron *pb.Patron) (store.Patron, error) {
+	name := strings.TrimSpace(patron.GetName())
+	if name == "" {
+		return store.Patron{}, invalidArgument("patron.name", "`patron.name` is required")
+	}
+
+	address, err := mail.ParseAddress(patron.GetEmail())
+	if err != nil {
+		return store.Patron{}, invalidArgument("patron.email", "`patron.email` is not a valid email address")
+	}
+
+	return store.Patron{
+		Name:  name,
+		Email: address.Address,
+		Phone: strings.TrimSpace(patron.GetPhone()),
+	}, nil
+}
+
+func toPBPatron(patron store.Patron) *pb.Patron {
+	return &pb.Patron{
+		Id:        patron.ID,
+		Name:      patron.Name,
+		Email:     patron.Email,
+		Phone:     patron.Phone,
+		CreatedAt: patron.CreatedAt.Format(timeFormat),
+		Balance:   float32(patron.Balance),
+	}
+}
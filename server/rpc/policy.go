@@ -0,0 +1,298 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmaroli/scheduling-rpc/auth"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// servicePrefix prefixes the full method name of every Reservation RPC
+const servicePrefix = "/reservations.Reservation/"
+
+// access is how much of a method a role may use
+type access int
+
+const (
+	// denied callers can't use the method at all
+	denied access = iota
+	// anyResource callers can use the method on anything
+	anyResource
+	// ownResource callers can only use the method on their own resources: librarians
+	// on their library and its copies and reservations, patrons on their own reservations
+	ownResource
+)
+
+// resource is what a request acts on, for ownResource checks
+type resource struct {
+	libraryID int64
+	patronID  int64
+}
+
+// rule is the access each role has to a method. Admins can use every method.
+type rule struct {
+	librarian access
+	patron    access
+	// resource looks up what the request acts on, needed when either role has ownResource access
+	resource func(ctx context.Context, st store.Store, req interface{}) (resource, error)
+}
+
+// policy declares who may call each Reservation RPC. Methods missing from it are admin only.
+var policy = map[string]rule{
+	"GetAllBooks": {librarian: anyResource, patron: anyResource},
+	"GetBook":     {librarian: anyResource, patron: anyResource},
+	"Search":      {librarian: anyResource, patron: anyResource},
+
+	"GetLibrary":    {librarian: anyResource, patron: anyResource},
+	"ListLibraries": {librarian: anyResource, patron: anyResource},
+	"UpdateLibrary": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{libraryID: req.(*pb.UpdateLibraryReq).GetLibrary().GetId()}, nil
+	}},
+
+	"GetOpeningHours": {librarian: anyResource, patron: anyResource},
+	"SetOpeningHours": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{libraryID: req.(*pb.SetOpeningHoursReq).GetLibraryId()}, nil
+	}},
+	"AddHoursException": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{libraryID: req.(*pb.AddHoursExceptionReq).GetException().GetLibraryId()}, nil
+	}},
+	"DeleteHoursException": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{libraryID: req.(*pb.DeleteHoursExceptionReq).GetLibraryId()}, nil
+	}},
+
+	"AddBook": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		book := req.(*pb.AddBookReq).GetBook()
+		libraryID, err := resolveLibraryID(ctx, st, book.GetLibraryId(), book.GetLibrary())
+		return resource{libraryID: libraryID}, err
+	}},
+	// Books are shared by every library, so only admins can delete them along with all of their copies
+	"DeleteBook": {},
+	// Imports can add copies at any library, so only admins can run them
+	"ImportBooks": {},
+
+	"ListCopies":      {librarian: anyResource, patron: anyResource},
+	"GetAvailability": {librarian: anyResource, patron: anyResource},
+	"AddCopy": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		copy := req.(*pb.AddCopyReq).GetCopy()
+		libraryID, err := resolveLibraryID(ctx, st, copy.GetLibraryId(), copy.GetLibrary())
+		return resource{libraryID: libraryID}, err
+	}},
+	"DeleteCopy": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return copyResource(ctx, st, req.(*pb.DeleteCopyReq).GetId())
+	}},
+
+	"CheckoutBook": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		checkout := req.(*pb.CheckoutBookReq)
+		start, end, err := parseTimes(checkout.GetStartDate(), checkout.GetEndDate())
+		if err != nil {
+			return resource{}, err
+		}
+		reservation, err := st.FindReservation(ctx, checkout.GetIsbn(), checkout.GetCopyId(), start, end)
+		return resource{libraryID: reservation.LibraryID, patronID: reservation.PatronID}, err
+	}},
+	"ReturnBook": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return checkedOutResource(ctx, st, req.(*pb.ReturnBookReq).GetIsbn(), req.(*pb.ReturnBookReq).GetCopyId())
+	}},
+	// Librarians reserve on behalf of patrons, whichever library the free copy is at
+	"ReserveBook": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.ReserveBookReq).GetPatronId()}, nil
+	}},
+
+	"ReserveRecurring": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.ReserveRecurringReq).GetPatronId()}, nil
+	}},
+	// A series can span libraries, so librarians manage every series like they reserve for any patron
+	"GetSeries": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return seriesResource(ctx, st, req.(*pb.GetSeriesReq).GetId())
+	}},
+	"CancelSeries": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return seriesResource(ctx, st, req.(*pb.CancelSeriesReq).GetId())
+	}},
+
+	"PlaceHold": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.PlaceHoldReq).GetPatronId()}, nil
+	}},
+	"GetHold": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return holdResource(ctx, st, req.(*pb.GetHoldReq).GetId())
+	}},
+	// Patrons see their own place in a queue with GetHold, not who else is in it
+	"ListHolds": {librarian: anyResource},
+	"CancelHold": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return holdResource(ctx, st, req.(*pb.CancelHoldReq).GetId())
+	}},
+
+	"CreatePatron": {librarian: anyResource},
+	"GetPatron": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.GetPatronReq).GetId()}, nil
+	}},
+	"UpdatePatron": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.UpdatePatronReq).GetPatron().GetId()}, nil
+	}},
+	"ListFees": {librarian: anyResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{patronID: req.(*pb.ListFeesReq).GetPatronId()}, nil
+	}},
+
+	"ListReservations": {librarian: ownResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		list := req.(*pb.ListReservationsReq)
+		libraryID, err := resolveLibraryID(ctx, st, list.GetLibraryId(), list.GetLibrary())
+		return resource{libraryID: libraryID, patronID: list.GetPatronId()}, err
+	}},
+	// Librarians list the overdue books of their own library
+	"ListOverdue": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return resource{libraryID: req.(*pb.ListOverdueReq).GetLibraryId()}, nil
+	}},
+	"GetReservation": {librarian: ownResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return reservationResource(ctx, st, req.(*pb.GetReservationReq).GetId())
+	}},
+	"CheckoutReservation": {librarian: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return reservationResource(ctx, st, req.(*pb.CheckoutReservationReq).GetId())
+	}},
+	"CancelReservation": {librarian: ownResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return reservationResource(ctx, st, req.(*pb.CancelReservationReq).GetId())
+	}},
+	"RescheduleReservation": {librarian: ownResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return reservationResource(ctx, st, req.(*pb.RescheduleReservationReq).GetId())
+	}},
+	"RenewLoan": {librarian: ownResource, patron: ownResource, resource: func(ctx context.Context, st store.Store, req interface{}) (resource, error) {
+		return reservationResource(ctx, st, req.(*pb.RenewLoanReq).GetId())
+	}},
+}
+
+func copyResource(ctx context.Context, st store.Store, id int64) (resource, error) {
+	copy, err := st.GetCopy(ctx, id)
+	return resource{libraryID: copy.LibraryID}, err
+}
+
+// checkedOutResource finds the library of the checked out copy of a book that ReturnBook would return
+func checkedOutResource(ctx context.Context, st store.Store, isbn string, copyID int64) (resource, error) {
+	if copyID != 0 {
+		return copyResource(ctx, st, copyID)
+	}
+
+	reservations, err := st.ListReservations(ctx, store.ListReservationsQuery{
+		ISBN:     isbn,
+		Statuses: []store.ReservationStatus{store.StatusCheckedOut},
+		Limit:    2,
+	})
+	switch {
+	case err != nil:
+		return resource{}, err
+	case len(reservations) == 0:
+		return resource{}, store.ErrNotCheckedOut
+	case len(reservations) > 1:
+		return resource{}, store.ErrCopyRequired
+	}
+	return resource{libraryID: reservations[0].LibraryID}, nil
+}
+
+func holdResource(ctx context.Context, st store.Store, id int64) (resource, error) {
+	hold, err := st.GetHold(ctx, id)
+	return resource{libraryID: hold.LibraryID, patronID: hold.PatronID}, err
+}
+
+func seriesResource(ctx context.Context, st store.Store, id int64) (resource, error) {
+	series, err := st.GetSeries(ctx, id)
+	return resource{patronID: series.PatronID}, err
+}
+
+func reservationResource(ctx context.Context, st store.Store, id int64) (resource, error) {
+	reservation, err := st.GetReservation(ctx, id)
+	return resource{libraryID: reservation.LibraryID, patronID: reservation.PatronID}, err
+}
+
+// authorizer enforces the policy on the principal put in the context by the auth interceptor
+type authorizer struct {
+	store store.Store
+}
+
+// Unary authorizes unary RPCs
+func (a authorizer) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Stream authorizes streaming RPCs. Their requests aren't known up front, so
+// their rules can't use ownResource.
+func (a authorizer) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod, nil); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authorize returns PermissionDenied unless the principal may call method with req
+func (a authorizer) authorize(ctx context.Context, method string, req interface{}) error {
+	if !strings.HasPrefix(method, servicePrefix) {
+		return nil
+	}
+
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return permissionDenied("the caller is not authenticated")
+	}
+	if principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+
+	name := strings.TrimPrefix(method, servicePrefix)
+	r := policy[name]
+
+	var librarian, patron access
+	if principal.HasRole(auth.RoleLibrarian) {
+		librarian = r.librarian
+	}
+	if principal.HasRole(auth.RolePatron) {
+		patron = r.patron
+	}
+
+	if librarian == anyResource || patron == anyResource {
+		return nil
+	}
+	if librarian == denied && patron == denied {
+		return permissionDenied(fmt.Sprintf("%s requires one of the roles %v", name, roles(r)))
+	}
+
+	res, err := r.resource(ctx, a.store, req)
+	if err != nil {
+		return err
+	}
+	if librarian == ownResource && principal.LibraryID != 0 && res.libraryID == principal.LibraryID {
+		return nil
+	}
+	if patron == ownResource && principal.PatronID != 0 && res.patronID == principal.PatronID {
+		return nil
+	}
+
+	if patron == ownResource {
+		return permissionDenied("patrons can only act on their own reservations")
+	}
+	return permissionDenied(fmt.Sprintf("librarians can only act on the library %d", principal.LibraryID))
+}
+
+// roles lists the roles with any access to the method in r
+func roles(r rule) []string {
+	roles := []string{auth.RoleAdmin}
+	if r.librarian != denied {
+		roles = append(roles, auth.RoleLibrarian)
+	}
+	if r.patron != denied {
+		roles = append(roles, auth.RolePatron)
+	}
+	return roles
+}
+
+func permissionDenied(description string) error {
+	return withDetails(status.New(codes.PermissionDenied, description), &errdetails.ErrorInfo{
+		Reason: "PERMISSION_DENIED",
+		Domain: errorDomain,
+	})
+}
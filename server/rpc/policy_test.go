@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/auth"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminOnly lists the methods deliberately left out of the policy
+var adminOnly = map[string]bool{
+	"CreateLibrary":         true,
+	"DeleteLibrary":         true,
+	"CreateWebhook":         true,
+	"GetWebhook":            true,
+	"ListWebhooks":          true,
+	"UpdateWebhook":         true,
+	"DeleteWebhook":         true,
+	"ListWebhookDeliveries": true,
+}
+
+// serviceMethods returns the name of every method of the Reservation service
+func serviceMethods(t *testing.T) []string {
+	server := grpc.NewServer()
+	pb.RegisterReservationServer(server, ReservationServer{})
+
+	info, ok := server.GetServiceInfo()["reservations.Reservation"]
+	if !ok {
+		t.Fatal("the Reservation service isn't registered")
+	}
+	var methods []string
+	for _, method := range info.Methods {
+		methods = append(methods, method.Name)
+	}
+	return methods
+}
+
+// policyFixture is the data the policy tests act on
+type policyFixture struct {
+	authorizer          authorizer
+	library, other      store.Library
+	patron, otherPatron store.Patron
+	// reservation is the patron's at library, and otherReservation the other patron's at other
+	reservation, otherReservation store.Reservation
+}
+
+func newPolicyFixture(t *testing.T) policyFixture {
+	ctx := context.Background()
+	st := store.NewMemory()
+	f := policyFixture{authorizer: authorizer{store: st}}
+
+	var err error
+	if f.library, err = st.CreateLibrary(ctx, store.Library{Name: "Central"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.other, err = st.CreateLibrary(ctx, store.Library{Name: "North"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.patron, err = st.CreatePatron(ctx, store.Patron{Name: "Ada", Email: "ada@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.otherPatron, err = st.CreatePatron(ctx, store.Patron{Name: "Bob", Email: "bob@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = st.AddBook(ctx, store.Book{ISBN: "111", LibraryID: f.library.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err = st.AddBook(ctx, store.Book{ISBN: "222", LibraryID: f.other.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Add(24 * time.Hour)
+	f.reservation, err = st.Reserve(ctx, store.Reservation{ISBN: "111", PatronID: f.patron.ID, Start: start, End: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.otherReservation, err = st.Reserve(ctx, store.Reservation{ISBN: "222", PatronID: f.otherPatron.ID, Start: start, End: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f policyFixture) authorize(principal auth.Principal, method string, req interface{}) codes.Code {
+	ctx := auth.NewContext(context.Background(), principal)
+	return status.Code(f.authorizer.authorize(ctx, servicePrefix+method, req))
+}
+
+func TestPolicyCoversEveryMethod(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, method := range serviceMethods(t) {
+		methods[method] = true
+		_, inPolicy := policy[method]
+		if inPolicy == adminOnly[method] {
+			t.Errorf("%s must be either in the policy or admin only, not both or neither", method)
+		}
+	}
+	for method := range policy {
+		if !methods[method] {
+			t.Errorf("the policy has %s, which isn't a Reservation method", method)
+		}
+	}
+}
+
+func TestAdminsCanCallEveryMethod(t *testing.T) {
+	f := newPolicyFixture(t)
+	admin := auth.Principal{Roles: []string{auth.RoleAdmin}}
+
+	for _, method := range serviceMethods(t) {
+		if code := f.authorize(admin, method, nil); code != codes.OK {
+			t.Errorf("admin calling %s: got %v, want OK", method, code)
+		}
+	}
+}
+
+func TestAdminOnlyMethods(t *testing.T) {
+	f := newPolicyFixture(t)
+	librarian := auth.Principal{Roles: []string{auth.RoleLibrarian}, LibraryID: f.library.ID}
+	patron := auth.Principal{Roles: []string{auth.RolePatron}, PatronID: f.patron.ID}
+
+	for _, method := range serviceMethods(t) {
+		r := policy[method]
+		if r.librarian != denied || r.patron != denied {
+			continue
+		}
+		// Admin only methods are denied before the request is looked at
+		for _, principal := range []auth.Principal{librarian, patron} {
+			if code := f.authorize(principal, method, nil); code != codes.PermissionDenied {
+				t.Errorf("%v calling %s: got %v, want PermissionDenied", principal.Roles, method, code)
+			}
+		}
+	}
+
+	for _, method := range []string{"CreateWebhook", "DeleteLibrary"} {
+		if _, ok := policy[method]; ok {
+			t.Errorf("%s should be admin only", method)
+		}
+	}
+}
+
+func TestOwnResourcePolicy(t *testing.T) {
+	f := newPolicyFixture(t)
+	librarian := auth.Principal{Roles: []string{auth.RoleLibrarian}, LibraryID: f.library.ID}
+	patron := auth.Principal{Roles: []string{auth.RolePatron}, PatronID: f.patron.ID}
+
+	start := time.Now().Add(48 * time.Hour)
+	reserve := func(patronID int64) *pb.ReserveBookReq {
+		return &pb.ReserveBookReq{
+			Isbn:      "111",
+			PatronId:  patronID,
+			StartDate: start.Format(timeFormat),
+			EndDate:   start.Add(time.Hour).Format(timeFormat),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		method    string
+		req       interface{}
+		want      codes.Code
+	}{
+		{"librarian updates own library", librarian, "UpdateLibrary",
+			&pb.UpdateLibraryReq{Library: &pb.Library{Id: f.library.ID}}, codes.OK},
+		{"librarian updates another library", librarian, "UpdateLibrary",
+			&pb.UpdateLibraryReq{Library: &pb.Library{Id: f.other.ID}}, codes.PermissionDenied},
+		{"librarian gets own library's reservation", librarian, "GetReservation",
+			&pb.GetReservationReq{Id: f.reservation.ID}, codes.OK},
+		{"librarian gets another library's reservation", librarian, "GetReservation",
+			&pb.GetReservationReq{Id: f.otherReservation.ID}, codes.PermissionDenied},
+		{"librarian cancels own library's reservation", librarian, "CancelReservation",
+			&pb.CancelReservationReq{Id: f.reservation.ID}, codes.OK},
+		{"librarian cancels another library's reservation", librarian, "CancelReservation",
+			&pb.CancelReservationReq{Id: f.otherReservation.ID}, codes.PermissionDenied},
+		{"librarian lists own library's overdue books", librarian, "ListOverdue",
+			&pb.ListOverdueReq{LibraryId: f.library.ID}, codes.OK},
+		{"librarian lists another library's overdue books", librarian, "ListOverdue",
+			&pb.ListOverdueReq{LibraryId: f.other.ID}, codes.PermissionDenied},
+
+		{"patron gets own reservation", patron, "GetReservation",
+			&pb.GetReservationReq{Id: f.reservation.ID}, codes.OK},
+		{"patron gets someone else's reservation", patron, "GetReservation",
+			&pb.GetReservationReq{Id: f.otherReservation.ID}, codes.PermissionDenied},
+		{"patron cancels own reservation", patron, "CancelReservation",
+			&pb.CancelReservationReq{Id: f.reservation.ID}, codes.OK},
+		{"patron cancels someone else's reservation", patron, "CancelReservation",
+			&pb.CancelReservationReq{Id: f.otherReservation.ID}, codes.PermissionDenied},
+		{"patron renews own loan", patron, "RenewLoan",
+			&pb.RenewLoanReq{Id: f.reservation.ID}, codes.OK},
+		{"patron renews someone else's loan", patron, "RenewLoan",
+			&pb.RenewLoanReq{Id: f.otherReservation.ID}, codes.PermissionDenied},
+		{"patron reserves for themselves", patron, "ReserveBook", reserve(f.patron.ID), codes.OK},
+		{"patron reserves for someone else", patron, "ReserveBook", reserve(f.otherPatron.ID), codes.PermissionDenied},
+		{"patron updates a library", patron, "UpdateLibrary",
+			&pb.UpdateLibraryReq{Library: &pb.Library{Id: f.library.ID}}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := f.authorize(tt.principal, tt.method, tt.req); code != tt.want {
+				t.Errorf("got %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnauthenticatedCallersAreDenied(t *testing.T) {
+	f := newPolicyFixture(t)
+
+	err := f.authorizer.authorize(context.Background(), servicePrefix+"GetBook", &pb.GetBookReq{Isbn: "111"})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("got %v, want PermissionDenied", code)
+	}
+}
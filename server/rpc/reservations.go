@@ -0,0 +1,249 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+)
+
+// GetReservation returns the reservation with the matching ID
+func (s ReservationServer) GetReservation(ctx context.Context, req *pb.GetReservationReq) (*pb.BookReservation, error) {
+	reservation, err := s.Store.GetReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBReservation(reservation), nil
+}
+
+// CheckoutReservation checks out the book held by a reservation
+func (s ReservationServer) CheckoutReservation(ctx context.Context, req *pb.CheckoutReservationReq) (*pb.BookReservation, error) {
+	reservation, err := s.Store.GetReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkOpen(ctx, reservation.LibraryID, time.Now(), "the book is checked out"); err != nil {
+		return nil, err
+	}
+
+	reservation, err = s.Store.Checkout(ctx, req.GetId(), req.GetPatronId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Checked out book with ISBN: %s", reservation.ISBN))
+	res := toPBReservation(reservation)
+	s.publish(ctx, webhook.BookCheckedOut, res)
+	return res, nil
+}
+
+// reservationPageToken is the cursor behind ListReservationsRes.nextPageToken
+type reservationPageToken struct {
+	Order pb.ReservationOrder `json:"o"`
+	Time  time.Time           `json:"t"`
+	ID    int64               `json:"i"`
+}
+
+var storeReservationOrders = map[pb.ReservationOrder]store.ReservationOrder{
+	pb.ReservationOrder_START_ASC:    store.OrderByStart,
+	pb.ReservationOrder_START_DESC:   store.OrderByStartDesc,
+	pb.ReservationOrder_CREATED_ASC:  store.OrderByCreated,
+	pb.ReservationOrder_CREATED_DESC: store.OrderByCreatedDesc,
+}
+
+// ListReservations lists reservations by book, library, time window and status
+func (s ReservationServer) ListReservations(ctx context.Context, req *pb.ListReservationsReq) (*pb.ListReservationsRes, error) {
+	order, ok := storeReservationOrders[req.GetOrderBy()]
+	if !ok {
+		return nil, invalidArgument("orderBy", "unknown `orderBy`")
+	}
+
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	query := store.ListReservationsQuery{
+		ISBN:      req.GetIsbn(),
+		Library:   req.GetLibrary(),
+		LibraryID: req.GetLibraryId(),
+		PatronID:  req.GetPatronId(),
+		Order:     order,
+		// Fetch one more than requested to find out whether there is another page
+		Limit: limit + 1,
+	}
+
+	query.Start, err = parseOptionalTime("startDate", req.GetStartDate())
+	if err != nil {
+		return nil, err
+	}
+	query.End, err = parseOptionalTime("endDate", req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range req.GetStatuses() {
+		storeStatus, ok := storeReservationStatuses[status]
+		if !ok {
+			return nil, invalidArgument("statuses", fmt.Sprintf("unknown status %s", status))
+		}
+		query.Statuses = append(query.Statuses, storeStatus)
+	}
+
+	if req.GetPageToken() != "" {
+		var token reservationPageToken
+		if err = decodePageToken(req.GetPageToken(), &token); err != nil {
+			return nil, err
+		}
+		if token.Order != req.GetOrderBy() {
+			return nil, invalidArgument("pageToken", "`pageToken` was issued for a different `orderBy`")
+		}
+		query.After = &store.ReservationCursor{Time: token.Time, ID: token.ID}
+	}
+
+	reservations, err := s.Store.ListReservations(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListReservationsRes{}
+	if len(reservations) > limit {
+		reservations = reservations[:limit]
+
+		last := reservations[limit-1]
+		token := reservationPageToken{Order: req.GetOrderBy(), Time: last.Start, ID: last.ID}
+		if order == store.OrderByCreated || order == store.OrderByCreatedDesc {
+			token.Time = last.CreatedAt
+		}
+		res.NextPageToken = encodePageToken(token)
+	}
+	for _, reservation := range reservations {
+		res.Reservations = append(res.Reservations, toPBReservation(reservation))
+	}
+
+	return res, nil
+}
+
+// CancelReservation cancels a reservation that hasn't been checked out yet
+func (s ReservationServer) CancelReservation(ctx context.Context, req *pb.CancelReservationReq) (*pb.BookReservation, error) {
+	reservation, err := s.Store.CancelReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Cancelled reservation %d", reservation.ID))
+	res := toPBReservation(reservation)
+	s.publish(ctx, webhook.ReservationCancelled, res)
+	s.promoteHolds(ctx, reservation.ISBN)
+	return res, nil
+}
+
+// RescheduleReservation moves a reservation to a new window, keeping the old one if the new one is
+// taken or its library is closed when the new window starts or ends
+func (s ReservationServer) RescheduleReservation(ctx context.Context, req *pb.RescheduleReservationReq) (*pb.BookReservation, error) {
+	var startTime, endTime, err = parseTimes(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.Store.GetReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkOpen(ctx, reservation.LibraryID, startTime, "the reservation starts"); err != nil {
+		return nil, err
+	}
+	if err = s.checkOpen(ctx, reservation.LibraryID, endTime, "the reservation ends"); err != nil {
+		return nil, err
+	}
+
+	reservation, err = s.Store.RescheduleReservation(ctx, req.GetId(), startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Rescheduled reservation %d", reservation.ID))
+	res := toPBReservation(reservation)
+	s.publish(ctx, webhook.ReservationRescheduled, res)
+	s.promoteHolds(ctx, reservation.ISBN)
+	return res, nil
+}
+
+// RenewLoan extends a checked out reservation to a later end
+func (s ReservationServer) RenewLoan(ctx context.Context, req *pb.RenewLoanReq) (*pb.BookReservation, error) {
+	if req.GetEndDate() == "" {
+		return nil, invalidArgument("endDate", "`endDate` is required")
+	}
+	endTime, err := parseOptionalTime("endDate", req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.Store.GetReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !endTime.After(reservation.End) {
+		return nil, invalidArgument("endDate", "`endDate` must be after the reservation's current end")
+	}
+	if err = s.checkOpen(ctx, reservation.LibraryID, endTime, "the renewed loan ends"); err != nil {
+		return nil, err
+	}
+
+	reservation, err = s.Store.Renew(ctx, req.GetId(), endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Renewed reservation %d until %s", reservation.ID, reservation.End.Format(timeFormat)))
+	res := toPBReservation(reservation)
+	s.publish(ctx, webhook.LoanRenewed, res)
+	return res, nil
+}
+
+var pbReservationStatuses = map[store.ReservationStatus]pb.ReservationStatus{
+	store.StatusReserved:   pb.ReservationStatus_RESERVED,
+	store.StatusCheckedOut: pb.ReservationStatus_CHECKED_OUT,
+	store.StatusReturned:   pb.ReservationStatus_RETURNED,
+	store.StatusCancelled:  pb.ReservationStatus_CANCELLED,
+}
+
+var storeReservationStatuses = map[pb.ReservationStatus]store.ReservationStatus{
+	pb.ReservationStatus_RESERVED:    store.StatusReserved,
+	pb.ReservationStatus_CHECKED_OUT: store.StatusCheckedOut,
+	pb.ReservationStatus_RETURNED:    store.StatusReturned,
+	pb.ReservationStatus_CANCELLED:   store.StatusCancelled,
+}
+
+func toPBReservation(reservation store.Reservation) *pb.BookReservation {
+	res := &pb.BookReservation{
+		Id:        reservation.ID,
+		Isbn:      reservation.ISBN,
+		CopyId:    reservation.CopyID,
+		StartDate: reservation.Start.Format(timeFormat),
+		EndDate:   reservation.End.Format(timeFormat),
+		Status:    pbReservationStatuses[reservation.Status],
+		CreatedAt: reservation.CreatedAt.Format(timeFormat),
+		Library:   reservation.Library,
+		LibraryId: reservation.LibraryID,
+
+		PatronId:     reservation.PatronID,
+		CheckedOutBy: reservation.CheckedOutBy,
+		Renewals:     int32(reservation.Renewals),
+		SeriesId:     reservation.SeriesID,
+	}
+	if !reservation.CheckedOutAt.IsZero() {
+		res.CheckedOutAt = reservation.CheckedOutAt.Format(timeFormat)
+	}
+	if !reservation.OverdueAt.IsZero() {
+		res.OverdueAt = reservation.OverdueAt.Format(timeFormat)
+	}
+	if !reservation.ReturnedAt.IsZero() {
+		res.ReturnedAt = reservation.ReturnedAt.Format(timeFormat)
+	}
+	return res
+}
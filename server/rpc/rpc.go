@@ -2,31 +2,28 @@ package rpc
 
 import (
 	"context"
-	"database/sql"
 	"errors"
-	"flag"
 	"fmt"
-	"log"
 	"net"
-	"os"
+	"sort"
 	"time"
 
+	"github.com/pmaroli/scheduling-rpc/auth"
+	"github.com/pmaroli/scheduling-rpc/config"
 	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-var (
-	host     = os.Getenv("PG_HOST")
-	port     = os.Getenv("PG_PORT")
-	user     = os.Getenv("PG_USER")
-	password = os.Getenv("PG_PASSWORD")
-	dbname   = os.Getenv("PG_DB")
-)
-
-// ReservationServer contains a sql.DB to interact with Postgres
+// ReservationServer serves the Reservation service on top of a store.Store
 type ReservationServer struct {
-	DB *sql.DB
+	Store store.Store
+	// Webhooks publishes events to webhook subscribers. Nothing is published if it is nil.
+	Webhooks *webhook.Publisher
 }
 
 var (
@@ -34,130 +31,183 @@ var (
 	emptyTime  = time.Time{}
 )
 
-// Start the gRPC server
-func Start() error {
-	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s sslmode=disable dbname=%s ", host, port, user, password, dbname)
-	fmt.Println(psqlInfo)
-	db, err := sql.Open("postgres", psqlInfo)
+// Server is the gRPC server hosting the Reservation service
+type Server struct {
+	lis        net.Listener
+	grpcServer *grpc.Server
+}
 
+// New listens on the configured gRPC address and registers the Reservation service backed by st
+func New(cfg config.Config, st store.Store) (*Server, error) {
+	lis, err := net.Listen("tcp", cfg.GRPC.Addr())
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to listen: %v", err)
 	}
-	defer db.Close()
 
-	err = db.Ping()
+	unary, stream, err := interceptors(cfg.Auth, st)
 	if err != nil {
-		panic(err)
+		lis.Close()
+		return nil, err
 	}
-	fmt.Println("Connected to the DB!")
-
-	// Start the gRPC server
-	flag.Parse()
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", 5001))
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+	if !cfg.Auth.Enabled() {
+		fmt.Println("WARNING: authentication is disabled, any caller can use every RPC. Set auth.hmac_secret or auth.jwks_file to enable it")
 	}
-	grpcServer := grpc.NewServer()
-	pb.RegisterReservationServer(grpcServer, ReservationServer{DB: db})
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(chainUnary(unary)), grpc.StreamInterceptor(chainStream(stream)))
+	pb.RegisterReservationServer(grpcServer, ReservationServer{Store: st, Webhooks: webhook.NewPublisher(st)})
 	reflection.Register(grpcServer)
-	return grpcServer.Serve(lis)
+
+	return &Server{lis: lis, grpcServer: grpcServer}, nil
 }
 
-// GetAllBooks from the Postgres DB
-func (s ReservationServer) GetAllBooks(ctx context.Context, req *pb.Empty) (*pb.GetAllBooksRes, error) {
-	var books []*pb.Book
+// interceptors returns the interceptors of the server in order, which only authenticate
+// and authorize callers when authentication is enabled
+func interceptors(cfg config.Auth, st store.Store) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	unary := []grpc.UnaryServerInterceptor{errorInterceptor}
+	stream := []grpc.StreamServerInterceptor{errorStreamInterceptor}
+	if !cfg.Enabled() {
+		return unary, stream, nil
+	}
 
-	getAllBooksSQL := `
-		SELECT isbn, library, price, ST_Y(geog::geometry) as lat, ST_X(geog::geometry) as lng
-		FROM books
-	`
-	rows, err := s.DB.Query(getAllBooksSQL)
+	authenticator, err := newAuthenticator(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	unary = append(unary, authenticator.Unary, authorizer{store: st}.Unary)
+	stream = append(stream, authenticator.Stream, authorizer{store: st}.Stream)
+	return unary, stream, nil
+}
 
-	for rows.Next() {
-		var (
-			isbn    string
-			library string
-			lat     float32
-			lng     float32
-			price   float32
-		)
-
-		err = rows.Scan(&isbn, &library, &price, &lat, &lng)
-		if err != nil {
+func newAuthenticator(cfg config.Auth) (*auth.Authenticator, error) {
+	var verifier *auth.Verifier
+	if cfg.JWKSFile != "" {
+		var err error
+		if verifier, err = auth.NewJWKSVerifier(cfg.JWKSFile); err != nil {
 			return nil, err
 		}
+	} else {
+		verifier = auth.NewHMACVerifier([]byte(cfg.HMACSecret))
+	}
+	verifier.Issuer = cfg.Issuer
+	verifier.Audience = cfg.Audience
 
-		// Append each book to the resultant list
-		books = append(books, &pb.Book{
-			Isbn:    isbn,
-			Lat:     lat,
-			Lng:     lng,
-			Price:   price,
-			Library: library,
-		})
+	return auth.NewAuthenticator(verifier), nil
+}
+
+// chainUnary runs the interceptors in order, the first being the outermost.
+// grpc-go only gained grpc.ChainUnaryInterceptor in v1.28.
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
 	}
+}
 
-	fmt.Println(books)
-	return &pb.GetAllBooksRes{Books: books}, nil
+// chainStream runs the interceptors in order, the first being the outermost
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return handler(srv, ss)
+	}
 }
 
-// GetBook returns a book with the matching ISBN
-func (s ReservationServer) GetBook(ctx context.Context, req *pb.GetBookReq) (*pb.Book, error) {
-	var (
-		isbn    string
-		lat     float32
-		lng     float32
-		price   float32
-		library string
-
-		book *pb.Book
-	)
-
-	getBookSQL := `
-		SELECT isbn, library, price, ST_Y(geog::geometry) as lat, ST_X(geog::geometry) as lng
-		FROM books
-		WHERE isbn = $1
-	`
-
-	rows, err := s.DB.Query(getBookSQL, req.GetIsbn())
+// Start the gRPC server
+func (s *Server) Start() error {
+	fmt.Println("Starting the gRPC server on", s.lis.Addr())
+	err := s.grpcServer.Serve(s.lis)
+	if err == grpc.ErrServerStopped {
+		return nil
+	}
+	return err
+}
+
+// Stop the gRPC server, waiting for in-flight RPCs to finish until ctx is done
+func (s *Server) Stop(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+// GetAllBooks from the store
+func (s ReservationServer) GetAllBooks(ctx context.Context, req *pb.GetAllBooksReq) (*pb.GetAllBooksRes, error) {
+	limit, err := pageSize(req.GetPageSize())
 	if err != nil {
 		return nil, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&isbn, &library, &price, &lat, &lng)
-		if err != nil {
+	var token bookPageToken
+	if req.GetPageToken() != "" {
+		if err = decodePageToken(req.GetPageToken(), &token); err != nil {
 			return nil, err
 		}
+	}
 
-		book = &pb.Book{
-			Isbn:    isbn,
-			Library: library,
-			Lat:     lat,
-			Lng:     lng,
-			Price:   price,
-		}
-	} else {
-		return nil, errors.New("could not find book")
+	// Fetch one more than requested to find out whether there is another page
+	books, err := s.Store.AllBooks(ctx, token.ISBN, limit+1)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Println(book)
-	return book, nil
+	res := &pb.GetAllBooksRes{}
+	if len(books) > limit {
+		books = books[:limit]
+		res.NextPageToken = encodePageToken(bookPageToken{ISBN: books[limit-1].ISBN})
+	}
+	res.Books = toPBBooks(books)
+	fmt.Println(res.Books)
+	return res, nil
 }
 
-// AddBook adds a book to the database
+// GetBook returns a book with the matching ISBN
+func (s ReservationServer) GetBook(ctx context.Context, req *pb.GetBookReq) (*pb.Book, error) {
+	book, err := s.Store.GetBook(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, err
+	}
+
+	res := toPBBook(book)
+	fmt.Println(res)
+	return res, nil
+}
+
+// AddBook adds a book to the store
 func (s ReservationServer) AddBook(ctx context.Context, req *pb.AddBookReq) (*pb.Empty, error) {
 	newBook := req.GetBook()
+	if newBook.GetIsbn() == "" {
+		return nil, invalidArgument("book.isbn", "`book.isbn` is required")
+	}
 
-	addBookSQL := `
-		INSERT INTO books (isbn, library, price, geog)
-		VALUES ($1, $2, $3, ST_MakePoint($4, $5))
-	`
+	// The first copy is located by its library, so the book's own coordinates are ignored
+	libraryID, err := resolveLibraryID(ctx, s.Store, newBook.GetLibraryId(), newBook.GetLibrary())
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := s.DB.Exec(addBookSQL, newBook.GetIsbn(), newBook.GetLibrary(), newBook.GetPrice(), newBook.GetLng(), newBook.GetLat())
+	err = s.Store.AddBook(ctx, store.Book{
+		ISBN:      newBook.GetIsbn(),
+		LibraryID: libraryID,
+		Price:     float64(newBook.GetPrice()),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -166,210 +216,346 @@ func (s ReservationServer) AddBook(ctx context.Context, req *pb.AddBookReq) (*pb
 	return &pb.Empty{}, nil
 }
 
-// ReserveBook reserves a book for a specified amount of time
-func (s ReservationServer) ReserveBook(ctx context.Context, req *pb.ReserveBookReq) (*pb.Empty, error) {
+// ReserveBook reserves a book for a specified amount of time at a library that is open
+// when the reservation starts and ends
+func (s ReservationServer) ReserveBook(ctx context.Context, req *pb.ReserveBookReq) (*pb.BookReservation, error) {
 	var startTime, endTime, err = parseTimes(req.GetStartDate(), req.GetEndDate())
 	if err != nil {
 		return nil, err
 	}
 
-	// First check if the reservation can be made
-	checkReservationSQL := `
-		SELECT COUNT(isbn) FROM reservations
-		WHERE
-			isbn = $1
-		AND duration && tstzrange($2, $3)
-	`
-	rows, err := s.DB.Query(checkReservationSQL, req.GetIsbn(), startTime.Format(timeFormat), endTime.Format(timeFormat))
+	requested := store.Reservation{
+		ISBN:     req.GetIsbn(),
+		CopyID:   req.GetCopyId(),
+		PatronID: req.GetPatronId(),
+		Start:    startTime,
+		End:      endTime,
+	}
+	reservation, err := s.reserve(ctx, requested, func(reservation store.Reservation) (store.Reservation, error) {
+		return s.Store.Reserve(ctx, reservation)
+	})
+	if errors.Is(err, store.ErrOverlap) {
+		return nil, s.overlapError(ctx, requested, err)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		var count int32
-		err = rows.Scan(&count)
+	fmt.Println(fmt.Sprintf("Made reservation %d for %s", reservation.ID, req.GetIsbn()))
+	res := toPBReservation(reservation)
+	s.publish(ctx, webhook.ReservationCreated, res)
+	return res, nil
+}
+
+// reserve tries each library holding a matching copy, or only reservation.LibraryID if
+// set, skipping those closed when the reservation starts or ends, until reserveAt makes
+// the reservation at one with a copy free over the window
+func (s ReservationServer) reserve(ctx context.Context, reservation store.Reservation, reserveAt func(store.Reservation) (store.Reservation, error)) (store.Reservation, error) {
+	libraryIDs, err := s.candidateLibraries(ctx, reservation.ISBN, reservation.CopyID)
+	if err != nil {
+		return store.Reservation{}, err
+	}
+
+	var overlapErr, closedErr error
+	for _, libraryID := range libraryIDs {
+		if reservation.LibraryID != 0 && libraryID != reservation.LibraryID {
+			continue
+		}
+
+		if err = s.checkOpen(ctx, libraryID, reservation.Start, "the reservation starts"); err == nil {
+			err = s.checkOpen(ctx, libraryID, reservation.End, "the reservation ends")
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			if closedErr == nil {
+				closedErr = err
+			}
+			continue
+		}
 		if err != nil {
-			return nil, err
+			return store.Reservation{}, err
 		}
 
-		if count > 0 {
-			return nil, errors.New("reservation overlaps with an existing slot")
+		atLibrary := reservation
+		atLibrary.LibraryID = libraryID
+		made, err := reserveAt(atLibrary)
+		if errors.Is(err, store.ErrOverlap) {
+			overlapErr = err
+			continue
 		}
+		return made, err
+	}
+
+	switch {
+	case overlapErr != nil:
+		return store.Reservation{}, overlapErr
+	case closedErr != nil:
+		return store.Reservation{}, closedErr
 	}
+	return store.Reservation{}, store.ErrCopyNotFound
+}
 
-	// If there are no overlapping reservations, make the reservation
-	reserveBookSQL := `
-		INSERT INTO reservations (isbn, duration)
-		VALUES ($1, tstzrange($2, $3))
-	`
-	_, err = s.DB.Exec(reserveBookSQL, req.GetIsbn(), startTime.Format(timeFormat), endTime.Format(timeFormat))
+// candidateLibraries returns the library of the copy, or the libraries holding
+// copies of the book in the order of their first copy if copyID is 0
+func (s ReservationServer) candidateLibraries(ctx context.Context, isbn string, copyID int64) ([]int64, error) {
+	if copyID != 0 {
+		copy, err := s.Store.GetCopy(ctx, copyID)
+		if err != nil {
+			return nil, err
+		}
+		if copy.ISBN != isbn {
+			return nil, store.ErrCopyNotFound
+		}
+		return []int64{copy.LibraryID}, nil
+	}
+
+	copies, err := s.Store.ListCopies(ctx, isbn)
 	if err != nil {
 		return nil, err
 	}
-
-	fmt.Println(fmt.Sprintf("Made reservation for %s", req.GetIsbn()))
-	// TODO: Return a status code or something?
-	return &pb.Empty{}, nil
+	sort.Slice(copies, func(i, j int) bool { return copies[i].ID < copies[j].ID })
+
+	var libraryIDs []int64
+	seen := make(map[int64]bool)
+	for _, copy := range copies {
+		if !seen[copy.LibraryID] {
+			seen[copy.LibraryID] = true
+			libraryIDs = append(libraryIDs, copy.LibraryID)
+		}
+	}
+	return libraryIDs, nil
 }
 
-// CheckoutBook populates the checked_out table to signify a reservation has been 'checked out'
+// CheckoutBook marks a reservation as 'checked out'
 func (s ReservationServer) CheckoutBook(ctx context.Context, req *pb.CheckoutBookReq) (*pb.Empty, error) {
 	var startTime, endTime, err = parseTimes(req.GetStartDate(), req.GetEndDate())
 	if err != nil {
 		return nil, err
 	}
 
-	// First get the reservation id
-	// Can probably simplify this to not require the exact start/end times
-	getReservationIDSQL := `
-		SELECT id FROM reservations
-		WHERE
-			isbn = $1
-			AND duration = tstzrange($2, $3)
-	`
-	row := s.DB.QueryRow(getReservationIDSQL, req.GetIsbn(), startTime.Format(timeFormat), endTime.Format(timeFormat))
+	// First get the reservation
+	// CheckoutReservation does the same without requiring the exact start/end times
+	reservation, err := s.Store.FindReservation(ctx, req.GetIsbn(), req.GetCopyId(), startTime, endTime)
 	if err != nil {
 		return nil, err
 	}
-	var reservationID string
-	err = row.Scan(&reservationID)
-	if err != nil {
+	if err = s.checkOpen(ctx, reservation.LibraryID, time.Now(), "the book is checked out"); err != nil {
 		return nil, err
 	}
 
-	checkoutBookSQL := `
-		INSERT INTO checked_out (isbn, reservation_id)
-		VALUES ($1, $2)
-	`
-	_, err = s.DB.Exec(checkoutBookSQL, req.GetIsbn(), reservationID)
+	reservation, err = s.Store.Checkout(ctx, reservation.ID, req.GetPatronId())
 	if err != nil {
-		// Will not allow checking out a book if the ISBN already exists in the table
+		// Will not allow checking out a book that is already checked out
 		return nil, err
 	}
 
 	fmt.Println(fmt.Sprintf("Checked out book with ISBN: %s", req.GetIsbn()))
+	s.publish(ctx, webhook.BookCheckedOut, toPBReservation(reservation))
 	return &pb.Empty{}, nil
 }
 
-// ReturnBook returns a previously checked out book
-func (s ReservationServer) ReturnBook(ctx context.Context, req *pb.ReturnBookReq) (*pb.Empty, error) {
-	returnBookSQL := `
-		DELETE FROM checked_out
-		WHERE isbn = $1
-		RETURNING isbn
-	`
-
-	result, err := s.DB.Exec(returnBookSQL, req.GetIsbn())
+// ReturnBook returns a previously checked out book, charging a late fee if it is overdue
+func (s ReservationServer) ReturnBook(ctx context.Context, req *pb.ReturnBookReq) (*pb.ReturnBookRes, error) {
+	reservation, fee, err := s.Store.Return(ctx, req.GetIsbn(), req.GetCopyId())
 	if err != nil {
 		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, err
+	fmt.Println(fmt.Sprintf("Returned book with ISBN: %s", req.GetIsbn()))
+	if fee.Amount > 0 {
+		fmt.Println(fmt.Sprintf("Charged patron %d a late fee of %.2f for %d days late", fee.PatronID, fee.Amount, fee.DaysLate))
 	}
-
-	if rowsAffected == 0 {
-		return nil, errors.New("book has not been checked out")
+	res := &pb.ReturnBookRes{
+		Reservation: toPBReservation(reservation),
+		LateFee:     float32(fee.Amount),
+		DaysLate:    int32(fee.DaysLate),
 	}
-
-	fmt.Println(fmt.Sprintf("Returned book with ISBN: %s", req.GetIsbn()))
-	return &pb.Empty{}, nil
+	s.publish(ctx, webhook.BookReturned, res.Reservation)
+	s.promoteHolds(ctx, req.GetIsbn())
+	return res, nil
 }
 
-// DeleteBook deletes a book from the DB
+// DeleteBook deletes a book from the store
 func (s ReservationServer) DeleteBook(ctx context.Context, req *pb.DeleteBookReq) (*pb.Empty, error) {
-	deleteBookSQL := `
-		DELETE FROM books
-		WHERE isbn = $1
-	`
-
-	result, err := s.DB.Exec(deleteBookSQL, req.GetIsbn())
-	if err != nil {
-		return nil, err
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	err := s.Store.DeleteBook(ctx, req.GetIsbn())
 	if err != nil {
 		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, errors.New("book not found")
-	}
-
 	fmt.Println(fmt.Sprintf("Deleted book with ISBN: %s", req.GetIsbn()))
 	return &pb.Empty{}, nil
 }
 
 // Search for books given the coordinates and radius of search
 func (s ReservationServer) Search(ctx context.Context, req *pb.SearchReq) (*pb.SearchRes, error) {
-	var startTime, endTime, err = parseTimes(req.GetStartDate(), req.GetEndDate())
+	startTime, err := parseOptionalTime("startDate", req.GetStartDate())
 	if err != nil {
 		return nil, err
 	}
+	endTime, err := parseOptionalTime("endDate", req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+	if !startTime.IsZero() && !endTime.IsZero() && !startTime.Before(endTime) {
+		return nil, invalidArgument("endDate", "`endDate` must be after `startDate`")
+	}
 
-	rangeInKm := req.GetRange()
-	rangeInMeters := rangeInKm * 1000
-
-	searchBooksSQL := `
-	SELECT isbn, library, price, ST_Y(geog::geometry) as lat, ST_X(geog::geometry) as lng
-	FROM books
-	WHERE
-		ST_DWithin(geog, ST_MakePoint($1, $2)::geography, $3)
-		AND isbn NOT IN (
-			SELECT DISTINCT(isbn) FROM reservations
-			WHERE duration && tstzrange($4, $5)
-		)
-	ORDER BY geog <-> ST_MakePoint($1, $2)::geography;
-	`
-
-	rows, err := s.DB.Query(searchBooksSQL, req.GetLng(), req.GetLat(), rangeInMeters, startTime.Format(timeFormat), endTime.Format(timeFormat))
+	limit, err := pageSize(req.GetPageSize())
 	if err != nil {
 		return nil, err
 	}
 
-	var books []*pb.Book
-	for rows.Next() {
-		var (
-			isbn    string
-			library string
-			lat     float32
-			lng     float32
-			price   float32
-		)
+	order, ok := storeSearchOrders[req.GetOrderBy()]
+	if !ok {
+		return nil, invalidArgument("orderBy", "unknown `orderBy`")
+	}
+	if req.GetMinPrice() < 0 {
+		return nil, invalidArgument("minPrice", "`minPrice` can't be negative")
+	}
+	if req.GetMaxPrice() < 0 {
+		return nil, invalidArgument("maxPrice", "`maxPrice` can't be negative")
+	}
+	if req.GetMaxPrice() > 0 && req.GetMaxPrice() < req.GetMinPrice() {
+		return nil, invalidArgument("maxPrice", "`maxPrice` must not be less than `minPrice`")
+	}
+
+	rangeInKm := req.GetRange()
+	rangeInMeters := rangeInKm * 1000
 
-		err = rows.Scan(&isbn, &library, &price, &lat, &lng)
-		if err != nil {
+	query := store.SearchQuery{
+		Lat:         float64(req.GetLat()),
+		Lng:         float64(req.GetLng()),
+		RangeMeters: float64(rangeInMeters),
+		Start:       startTime,
+		End:         endTime,
+
+		MinPrice:   float64(req.GetMinPrice()),
+		MaxPrice:   float64(req.GetMaxPrice()),
+		LibraryIDs: req.GetLibraryIds(),
+		ISBNs:      req.GetIsbns(),
+
+		Order: order,
+		// Fetch one more than requested to find out whether there is another page
+		Limit: limit + 1,
+	}
+
+	if req.GetPageToken() != "" {
+		var token searchPageToken
+		if err = decodePageToken(req.GetPageToken(), &token); err != nil {
 			return nil, err
 		}
+		if token.Order != req.GetOrderBy() {
+			return nil, invalidArgument("pageToken", "`pageToken` was issued for a different `orderBy`")
+		}
+		query.After = &store.SearchCursor{
+			DistanceMeters: token.DistanceMeters,
+			Price:          token.Price,
+			Library:        token.Library,
+			ISBN:           token.ISBN,
+			LibraryID:      token.LibraryID,
+		}
+	}
+
+	books, err := s.Store.SearchBooks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 
-		// Append each book to the search result
-		books = append(books, &pb.Book{
-			Isbn:    isbn,
-			Lat:     lat,
-			Lng:     lng,
-			Price:   price,
-			Library: library,
+	res := &pb.SearchRes{}
+	if len(books) > limit {
+		books = books[:limit]
+
+		last := books[limit-1]
+		res.NextPageToken = encodePageToken(searchPageToken{
+			Order:          req.GetOrderBy(),
+			DistanceMeters: last.DistanceMeters,
+			Price:          last.Price,
+			Library:        last.Library,
+			ISBN:           last.ISBN,
+			LibraryID:      last.LibraryID,
 		})
 	}
+	res.Books = toPBBooks(books)
+	return res, nil
+}
+
+// bookPageToken is the cursor behind GetAllBooksRes.nextPageToken
+type bookPageToken struct {
+	ISBN string `json:"i"`
+}
+
+// searchPageToken is the cursor behind SearchRes.nextPageToken
+type searchPageToken struct {
+	Order          pb.SearchOrder `json:"o"`
+	DistanceMeters float64        `json:"d"`
+	Price          float64        `json:"p"`
+	Library        string         `json:"n"`
+	ISBN           string         `json:"i"`
+	LibraryID      int64          `json:"l"`
+}
+
+var storeSearchOrders = map[pb.SearchOrder]store.SearchOrder{
+	pb.SearchOrder_DISTANCE:     store.SearchByDistance,
+	pb.SearchOrder_PRICE:        store.SearchByPrice,
+	pb.SearchOrder_LIBRARY_NAME: store.SearchByLibraryName,
+}
+
+func toPBBook(book store.Book) *pb.Book {
+	return &pb.Book{
+		Isbn:    book.ISBN,
+		Lat:     float32(book.Lat),
+		Lng:     float32(book.Lng),
+		Price:   float32(book.Price),
+		Library: book.Library,
+
+		LibraryId:       book.LibraryID,
+		AvailableCopies: int32(book.AvailableCopies),
+		TotalCopies:     int32(book.TotalCopies),
+		DistanceMeters:  float32(book.DistanceMeters),
+	}
+}
 
-	return &pb.SearchRes{Books: books}, nil
+func toPBBooks(books []store.Book) []*pb.Book {
+	var res []*pb.Book
+	for _, book := range books {
+		res = append(res, toPBBook(book))
+	}
+	return res
 }
 
+// parseOptionalTime parses an ISO8601 field that may be left empty, returning the zero time if it is
+func parseOptionalTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return emptyTime, nil
+	}
+
+	t, err := time.Parse(timeFormat, value)
+	if err != nil {
+		return emptyTime, invalidArgument(field, fmt.Sprintf("invalid datetime format: `%s` was not formatted as ISO8601", field))
+	}
+	return t, nil
+}
+
+// parseTimes parses a required window, which must start before it ends
 func parseTimes(startTimeString, endTimeString string) (time.Time, time.Time, error) {
 	if startTimeString == "" || endTimeString == "" {
-		return emptyTime, emptyTime, errors.New("empty time search is not implemented right now")
+		field := "startDate"
+		if startTimeString != "" {
+			field = "endDate"
+		}
+		return emptyTime, emptyTime, invalidArgument(field, "`"+field+"` is required")
 	}
 
 	var startTime, err = time.Parse(timeFormat, startTimeString)
 	if err != nil {
-		return emptyTime, emptyTime, errors.New("invalid datetime format: `startDate` was not formatted as ISO8601")
+		return emptyTime, emptyTime, invalidArgument("startDate", "invalid datetime format: `startDate` was not formatted as ISO8601")
 	}
 
 	endTime, err := time.Parse(timeFormat, endTimeString)
 	if err != nil {
-		return emptyTime, emptyTime, errors.New("invalid datetime format: `endDate` was not formatted as ISO8601")
+		return emptyTime, emptyTime, invalidArgument("endDate", "invalid datetime format: `endDate` was not formatted as ISO8601")
+	}
+	if !startTime.Before(endTime) {
+		return emptyTime, emptyTime, invalidArgument("endDate", "`endDate` must be after `startDate`")
 	}
 
 	return startTime, endTime, nil
@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pmaroli/scheduling-rpc/auth"
+	"github.com/pmaroli/scheduling-rpc/config"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptorsOnlyAuthenticateWhenEnabled(t *testing.T) {
+	secret := "test-secret"
+	admin, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "ada",
+		"roles": []string{auth.RoleAdmin},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		cfg           config.Auth
+		authorization string
+		want          codes.Code
+		wantPrincipal bool
+	}{
+		{"disabled without a token", config.Auth{}, "", codes.OK, false},
+		{"disabled with an invalid token", config.Auth{}, "Bearer not-a-jwt", codes.OK, false},
+		{"enabled without a token", config.Auth{HMACSecret: config.Secret(secret)}, "", codes.Unauthenticated, false},
+		{"enabled with a valid token", config.Auth{HMACSecret: config.Secret(secret)}, "Bearer " + admin, codes.OK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unary, _, err := interceptors(tt.cfg, store.NewMemory())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			var gotPrincipal bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				_, gotPrincipal = auth.FromContext(ctx)
+				return &pb.Book{}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: servicePrefix + "GetBook"}
+			_, err = chainUnary(unary)(ctx, &pb.GetBookReq{Isbn: "111"}, info, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.want)
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("the handler got a principal: %t, want %t", gotPrincipal, tt.wantPrincipal)
+			}
+		})
+	}
+}
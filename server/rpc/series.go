@@ -0,0 +1,265 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+	"github.com/teambition/rrule-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSeriesOccurrences bounds how many reservations a series can make
+const maxSeriesOccurrences = 200
+
+// errLibraryClosed is recorded on occurrences that start or end while every library
+// holding a matching copy is closed
+var errLibraryClosed = errors.New("every library holding the book is closed when the occurrence starts or ends")
+
+// ReserveRecurring reserves a book for every occurrence of a recurrence rule as one series
+func (s ReservationServer) ReserveRecurring(ctx context.Context, req *pb.ReserveRecurringReq) (*pb.ReserveRecurringRes, error) {
+	startTime, endTime, err := parseTimes(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetTimezone() == "" {
+		return nil, invalidArgument("timezone", "`timezone` is required")
+	}
+	location, err := time.LoadLocation(req.GetTimezone())
+	if err != nil {
+		return nil, invalidArgument("timezone", "`timezone` is not a known IANA time zone")
+	}
+	if _, ok := pb.SeriesMode_name[int32(req.GetMode())]; !ok {
+		return nil, invalidArgument("mode", "unknown `mode`")
+	}
+
+	rule, starts, err := expandRRule(req.GetRrule(), startTime.In(location))
+	if err != nil {
+		return nil, err
+	}
+
+	libraryIDs, err := s.candidateLibraries(ctx, req.GetIsbn(), req.GetCopyId())
+	if err != nil {
+		return nil, err
+	}
+	length := endTime.Sub(startTime)
+	occurrences, err := s.planOccurrences(ctx, libraryIDs, starts, length)
+	if err != nil {
+		return nil, err
+	}
+	for i := range occurrences {
+		occurrences[i].CopyID = req.GetCopyId()
+	}
+
+	series, occurrences, err := s.Store.ReserveSeries(ctx, store.Series{
+		ISBN:     req.GetIsbn(),
+		PatronID: req.GetPatronId(),
+		RRule:    rule,
+		Timezone: location.String(),
+		Start:    startTime,
+		End:      endTime,
+	}, occurrences, req.GetMode() == pb.SeriesMode_ALL_OR_NOTHING)
+	if errors.Is(err, store.ErrSeriesConflict) {
+		return nil, withDetails(status.New(codes.AlreadyExists, err.Error()), &errdetails.ErrorInfo{
+			Reason: "SERIES_CONFLICT",
+			Domain: errorDomain,
+		}, &pb.ReserveRecurringRes{Occurrences: toPBOccurrences(occurrences)})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ReserveRecurringRes{Series: toPBSeries(series, nil), Occurrences: toPBOccurrences(occurrences)}
+	for _, occurrence := range occurrences {
+		if occurrence.Err == nil {
+			reservation := toPBReservation(occurrence.Reservation)
+			res.Series.Reservations = append(res.Series.Reservations, reservation)
+			s.publish(ctx, webhook.ReservationCreated, reservation)
+		}
+	}
+
+	fmt.Println(fmt.Sprintf("Made series %d of %d reservations for %s", series.ID, len(res.Series.Reservations), req.GetIsbn()))
+	return res, nil
+}
+
+// expandRRule parses an RRULE and returns it normalized along with the starts of its
+// occurrences from dtstart, which keep dtstart's wall clock time in its location
+// across daylight saving time changes
+func expandRRule(rule string, dtstart time.Time) (string, []time.Time, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return "", nil, invalidArgument("rrule", "`rrule` is required")
+	}
+	if strings.Contains(rule, "\n") {
+		return "", nil, invalidArgument("rrule", "`rrule` must be a single RRULE without DTSTART")
+	}
+
+	option, err := rrule.StrToROptionInLocation(rule, dtstart.Location())
+	if err != nil {
+		return "", nil, invalidArgument("rrule", fmt.Sprintf("`rrule` is invalid: %v", err))
+	}
+	if option.Count == 0 && option.Until.IsZero() {
+		return "", nil, invalidArgument("rrule", "`rrule` must end with COUNT or UNTIL")
+	}
+	option.Dtstart = dtstart
+	recurrence, err := rrule.NewRRule(*option)
+	if err != nil {
+		return "", nil, invalidArgument("rrule", fmt.Sprintf("`rrule` is invalid: %v", err))
+	}
+
+	var starts []time.Time
+	next := recurrence.Iterator()
+	for start, ok := next(); ok; start, ok = next() {
+		if len(starts) == maxSeriesOccurrences {
+			return "", nil, invalidArgument("rrule", fmt.Sprintf("`rrule` can have at most %d occurrences", maxSeriesOccurrences))
+		}
+		starts = append(starts, start)
+	}
+	if len(starts) == 0 {
+		return "", nil, invalidArgument("rrule", "`rrule` has no occurrences after `startDate`")
+	}
+
+	return option.RRuleString(), starts, nil
+}
+
+// planOccurrences returns an occurrence of length at each start, to be reserved at the
+// libraries open when it starts and ends, marking those with no such library as closed
+func (s ReservationServer) planOccurrences(ctx context.Context, libraryIDs []int64, starts []time.Time, length time.Duration) ([]store.SeriesOccurrence, error) {
+	first, last := starts[0], starts[len(starts)-1].Add(length)
+	openings := make(map[int64][]interval)
+	for _, libraryID := range libraryIDs {
+		open, err := s.openPeriods(ctx, libraryID, first, last)
+		if err != nil {
+			return nil, err
+		}
+		openings[libraryID] = open
+	}
+
+	var occurrences []store.SeriesOccurrence
+	for _, start := range starts {
+		occurrence := store.SeriesOccurrence{Start: start, End: start.Add(length)}
+		for _, libraryID := range libraryIDs {
+			if isOpenAt(openings[libraryID], occurrence.Start) && isOpenAt(openings[libraryID], occurrence.End) {
+				occurrence.LibraryIDs = append(occurrence.LibraryIDs, libraryID)
+			}
+		}
+		if len(occurrence.LibraryIDs) == 0 {
+			occurrence.Err = errLibraryClosed
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// isOpenAt reports whether t falls in one of the opening periods, closing time included
+func isOpenAt(open []interval, t time.Time) bool {
+	for _, period := range open {
+		if !t.Before(period.start) && !t.After(period.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSeries returns a reservation series along with its reservations
+func (s ReservationServer) GetSeries(ctx context.Context, req *pb.GetSeriesReq) (*pb.ReservationSeries, error) {
+	series, err := s.Store.GetSeries(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.seriesReservations(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toPBSeries(series, reservations), nil
+}
+
+// CancelSeries cancels a series along with its reservations that haven't been checked out
+func (s ReservationServer) CancelSeries(ctx context.Context, req *pb.CancelSeriesReq) (*pb.ReservationSeries, error) {
+	series, cancelled, err := s.Store.CancelSeries(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Cancelled series %d and %d of its reservations", series.ID, len(cancelled)))
+	for _, reservation := range cancelled {
+		s.publish(ctx, webhook.ReservationCancelled, toPBReservation(reservation))
+	}
+	if len(cancelled) > 0 {
+		s.promoteHolds(ctx, series.ISBN)
+	}
+
+	reservations, err := s.seriesReservations(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toPBSeries(series, reservations), nil
+}
+
+// seriesReservations returns every reservation of a series, earliest first
+func (s ReservationServer) seriesReservations(ctx context.Context, seriesID int64) ([]store.Reservation, error) {
+	query := store.ListReservationsQuery{SeriesID: seriesID, Order: store.OrderByStart, Limit: maxPageSize}
+
+	var reservations []store.Reservation
+	for {
+		page, err := s.Store.ListReservations(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, page...)
+
+		if len(page) < query.Limit {
+			return reservations, nil
+		}
+		last := page[len(page)-1]
+		query.After = &store.ReservationCursor{Time: last.Start, ID: last.ID}
+	}
+}
+
+func toPBSeries(series store.Series, reservations []store.Reservation) *pb.ReservationSeries {
+	res := &pb.ReservationSeries{
+		Id:        series.ID,
+		Isbn:      series.ISBN,
+		PatronId:  series.PatronID,
+		Rrule:     series.RRule,
+		Timezone:  series.Timezone,
+		StartDate: series.Start.Format(timeFormat),
+		EndDate:   series.End.Format(timeFormat),
+		CreatedAt: series.CreatedAt.Format(timeFormat),
+	}
+	if !series.CancelledAt.IsZero() {
+		res.CancelledAt = series.CancelledAt.Format(timeFormat)
+	}
+	for _, reservation := range reservations {
+		res.Reservations = append(res.Reservations, toPBReservation(reservation))
+	}
+	return res
+}
+
+func toPBOccurrences(occurrences []store.SeriesOccurrence) []*pb.Occurrence {
+	var res []*pb.Occurrence
+	for _, occurrence := range occurrences {
+		pbOccurrence := &pb.Occurrence{
+			StartDate: occurrence.Start.Format(timeFormat),
+			EndDate:   occurrence.End.Format(timeFormat),
+		}
+		switch {
+		case occurrence.Err == nil && occurrence.Reservation.ID != 0:
+			pbOccurrence.Reservation = toPBReservation(occurrence.Reservation)
+		case occurrence.Err == errLibraryClosed:
+			pbOccurrence.Error, pbOccurrence.Reason = occurrence.Err.Error(), "LIBRARY_CLOSED"
+		case occurrence.Err != nil:
+			pbOccurrence.Error, pbOccurrence.Reason = occurrence.Err.Error(), errorReason(occurrence.Err)
+		}
+		res = append(res, pbOccurrence)
+	}
+	return res
+}
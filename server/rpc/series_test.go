@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExpandRRuleKeepsWallClockAcrossDST(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Clocks go forward on Sunday 8 March 2026, between the first and second Tuesdays
+	dtstart := time.Date(2026, 3, 3, 14, 0, 0, 0, location)
+
+	_, starts, err := expandRRule("RRULE:FREQ=WEEKLY;BYDAY=TU;COUNT=4", dtstart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		day    int
+		offset time.Duration
+	}{
+		{3, -5 * time.Hour},
+		{10, -4 * time.Hour},
+		{17, -4 * time.Hour},
+		{24, -4 * time.Hour},
+	}
+	if len(starts) != len(want) {
+		t.Fatalf("got %d occurrences, want %d", len(starts), len(want))
+	}
+	for i, start := range starts {
+		local := start.In(location)
+		if local.Day() != want[i].day || local.Hour() != 14 || local.Minute() != 0 {
+			t.Errorf("occurrence %d starts at %s, want 14:00 on March %d", i, local.Format(time.RFC3339), want[i].day)
+		}
+		if _, offset := local.Zone(); time.Duration(offset)*time.Second != want[i].offset {
+			t.Errorf("occurrence %d is at UTC%+d, want UTC%+d", i, offset/3600, int(want[i].offset.Hours()))
+		}
+	}
+	if got := starts[1].Sub(starts[0]); got != 7*24*time.Hour-time.Hour {
+		t.Errorf("the first two occurrences are %v apart, want a week less the skipped hour", got)
+	}
+}
+
+func TestExpandRRuleRejectsInvalidRules(t *testing.T) {
+	dtstart := time.Date(2026, 3, 3, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"missing", ""},
+		{"no COUNT or UNTIL", "FREQ=WEEKLY;BYDAY=TU"},
+		{"too many occurrences", fmt.Sprintf("FREQ=DAILY;COUNT=%d", maxSeriesOccurrences+1)},
+		{"invalid", "FREQ=SOMETIMES;COUNT=2"},
+		{"with DTSTART", "DTSTART:20260303T140000Z\nRRULE:FREQ=DAILY;COUNT=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := expandRRule(tt.rule, dtstart)
+			if code := status.Code(err); code != codes.InvalidArgument {
+				t.Errorf("got %v (%v), want InvalidArgument", code, err)
+			}
+		})
+	}
+
+	if _, starts, err := expandRRule(fmt.Sprintf("FREQ=DAILY;COUNT=%d", maxSeriesOccurrences), dtstart); err != nil || len(starts) != maxSeriesOccurrences {
+		t.Errorf("a rule with exactly %d occurrences: got %d occurrences and %v", maxSeriesOccurrences, len(starts), err)
+	}
+}
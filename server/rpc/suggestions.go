@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxSuggestions bounds both the slots and the nearby books suggested
+	maxSuggestions = 5
+	// suggestionHorizon is how far past the requested start free slots are looked for
+	suggestionHorizon = 30 * 24 * time.Hour
+	// suggestionRangeMeters is how far from the requested copy nearby books are looked for
+	suggestionRangeMeters = 10000
+)
+
+// overlapError returns err as a RESERVATION_OVERLAP status carrying the alternatives
+// to the reservation's window. Failing to find them leaves the status without them.
+func (s ReservationServer) overlapError(ctx context.Context, reservation store.Reservation, err error) error {
+	suggestions, suggestErr := s.suggest(ctx, reservation)
+	if suggestErr != nil {
+		fmt.Println(fmt.Sprintf("Couldn't suggest alternatives for book with ISBN %s: %v", reservation.ISBN, suggestErr))
+		return err
+	}
+
+	return withDetails(status.New(codes.AlreadyExists, err.Error()), &errdetails.ErrorInfo{
+		Reason: "RESERVATION_OVERLAP",
+		Domain: errorDomain,
+	}, suggestions)
+}
+
+// suggest finds the next free slots of the same length as the reservation and the
+// books free over its window nearby
+func (s ReservationServer) suggest(ctx context.Context, reservation store.Reservation) (*pb.ReservationSuggestions, error) {
+	copies, err := s.Store.ListCopies(ctx, reservation.ISBN)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.CopyID != 0 {
+		var requested []store.Copy
+		for _, copy := range copies {
+			if copy.ID == reservation.CopyID {
+				requested = append(requested, copy)
+			}
+		}
+		copies = requested
+	}
+	if len(copies) == 0 {
+		return nil, store.ErrCopyNotFound
+	}
+
+	suggestions := &pb.ReservationSuggestions{}
+
+	from := reservation.Start
+	if now := time.Now(); now.After(from) {
+		from = now
+	}
+	calendars, err := s.calendars(ctx, reservation.ISBN, copies, from, from.Add(suggestionHorizon))
+	if err != nil {
+		return nil, err
+	}
+	length := reservation.End.Sub(reservation.Start)
+	var slots []store.Reservation
+	for _, calendar := range calendars {
+		found := 0
+		for _, free := range calendar.free {
+			// Take back to back slots from the interval until enough are found for this copy
+			for found < maxSuggestions {
+				slot, ok := fitSlot(free, calendar.open, length)
+				if !ok {
+					break
+				}
+				slots = append(slots, store.Reservation{
+					CopyID:    calendar.copy.ID,
+					LibraryID: calendar.copy.LibraryID,
+					Library:   calendar.copy.Library,
+					Start:     slot.start,
+					End:       slot.end,
+				})
+				found++
+				free.start = slot.end
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		if !slots[i].Start.Equal(slots[j].Start) {
+			return slots[i].Start.Before(slots[j].Start)
+		}
+		return slots[i].CopyID < slots[j].CopyID
+	})
+	if len(slots) > maxSuggestions {
+		slots = slots[:maxSuggestions]
+	}
+	for _, slot := range slots {
+		suggestions.Slots = append(suggestions.Slots, &pb.SuggestedSlot{
+			CopyId:    slot.CopyID,
+			LibraryId: slot.LibraryID,
+			Library:   slot.Library,
+			StartDate: slot.Start.Format(timeFormat),
+			EndDate:   slot.End.Format(timeFormat),
+		})
+	}
+
+	nearby, err := s.Store.SearchBooks(ctx, store.SearchQuery{
+		Lat:         copies[0].Lat,
+		Lng:         copies[0].Lng,
+		RangeMeters: suggestionRangeMeters,
+		Start:       reservation.Start,
+		End:         reservation.End,
+		Order:       store.SearchByDistance,
+		Limit:       maxSuggestions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	suggestions.Nearby = toPBBooks(nearby)
+
+	return suggestions, nil
+}
+
+// fitSlot returns the earliest window of length within a free interval that starts and
+// ends while the library is open, reporting false if there is none
+func fitSlot(free interval, open []interval, length time.Duration) (interval, bool) {
+	latest := free.end.Add(-length)
+	for _, closing := range open {
+		// The window must end during this period, which bounds when it starts
+		earliest, last := closing.start.Add(-length), closing.end.Add(-length)
+		if earliest.Before(free.start) {
+			earliest = free.start
+		}
+		if last.After(latest) {
+			last = latest
+		}
+		if earliest.After(last) {
+			continue
+		}
+
+		// ...and it must start during an opening period
+		for _, opening := range open {
+			if opening.end.Before(earliest) {
+				continue
+			}
+			if opening.start.After(last) {
+				break
+			}
+			start := earliest
+			if opening.start.After(start) {
+				start = opening.start
+			}
+			return interval{start: start, end: start.Add(length)}, true
+		}
+	}
+	return interval{}, false
+}
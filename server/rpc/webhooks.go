@@ -0,0 +1,198 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/pmaroli/scheduling-rpc/protobufs"
+	"github.com/pmaroli/scheduling-rpc/store"
+	"github.com/pmaroli/scheduling-rpc/webhook"
+)
+
+// CreateWebhook subscribes an HTTP endpoint to events
+func (s ReservationServer) CreateWebhook(ctx context.Context, req *pb.CreateWebhookReq) (*pb.Webhook, error) {
+	hook, err := toStoreWebhook(req.GetWebhook())
+	if err != nil {
+		return nil, err
+	}
+	if hook.Secret == "" {
+		if hook.Secret, err = webhook.RandomHex(32); err != nil {
+			return nil, err
+		}
+	}
+
+	hook, err = s.Store.CreateWebhook(ctx, hook)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Created webhook %d", hook.ID))
+	res := toPBWebhook(hook)
+	res.Secret = hook.Secret
+	return res, nil
+}
+
+// GetWebhook returns the webhook with the matching ID
+func (s ReservationServer) GetWebhook(ctx context.Context, req *pb.GetWebhookReq) (*pb.Webhook, error) {
+	hook, err := s.Store.GetWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toPBWebhook(hook), nil
+}
+
+// ListWebhooks returns every webhook
+func (s ReservationServer) ListWebhooks(ctx context.Context, req *pb.Empty) (*pb.ListWebhooksRes, error) {
+	hooks, err := s.Store.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListWebhooksRes{}
+	for _, hook := range hooks {
+		res.Webhooks = append(res.Webhooks, toPBWebhook(hook))
+	}
+	return res, nil
+}
+
+// UpdateWebhook replaces the URL, events and active flag of a webhook, and its secret if one is given
+func (s ReservationServer) UpdateWebhook(ctx context.Context, req *pb.UpdateWebhookReq) (*pb.Webhook, error) {
+	hook, err := toStoreWebhook(req.GetWebhook())
+	if err != nil {
+		return nil, err
+	}
+	hook.ID = req.GetWebhook().GetId()
+
+	if hook.Secret == "" {
+		existing, err := s.Store.GetWebhook(ctx, hook.ID)
+		if err != nil {
+			return nil, err
+		}
+		hook.Secret = existing.Secret
+	}
+
+	hook, err = s.Store.UpdateWebhook(ctx, hook)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Updated webhook %d", hook.ID))
+	res := toPBWebhook(hook)
+	res.Secret = req.GetWebhook().GetSecret()
+	return res, nil
+}
+
+// DeleteWebhook deletes a webhook along with its delivery log
+func (s ReservationServer) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookReq) (*pb.Empty, error) {
+	err := s.Store.DeleteWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(fmt.Sprintf("Deleted webhook %d", req.GetId()))
+	return &pb.Empty{}, nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook, newest first
+func (s ReservationServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesReq) (*pb.ListWebhookDeliveriesRes, error) {
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.Store.ListDeliveries(ctx, req.GetWebhookId(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListWebhookDeliveriesRes{}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, toPBDelivery(delivery))
+	}
+	return res, nil
+}
+
+// publish queues an event about msg, rendered as the REST gateway would, for delivery to webhooks
+func (s ReservationServer) publish(ctx context.Context, event string, msg proto.Message) {
+	var data bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&data, msg); err != nil {
+		fmt.Println(fmt.Sprintf("Failed to publish %s: %v", event, err))
+		return
+	}
+	s.Webhooks.Publish(ctx, event, json.RawMessage(data.Bytes()))
+}
+
+// toStoreWebhook validates the writable fields of a webhook
+func toStoreWebhook(hook *pb.Webhook) (store.Webhook, error) {
+	u, err := url.Parse(strings.TrimSpace(hook.GetUrl()))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return store.Webhook{}, invalidArgument("webhook.url", "`webhook.url` must be an http or https URL")
+	}
+
+	known := make(map[string]bool)
+	for _, event := range webhook.Events {
+		known[event] = true
+	}
+	events := []string{}
+	for _, event := range hook.GetEvents() {
+		if !known[event] {
+			return store.Webhook{}, invalidArgument("webhook.events", fmt.Sprintf("unknown event %q, must be one of %v", event, webhook.Events))
+		}
+		events = append(events, event)
+	}
+
+	return store.Webhook{
+		URL:    u.String(),
+		Secret: hook.GetSecret(),
+		Events: events,
+		Active: hook.GetActive(),
+	}, nil
+}
+
+// toPBWebhook leaves out the secret, which is only returned when it is set
+func toPBWebhook(hook store.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        hook.ID,
+		Url:       hook.URL,
+		Events:    hook.Events,
+		Active:    hook.Active,
+		CreatedAt: hook.CreatedAt.Format(timeFormat),
+	}
+}
+
+var pbDeliveryStatuses = map[store.DeliveryStatus]pb.DeliveryStatus{
+	store.DeliveryPending:   pb.DeliveryStatus_PENDING,
+	store.DeliveryDelivered: pb.DeliveryStatus_DELIVERED,
+	store.DeliveryFailed:    pb.DeliveryStatus_FAILED,
+}
+
+func toPBDelivery(delivery store.WebhookDelivery) *pb.WebhookDelivery {
+	res := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        string(delivery.Payload),
+		Status:         pbDeliveryStatuses[delivery.Status],
+		Attempts:       int32(delivery.Attempts),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(timeFormat),
+	}
+	if delivery.Status == store.DeliveryPending {
+		res.NextAttemptAt = delivery.NextAttemptAt.Format(timeFormat)
+	}
+	if !delivery.LastAttemptAt.IsZero() {
+		res.LastAttemptAt = delivery.LastAttemptAt.Format(timeFormat)
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		res.DeliveredAt = delivery.DeliveredAt.Format(timeFormat)
+	}
+	return res
+}
@@ -0,0 +1,758 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// earthRadiusMeters is the mean radius used to approximate PostGIS geography distances
+const earthRadiusMeters = 6371008.8
+
+// Memory is a Store that keeps everything in process memory. It mirrors the
+// constraints of the Postgres schema and is meant for tests and local development.
+type Memory struct {
+	mu sync.RWMutex
+
+	libraries     map[int64]Library
+	nextLibraryID int64
+	// hours holds the weekly opening hours of each library
+	hours           map[int64][]OpeningHours
+	exceptions      map[int64]HoursException
+	nextExceptionID int64
+
+	// books holds the ISBN and price of each book, the rest is filled in from its copies
+	books map[string]Book
+	// copies hold their library ID, the rest is filled in from the library
+	copies       map[int64]Copy
+	nextCopyID   int64
+	reservations map[int64]Reservation
+	// checkouts maps a copy ID to the ID of the reservation it was checked out under
+	checkouts map[int64]int64
+	// checkoutTimes maps the ID of a checked out reservation to when it was checked out
+	checkoutTimes map[int64]time.Time
+	// checkoutPatrons maps the ID of a checked out reservation to the patron who checked it out
+	checkoutPatrons map[int64]int64
+	// checkoutOverdue maps the ID of a checked out reservation to when it was flagged as overdue
+	checkoutOverdue map[int64]time.Time
+	nextID          int64
+
+	patrons      map[int64]Patron
+	nextPatronID int64
+	fees         map[int64]Fee
+	nextFeeID    int64
+
+	holds      map[int64]Hold
+	nextHoldID int64
+
+	series       map[int64]Series
+	nextSeriesID int64
+
+	webhooks       map[int64]Webhook
+	nextWebhookID  int64
+	deliveries     map[int64]WebhookDelivery
+	nextDeliveryID int64
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory returns an empty in-memory Store
+func NewMemory() *Memory {
+	return &Memory{
+		libraries:    make(map[int64]Library),
+		hours:        make(map[int64][]OpeningHours),
+		exceptions:   make(map[int64]HoursException),
+		books:        make(map[string]Book),
+		copies:       make(map[int64]Copy),
+		reservations: make(map[int64]Reservation),
+		checkouts:    make(map[int64]int64),
+
+		checkoutTimes:   make(map[int64]time.Time),
+		checkoutPatrons: make(map[int64]int64),
+		checkoutOverdue: make(map[int64]time.Time),
+
+		patrons: make(map[int64]Patron),
+		fees:    make(map[int64]Fee),
+		holds:   make(map[int64]Hold),
+		series:  make(map[int64]Series),
+
+		webhooks:   make(map[int64]Webhook),
+		deliveries: make(map[int64]WebhookDelivery),
+	}
+}
+
+// AllBooks returns up to limit books ordered by ISBN, starting after the ISBN after
+func (m *Memory) AllBooks(ctx context.Context, after string, limit int) ([]Book, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var books []Book
+	for _, book := range m.books {
+		if book.ISBN > after {
+			books = append(books, m.withFirstCopy(book))
+		}
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].ISBN < books[j].ISBN })
+
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return books, nil
+}
+
+// GetBook returns the book with the matching ISBN
+func (m *Memory) GetBook(ctx context.Context, isbn string) (Book, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	book, ok := m.books[isbn]
+	if !ok {
+		return Book{}, ErrBookNotFound
+	}
+	return m.withFirstCopy(book), nil
+}
+
+// AddBook adds a new book, along with its first copy if the library ID is set
+func (m *Memory) AddBook(ctx context.Context, book Book) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[book.ISBN]; ok {
+		return ErrBookExists
+	}
+	if book.LibraryID != 0 {
+		if _, ok := m.libraries[book.LibraryID]; !ok {
+			return ErrLibraryNotFound
+		}
+		if m.barcodeTaken(book.ISBN) {
+			return ErrCopyExists
+		}
+	}
+
+	m.books[book.ISBN] = Book{ISBN: book.ISBN, Price: book.Price}
+	if book.LibraryID != 0 {
+		m.addCopy(Copy{ISBN: book.ISBN, LibraryID: book.LibraryID, Barcode: book.ISBN})
+	}
+	return nil
+}
+
+// ImportBooks upserts the books of the rows, and copies at their libraries
+func (m *Memory) ImportBooks(ctx context.Context, rows []ImportRow, imported map[string]bool, dryRun bool) ([]ImportRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// A dry run undoes every change once the rows are imported, as rolling back does in Postgres
+	var (
+		previous    = make(map[string]Book)
+		addedBooks  []string
+		addedCopies []int64
+	)
+	rows = append([]ImportRow(nil), rows...)
+	for i := range rows {
+		if rows[i].Err != nil {
+			rows[i].Outcome = ImportRejected
+			continue
+		}
+
+		book := rows[i].Book
+		existing, stored := m.books[book.ISBN]
+		// Books imported by an earlier dry run batch were undone, but exist as far as the import goes
+		exists := stored || imported[book.ISBN]
+		barcode := book.ISBN
+		if exists {
+			barcode = fmt.Sprintf("%s-%d", book.ISBN, book.LibraryID)
+		}
+		addCopy := book.LibraryID != 0 && !m.hasCopyAt(book.ISBN, book.LibraryID)
+		if _, ok := m.libraries[book.LibraryID]; book.LibraryID != 0 && !ok {
+			rows[i].Outcome, rows[i].Err = ImportRejected, ErrLibraryNotFound
+			continue
+		}
+		if addCopy && m.barcodeTaken(barcode) {
+			rows[i].Outcome, rows[i].Err = ImportRejected, ErrCopyExists
+			continue
+		}
+
+		switch {
+		case stored:
+			if _, ok := previous[book.ISBN]; !ok {
+				previous[book.ISBN] = existing
+			}
+			existing.Price = book.Price
+			m.books[book.ISBN] = existing
+		default:
+			m.books[book.ISBN] = Book{ISBN: book.ISBN, Price: book.Price}
+			addedBooks = append(addedBooks, book.ISBN)
+		}
+		rows[i].Outcome = ImportCreated
+		if exists {
+			rows[i].Outcome = ImportUpdated
+		}
+		imported[book.ISBN] = true
+		if addCopy {
+			copy := m.addCopy(Copy{ISBN: book.ISBN, LibraryID: book.LibraryID, Barcode: barcode})
+			addedCopies = append(addedCopies, copy.ID)
+		}
+	}
+
+	if dryRun {
+		for _, id := range addedCopies {
+			delete(m.copies, id)
+		}
+		// Books created by the import may have been updated by later rows, so they're
+		// deleted after the updated books are restored
+		for isbn, book := range previous {
+			m.books[isbn] = book
+		}
+		for _, isbn := range addedBooks {
+			delete(m.books, isbn)
+		}
+	}
+	return rows, nil
+}
+
+// hasCopyAt reports whether a library holds a copy of a book. Callers must hold mu.
+func (m *Memory) hasCopyAt(isbn string, libraryID int64) bool {
+	for _, copy := range m.copies {
+		if copy.ISBN == isbn && copy.LibraryID == libraryID {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteBook deletes the book with the matching ISBN and its copies
+func (m *Memory) DeleteBook(ctx context.Context, isbn string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[isbn]; !ok {
+		return ErrBookNotFound
+	}
+	for _, reservation := range m.reservations {
+		if reservation.ISBN == isbn {
+			return ErrBookInUse
+		}
+	}
+	for _, hold := range m.holds {
+		if hold.ISBN == isbn {
+			return ErrBookInUse
+		}
+	}
+	for id, copy := range m.copies {
+		if copy.ISBN == isbn {
+			delete(m.copies, id)
+		}
+	}
+	delete(m.books, isbn)
+	return nil
+}
+
+// SearchBooks returns the books at libraries within range with copies free over the window,
+// one per book and library, in the query's order
+func (m *Memory) SearchBooks(ctx context.Context, query SearchQuery) ([]Book, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !query.Start.IsZero() && !query.End.IsZero() && query.End.Before(query.Start) {
+		return nil, ErrInvalidRange
+	}
+	windowed := !query.Start.IsZero() || !query.End.IsZero()
+	now := time.Now()
+
+	libraryIDs := make(map[int64]bool)
+	for _, id := range query.LibraryIDs {
+		libraryIDs[id] = true
+	}
+	isbns := make(map[string]bool)
+	for _, isbn := range query.ISBNs {
+		isbns[isbn] = true
+	}
+
+	type key struct {
+		isbn      string
+		libraryID int64
+	}
+	results := make(map[key]*Book)
+	for _, copy := range m.copies {
+		copy = m.withLibrary(copy)
+		price := m.books[copy.ISBN].Price
+		distance := distanceMeters(query.Lat, query.Lng, copy.Lat, copy.Lng)
+
+		switch {
+		case distance > query.RangeMeters,
+			query.MinPrice > 0 && price < query.MinPrice,
+			query.MaxPrice > 0 && price > query.MaxPrice,
+			len(libraryIDs) > 0 && !libraryIDs[copy.LibraryID],
+			len(isbns) > 0 && !isbns[copy.ISBN]:
+			continue
+		}
+
+		k := key{copy.ISBN, copy.LibraryID}
+		if _, ok := results[k]; !ok {
+			results[k] = &Book{
+				ISBN:      copy.ISBN,
+				Price:     price,
+				LibraryID: copy.LibraryID,
+				Library:   copy.Library,
+				Lat:       copy.Lat,
+				Lng:       copy.Lng,
+
+				DistanceMeters: distance,
+			}
+		}
+		results[k].TotalCopies++
+
+		if windowed && !m.isReserved(copy.ID, query.Start, query.End) || !windowed && !m.isReservedAt(copy.ID, now) {
+			results[k].AvailableCopies++
+		}
+	}
+
+	var books []Book
+	for _, book := range results {
+		// A windowed search only returns the books with a copy free over the window
+		if windowed && book.AvailableCopies == 0 {
+			continue
+		}
+		if query.After != nil && !searchesBefore(query.Order, cursorBook(*query.After), *book) {
+			continue
+		}
+		books = append(books, *book)
+	}
+	sort.Slice(books, func(i, j int) bool { return searchesBefore(query.Order, books[i], books[j]) })
+
+	if len(books) > query.Limit {
+		books = books[:query.Limit]
+	}
+	return books, nil
+}
+
+// searchesBefore reports whether search result a comes before b in the given order
+func searchesBefore(order SearchOrder, a, b Book) bool {
+	switch order {
+	case SearchByPrice:
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		}
+	case SearchByLibraryName:
+		if a.Library != b.Library {
+			return a.Library < b.Library
+		}
+	}
+	if order != SearchByLibraryName && a.DistanceMeters != b.DistanceMeters {
+		return a.DistanceMeters < b.DistanceMeters
+	}
+	if a.ISBN != b.ISBN {
+		return a.ISBN < b.ISBN
+	}
+	return a.LibraryID < b.LibraryID
+}
+
+// cursorBook returns a search result with the sort key of the cursor
+func cursorBook(cursor SearchCursor) Book {
+	return Book{
+		DistanceMeters: cursor.DistanceMeters,
+		Price:          cursor.Price,
+		Library:        cursor.Library,
+		ISBN:           cursor.ISBN,
+		LibraryID:      cursor.LibraryID,
+	}
+}
+
+// Reserve reserves the requested copy, or the first free copy, of a book for [start, end)
+// unless it overlaps an existing reservation
+func (m *Memory) Reserve(ctx context.Context, reservation Reservation) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.reserve(reservation)
+}
+
+// reserve makes a reservation. Callers must hold mu.
+func (m *Memory) reserve(reservation Reservation) (Reservation, error) {
+	isbn, start, end := reservation.ISBN, reservation.Start, reservation.End
+	if end.Before(start) {
+		return Reservation{}, ErrInvalidRange
+	}
+	if _, ok := m.books[isbn]; !ok {
+		return Reservation{}, ErrBookNotFound
+	}
+
+	var copyIDs []int64
+	for id, copy := range m.copies {
+		if copy.ISBN == isbn && (reservation.CopyID == 0 || id == reservation.CopyID) &&
+			(reservation.LibraryID == 0 || copy.LibraryID == reservation.LibraryID) {
+			copyIDs = append(copyIDs, id)
+		}
+	}
+	if len(copyIDs) == 0 {
+		return Reservation{}, ErrCopyNotFound
+	}
+	sort.Slice(copyIDs, func(i, j int) bool { return copyIDs[i] < copyIDs[j] })
+
+	var copyID int64
+	for _, id := range copyIDs {
+		if !m.isReserved(id, start, end) {
+			copyID = id
+			break
+		}
+	}
+	if copyID == 0 {
+		return Reservation{}, ErrOverlap
+	}
+	if _, ok := m.patrons[reservation.PatronID]; reservation.PatronID != 0 && !ok {
+		return Reservation{}, ErrPatronNotFound
+	}
+
+	m.nextID++
+	reservation = Reservation{
+		ID:        m.nextID,
+		ISBN:      isbn,
+		CopyID:    copyID,
+		PatronID:  reservation.PatronID,
+		Start:     start,
+		End:       end,
+		Status:    StatusReserved,
+		CreatedAt: time.Now(),
+		SeriesID:  reservation.SeriesID,
+	}
+	m.reservations[reservation.ID] = reservation
+	return m.withBookState(reservation), nil
+}
+
+// GetReservation returns the reservation with the matching ID
+func (m *Memory) GetReservation(ctx context.Context, id int64) (Reservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return Reservation{}, ErrReservationNotFound
+	}
+	return m.withBookState(reservation), nil
+}
+
+// FindReservation returns the reservation of a copy of a book over exactly [start, end)
+func (m *Memory) FindReservation(ctx context.Context, isbn string, copyID int64, start, end time.Time) (Reservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var found []Reservation
+	for _, reservation := range m.reservations {
+		if reservation.ISBN == isbn && (copyID == 0 || reservation.CopyID == copyID) &&
+			reservation.Start.Equal(start) && reservation.End.Equal(end) && reservation.Status != StatusCancelled {
+			found = append(found, reservation)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Reservation{}, ErrReservationNotFound
+	case 1:
+		return m.withBookState(found[0]), nil
+	}
+	return Reservation{}, ErrCopyRequired
+}
+
+// Checkout marks a reservation as checked out
+func (m *Memory) Checkout(ctx context.Context, reservationID, patronID int64) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, err := m.activeReservation(reservationID)
+	if err != nil {
+		return Reservation{}, err
+	}
+	if _, ok := m.checkouts[reservation.CopyID]; ok {
+		return Reservation{}, ErrAlreadyCheckedOut
+	}
+	if patronID == 0 {
+		patronID = reservation.PatronID
+	}
+	if _, ok := m.patrons[patronID]; patronID != 0 && !ok {
+		return Reservation{}, ErrPatronNotFound
+	}
+
+	m.checkouts[reservation.CopyID] = reservationID
+	m.checkoutTimes[reservationID] = time.Now()
+	m.checkoutPatrons[reservationID] = patronID
+	reservation.Status = StatusCheckedOut
+	m.reservations[reservationID] = reservation
+	return m.withBookState(reservation), nil
+}
+
+// ListReservations returns up to query.Limit reservations matching the query
+func (m *Memory) ListReservations(ctx context.Context, query ListReservationsQuery) ([]Reservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !query.Start.IsZero() && !query.End.IsZero() && query.End.Before(query.Start) {
+		return nil, ErrInvalidRange
+	}
+
+	statuses := make(map[ReservationStatus]bool)
+	for _, status := range query.Statuses {
+		statuses[status] = true
+	}
+
+	var reservations []Reservation
+	for _, reservation := range m.reservations {
+		reservation = m.withBookState(reservation)
+
+		switch {
+		case query.ISBN != "" && reservation.ISBN != query.ISBN,
+			query.Library != "" && reservation.Library != query.Library,
+			query.LibraryID != 0 && reservation.LibraryID != query.LibraryID,
+			query.PatronID != 0 && reservation.PatronID != query.PatronID,
+			query.SeriesID != 0 && reservation.SeriesID != query.SeriesID,
+			len(statuses) > 0 && !statuses[reservation.Status],
+			!query.Start.IsZero() && !query.Start.Before(reservation.End),
+			!query.End.IsZero() && !reservation.Start.Before(query.End),
+			!query.EndBefore.IsZero() && !reservation.End.Before(query.EndBefore):
+			continue
+		}
+		if query.After != nil && !sortsAfter(query.Order, reservation, *query.After) {
+			continue
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return sortsAfter(query.Order, reservations[j], cursorOf(query.Order, reservations[i]))
+	})
+	if len(reservations) > query.Limit {
+		reservations = reservations[:query.Limit]
+	}
+	return reservations, nil
+}
+
+// CancelReservation cancels a reservation that hasn't been checked out, releasing its slot
+func (m *Memory) CancelReservation(ctx context.Context, id int64) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, err := m.activeReservation(id)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	reservation.Status = StatusCancelled
+	m.reservations[id] = reservation
+	return m.withBookState(reservation), nil
+}
+
+// RescheduleReservation moves a reservation that hasn't been checked out to [start, end)
+func (m *Memory) RescheduleReservation(ctx context.Context, id int64, start, end time.Time) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, err := m.activeReservation(id)
+	if err != nil {
+		return Reservation{}, err
+	}
+	if end.Before(start) {
+		return Reservation{}, ErrInvalidRange
+	}
+
+	for _, other := range m.reservations {
+		if other.ID != id && other.CopyID == reservation.CopyID && other.Status.holdsSlot() && overlaps(other.Start, other.End, start, end) {
+			return Reservation{}, ErrOverlap
+		}
+	}
+
+	reservation.Start = start
+	reservation.End = end
+	m.reservations[id] = reservation
+	return m.withBookState(reservation), nil
+}
+
+// Return removes the checkout of a copy of a book and marks its reservation as returned,
+// charging a late fee if it is overdue
+func (m *Memory) Return(ctx context.Context, isbn string, copyID int64) (Reservation, Fee, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var copyIDs []int64
+	for id := range m.checkouts {
+		if m.copies[id].ISBN == isbn && (copyID == 0 || id == copyID) {
+			copyIDs = append(copyIDs, id)
+		}
+	}
+	switch {
+	case len(copyIDs) == 0:
+		return Reservation{}, Fee{}, ErrNotCheckedOut
+	case len(copyIDs) > 1:
+		return Reservation{}, Fee{}, ErrCopyRequired
+	}
+
+	reservationID := m.checkouts[copyIDs[0]]
+	patronID := m.checkoutPatrons[reservationID]
+	delete(m.checkouts, copyIDs[0])
+	delete(m.checkoutTimes, reservationID)
+	delete(m.checkoutPatrons, reservationID)
+	delete(m.checkoutOverdue, reservationID)
+
+	reservation := m.reservations[reservationID]
+	reservation.Status = StatusReturned
+	reservation.ReturnedAt = time.Now()
+	m.reservations[reservationID] = reservation
+
+	if patronID == 0 {
+		patronID = reservation.PatronID
+	}
+	if patronID == 0 {
+		return m.withBookState(reservation), Fee{}, nil
+	}
+
+	policy := m.libraries[m.copies[reservation.CopyID].LibraryID].LateFees
+	fee := Fee{PatronID: patronID, ReservationID: reservationID, CreatedAt: reservation.ReturnedAt}
+	fee.Amount, fee.DaysLate = policy.Fee(m.books[reservation.ISBN].Price, reservation.End, reservation.ReturnedAt)
+	if fee.Amount == 0 {
+		return m.withBookState(reservation), Fee{}, nil
+	}
+
+	m.nextFeeID++
+	fee.ID = m.nextFeeID
+	m.fees[fee.ID] = fee
+	return m.withBookState(reservation), fee, nil
+}
+
+// Renew extends a checked out reservation to end, if its library allows it
+func (m *Memory) Renew(ctx context.Context, id int64, end time.Time) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return Reservation{}, ErrReservationNotFound
+	}
+	switch reservation.Status {
+	case StatusReserved:
+		return Reservation{}, ErrNotCheckedOut
+	case StatusReturned, StatusCancelled:
+		return Reservation{}, ErrReservationClosed
+	}
+
+	library := m.libraries[m.copies[reservation.CopyID].LibraryID]
+	if err := checkRenewal(library, reservation, end, time.Now()); err != nil {
+		return Reservation{}, err
+	}
+
+	for _, other := range m.reservations {
+		if other.ID != id && other.CopyID == reservation.CopyID && other.Status.holdsSlot() && overlaps(other.Start, other.End, reservation.Start, end) {
+			return Reservation{}, ErrOverlap
+		}
+	}
+
+	reservation.End = end
+	reservation.Renewals++
+	m.reservations[id] = reservation
+	return m.withBookState(reservation), nil
+}
+
+// FlagOverdue flags the checkouts whose reservation ended before now as overdue
+func (m *Memory) FlagOverdue(ctx context.Context, now time.Time) ([]Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var reservations []Reservation
+	for _, id := range m.checkouts {
+		reservation := m.reservations[id]
+		if _, flagged := m.checkoutOverdue[id]; flagged || !reservation.End.Before(now) {
+			continue
+		}
+		m.checkoutOverdue[id] = now
+		reservations = append(reservations, m.withBookState(reservation))
+	}
+
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].ID < reservations[j].ID })
+	return reservations, nil
+}
+
+// withBookState fills in the fields that Postgres joins from copies, libraries and checked_out. Callers must hold mu.
+func (m *Memory) withBookState(reservation Reservation) Reservation {
+	reservation.LibraryID = m.copies[reservation.CopyID].LibraryID
+	reservation.Library = m.libraries[reservation.LibraryID].Name
+	if id, ok := m.checkouts[reservation.CopyID]; ok && id == reservation.ID {
+		reservation.CheckedOutAt = m.checkoutTimes[id]
+		reservation.CheckedOutBy = m.checkoutPatrons[id]
+		reservation.OverdueAt = m.checkoutOverdue[id]
+	}
+	return reservation
+}
+
+// cursorOf returns the sort key of a reservation in the given order
+func cursorOf(order ReservationOrder, reservation Reservation) ReservationCursor {
+	switch order {
+	case OrderByCreated, OrderByCreatedDesc:
+		return ReservationCursor{Time: reservation.CreatedAt, ID: reservation.ID}
+	}
+	return ReservationCursor{Time: reservation.Start, ID: reservation.ID}
+}
+
+// sortsAfter reports whether reservation comes after the cursor in the given order
+func sortsAfter(order ReservationOrder, reservation Reservation, cursor ReservationCursor) bool {
+	key := cursorOf(order, reservation)
+	after := key.Time.After(cursor.Time) || (key.Time.Equal(cursor.Time) && key.ID > cursor.ID)
+	before := key.Time.Before(cursor.Time) || (key.Time.Equal(cursor.Time) && key.ID < cursor.ID)
+
+	switch order {
+	case OrderByStartDesc, OrderByCreatedDesc:
+		return before
+	}
+	return after
+}
+
+// activeReservation returns a reservation that is reserved and not yet checked out. Callers must hold mu.
+func (m *Memory) activeReservation(id int64) (Reservation, error) {
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return Reservation{}, ErrReservationNotFound
+	}
+
+	switch reservation.Status {
+	case StatusCheckedOut:
+		return Reservation{}, ErrAlreadyCheckedOut
+	case StatusReturned, StatusCancelled:
+		return Reservation{}, ErrReservationClosed
+	}
+	return reservation, nil
+}
+
+// isReserved reports whether any live reservation of the copy overlaps [start, end).
+// Either may be the zero time to leave that end unbounded. Callers must hold mu.
+func (m *Memory) isReserved(copyID int64, start, end time.Time) bool {
+	for _, reservation := range m.reservations {
+		if reservation.CopyID != copyID || !reservation.Status.holdsSlot() {
+			continue
+		}
+		if (start.IsZero() || start.Before(reservation.End)) && (end.IsZero() || reservation.Start.Before(end)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReservedAt reports whether a live reservation of the copy holds it at t. Callers must hold mu.
+func (m *Memory) isReservedAt(copyID int64, t time.Time) bool {
+	for _, reservation := range m.reservations {
+		if reservation.CopyID == copyID && reservation.Status.holdsSlot() && !t.Before(reservation.Start) && t.Before(reservation.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// distanceMeters returns the great-circle distance between two coordinates
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"sort"
+)
+
+// AddCopy adds a new copy of an existing book
+func (m *Memory) AddCopy(ctx context.Context, copy Copy) (Copy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[copy.ISBN]; !ok {
+		return Copy{}, ErrBookNotFound
+	}
+	if _, ok := m.libraries[copy.LibraryID]; !ok {
+		return Copy{}, ErrLibraryNotFound
+	}
+	if m.barcodeTaken(copy.Barcode) {
+		return Copy{}, ErrCopyExists
+	}
+
+	return m.withLibrary(m.addCopy(copy)), nil
+}
+
+// GetCopy returns the copy with the matching ID
+func (m *Memory) GetCopy(ctx context.Context, id int64) (Copy, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	copy, ok := m.copies[id]
+	if !ok {
+		return Copy{}, ErrCopyNotFound
+	}
+	return m.withLibrary(copy), nil
+}
+
+// ListCopies returns the copies of a book ordered by ID
+func (m *Memory) ListCopies(ctx context.Context, isbn string) ([]Copy, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.books[isbn]; !ok {
+		return nil, ErrBookNotFound
+	}
+	return m.copiesOf(isbn), nil
+}
+
+// DeleteCopy deletes a copy that has never been reserved
+func (m *Memory) DeleteCopy(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.copies[id]; !ok {
+		return ErrCopyNotFound
+	}
+	for _, reservation := range m.reservations {
+		if reservation.CopyID == id {
+			return ErrCopyInUse
+		}
+	}
+	delete(m.copies, id)
+	return nil
+}
+
+// addCopy assigns the copy an ID and stores it. Callers must hold mu.
+func (m *Memory) addCopy(copy Copy) Copy {
+	m.nextCopyID++
+	copy.ID = m.nextCopyID
+	copy.Library, copy.Lat, copy.Lng = "", 0, 0
+	m.copies[copy.ID] = copy
+	return copy
+}
+
+// withLibrary fills in the fields that Postgres joins from libraries. Callers must hold mu.
+func (m *Memory) withLibrary(copy Copy) Copy {
+	library := m.libraries[copy.LibraryID]
+	copy.Library, copy.Lat, copy.Lng = library.Name, library.Lat, library.Lng
+	return copy
+}
+
+// copiesOf returns the copies of a book ordered by ID. Callers must hold mu.
+func (m *Memory) copiesOf(isbn string) []Copy {
+	var copies []Copy
+	for _, copy := range m.copies {
+		if copy.ISBN == isbn {
+			copies = append(copies, m.withLibrary(copy))
+		}
+	}
+	sort.Slice(copies, func(i, j int) bool { return copies[i].ID < copies[j].ID })
+	return copies
+}
+
+// withFirstCopy fills in the library of the book's first copy, as Postgres does. Callers must hold mu.
+func (m *Memory) withFirstCopy(book Book) Book {
+	if copies := m.copiesOf(book.ISBN); len(copies) > 0 {
+		book.LibraryID, book.Library, book.Lat, book.Lng = copies[0].LibraryID, copies[0].Library, copies[0].Lat, copies[0].Lng
+	}
+	return book
+}
+
+// barcodeTaken reports whether any copy has the barcode. Callers must hold mu.
+func (m *Memory) barcodeTaken(barcode string) bool {
+	for _, copy := range m.copies {
+		if copy.Barcode == barcode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// PlaceHold adds a patron to the back of the hold queue of a book
+func (m *Memory) PlaceHold(ctx context.Context, hold Hold) (Hold, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[hold.ISBN]; !ok {
+		return Hold{}, ErrBookNotFound
+	}
+	if _, ok := m.patrons[hold.PatronID]; !ok {
+		return Hold{}, ErrPatronNotFound
+	}
+	if _, ok := m.libraries[hold.LibraryID]; hold.LibraryID != 0 && !ok {
+		return Hold{}, ErrLibraryNotFound
+	}
+	for _, other := range m.holds {
+		if other.ISBN == hold.ISBN && other.PatronID == hold.PatronID && other.Status == HoldWaiting {
+			return Hold{}, ErrHoldExists
+		}
+	}
+
+	m.nextHoldID++
+	hold = Hold{
+		ID:        m.nextHoldID,
+		ISBN:      hold.ISBN,
+		PatronID:  hold.PatronID,
+		LibraryID: hold.LibraryID,
+		Days:      hold.Days,
+		Status:    HoldWaiting,
+		CreatedAt: time.Now(),
+	}
+	m.holds[hold.ID] = hold
+	return m.withPosition(hold), nil
+}
+
+// GetHold returns the hold with the matching ID
+func (m *Memory) GetHold(ctx context.Context, id int64) (Hold, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hold, ok := m.holds[id]
+	if !ok {
+		return Hold{}, ErrHoldNotFound
+	}
+	return m.withPosition(hold), nil
+}
+
+// ListHolds returns the waiting holds of a book in queue order
+func (m *Memory) ListHolds(ctx context.Context, isbn string) ([]Hold, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.books[isbn]; !ok {
+		return nil, ErrBookNotFound
+	}
+
+	queue := m.queueOf(isbn)
+	for i := range queue {
+		queue[i].Position = i + 1
+	}
+	return queue, nil
+}
+
+// HeldBooks returns the ISBNs of the books with waiting holds, in order
+func (m *Memory) HeldBooks(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	held := make(map[string]bool)
+	for _, hold := range m.holds {
+		if hold.Status == HoldWaiting {
+			held[hold.ISBN] = true
+		}
+	}
+	isbns := make([]string, 0, len(held))
+	for isbn := range held {
+		isbns = append(isbns, isbn)
+	}
+	sort.Strings(isbns)
+	return isbns, nil
+}
+
+// CancelHold takes a waiting hold out of its queue
+func (m *Memory) CancelHold(ctx context.Context, id int64) (Hold, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hold, ok := m.holds[id]
+	if !ok {
+		return Hold{}, ErrHoldNotFound
+	}
+	if hold.Status != HoldWaiting {
+		return Hold{}, ErrHoldClosed
+	}
+
+	hold.Status = HoldCancelled
+	m.holds[id] = hold
+	return hold, nil
+}
+
+// FulfillHold makes the reservation and marks the waiting hold as fulfilled by it
+func (m *Memory) FulfillHold(ctx context.Context, id int64, reservation Reservation) (Hold, Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hold, ok := m.holds[id]
+	if !ok {
+		return Hold{}, Reservation{}, ErrHoldNotFound
+	}
+	if hold.Status != HoldWaiting {
+		return Hold{}, Reservation{}, ErrHoldClosed
+	}
+
+	reservation, err := m.reserve(reservation)
+	if err != nil {
+		return Hold{}, Reservation{}, err
+	}
+
+	hold.Status = HoldFulfilled
+	hold.ReservationID = reservation.ID
+	m.holds[id] = hold
+	return hold, reservation, nil
+}
+
+// queueOf returns the waiting holds of a book in the order they were placed. Callers must hold mu.
+func (m *Memory) queueOf(isbn string) []Hold {
+	var queue []Hold
+	for _, hold := range m.holds {
+		if hold.ISBN == isbn && hold.Status == HoldWaiting {
+			queue = append(queue, hold)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool { return queue[i].ID < queue[j].ID })
+	return queue
+}
+
+// withPosition sets the queue position of a waiting hold. Callers must hold mu.
+func (m *Memory) withPosition(hold Hold) Hold {
+	if hold.Status != HoldWaiting {
+		return hold
+	}
+	for i, other := range m.queueOf(hold.ISBN) {
+		if other.ID == hold.ID {
+			hold.Position = i + 1
+		}
+	}
+	return hold
+}
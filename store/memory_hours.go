@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// GetOpeningHours returns the weekly opening hours of a library ordered by weekday and time
+func (m *Memory) GetOpeningHours(ctx context.Context, libraryID int64) ([]OpeningHours, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.libraries[libraryID]; !ok {
+		return nil, ErrLibraryNotFound
+	}
+	return append([]OpeningHours(nil), m.hours[libraryID]...), nil
+}
+
+// SetOpeningHours replaces the weekly opening hours of a library
+func (m *Memory) SetOpeningHours(ctx context.Context, libraryID int64, hours []OpeningHours) ([]OpeningHours, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.libraries[libraryID]; !ok {
+		return nil, ErrLibraryNotFound
+	}
+
+	hours = append([]OpeningHours(nil), hours...)
+	sort.Slice(hours, func(i, j int) bool {
+		if hours[i].Weekday != hours[j].Weekday {
+			return hours[i].Weekday < hours[j].Weekday
+		}
+		return hours[i].Opens < hours[j].Opens
+	})
+	if len(hours) == 0 {
+		delete(m.hours, libraryID)
+	} else {
+		m.hours[libraryID] = hours
+	}
+
+	return append([]OpeningHours(nil), hours...), nil
+}
+
+// ListHoursExceptions returns the opening hours exceptions of a library dated from from to to inclusive
+func (m *Memory) ListHoursExceptions(ctx context.Context, libraryID int64, from, to time.Time) ([]HoursException, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.libraries[libraryID]; !ok {
+		return nil, ErrLibraryNotFound
+	}
+
+	// Dates are compared as dates, like the Postgres DATE column
+	from, to = truncateDate(from), truncateDate(to)
+
+	var exceptions []HoursException
+	for _, exception := range m.exceptions {
+		if exception.LibraryID != libraryID ||
+			!from.IsZero() && exception.Date.Before(from) ||
+			!to.IsZero() && exception.Date.After(to) {
+			continue
+		}
+		exceptions = append(exceptions, exception)
+	}
+	sort.Slice(exceptions, func(i, j int) bool { return exceptions[i].Date.Before(exceptions[j].Date) })
+
+	return exceptions, nil
+}
+
+// AddHoursException adds an opening hours exception to a library
+func (m *Memory) AddHoursException(ctx context.Context, exception HoursException) (HoursException, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.libraries[exception.LibraryID]; !ok {
+		return HoursException{}, ErrLibraryNotFound
+	}
+
+	exception.Date = truncateDate(exception.Date)
+	for _, other := range m.exceptions {
+		if other.LibraryID == exception.LibraryID && other.Date.Equal(exception.Date) {
+			return HoursException{}, ErrHoursExceptionExists
+		}
+	}
+	if exception.Closed {
+		exception.Opens, exception.Closes = 0, 0
+	}
+
+	m.nextExceptionID++
+	exception.ID = m.nextExceptionID
+	m.exceptions[exception.ID] = exception
+
+	return exception, nil
+}
+
+// DeleteHoursException deletes an opening hours exception of a library
+func (m *Memory) DeleteHoursException(ctx context.Context, libraryID, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exception, ok := m.exceptions[id]
+	if !ok || exception.LibraryID != libraryID {
+		return ErrHoursExceptionNotFound
+	}
+	delete(m.exceptions, id)
+	return nil
+}
+
+// truncateDate returns midnight UTC of the date of t, keeping the zero time zero
+func truncateDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// CreateLibrary adds a new library
+func (m *Memory) CreateLibrary(ctx context.Context, library Library) (Library, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.libraryNameTaken(library.Name, 0) {
+		return Library{}, ErrLibraryExists
+	}
+
+	m.nextLibraryID++
+	library.ID = m.nextLibraryID
+	library.CreatedAt = time.Now()
+	m.libraries[library.ID] = library
+	return library, nil
+}
+
+// GetLibrary returns the library with the matching ID
+func (m *Memory) GetLibrary(ctx context.Context, id int64) (Library, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	library, ok := m.libraries[id]
+	if !ok {
+		return Library{}, ErrLibraryNotFound
+	}
+	return library, nil
+}
+
+// ListLibraries returns every library ordered by name
+func (m *Memory) ListLibraries(ctx context.Context) ([]Library, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var libraries []Library
+	for _, library := range m.libraries {
+		libraries = append(libraries, library)
+	}
+	sort.Slice(libraries, func(i, j int) bool { return libraries[i].Name < libraries[j].Name })
+
+	return libraries, nil
+}
+
+// UpdateLibrary replaces every field of an existing library but its ID and creation time
+func (m *Memory) UpdateLibrary(ctx context.Context, library Library) (Library, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.libraries[library.ID]
+	if !ok {
+		return Library{}, ErrLibraryNotFound
+	}
+	if m.libraryNameTaken(library.Name, library.ID) {
+		return Library{}, ErrLibraryExists
+	}
+
+	library.CreatedAt = existing.CreatedAt
+	m.libraries[library.ID] = library
+	return library, nil
+}
+
+// DeleteLibrary deletes a library that holds no copies
+func (m *Memory) DeleteLibrary(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.libraries[id]; !ok {
+		return ErrLibraryNotFound
+	}
+	for _, copy := range m.copies {
+		if copy.LibraryID == id {
+			return ErrLibraryInUse
+		}
+	}
+	for _, hold := range m.holds {
+		if hold.LibraryID == id {
+			return ErrLibraryInUse
+		}
+	}
+	delete(m.libraries, id)
+	delete(m.hours, id)
+	for exceptionID, exception := range m.exceptions {
+		if exception.LibraryID == id {
+			delete(m.exceptions, exceptionID)
+		}
+	}
+	return nil
+}
+
+// libraryNameTaken reports whether a library other than exceptID has the name. Callers must hold mu.
+func (m *Memory) libraryNameTaken(name string, exceptID int64) bool {
+	for id, library := range m.libraries {
+		if id != exceptID && library.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// CreatePatron adds a new patron
+func (m *Memory) CreatePatron(ctx context.Context, patron Patron) (Patron, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.emailTaken(patron.Email, 0) {
+		return Patron{}, ErrPatronExists
+	}
+
+	m.nextPatronID++
+	patron.ID = m.nextPatronID
+	patron.CreatedAt = time.Now()
+	m.patrons[patron.ID] = patron
+	return patron, nil
+}
+
+// GetPatron returns the patron with the matching ID
+func (m *Memory) GetPatron(ctx context.Context, id int64) (Patron, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	patron, ok := m.patrons[id]
+	if !ok {
+		return Patron{}, ErrPatronNotFound
+	}
+	return m.withBalance(patron), nil
+}
+
+// UpdatePatron replaces the name, email and phone of an existing patron
+func (m *Memory) UpdatePatron(ctx context.Context, patron Patron) (Patron, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.patrons[patron.ID]
+	if !ok {
+		return Patron{}, ErrPatronNotFound
+	}
+	if m.emailTaken(patron.Email, patron.ID) {
+		return Patron{}, ErrPatronExists
+	}
+
+	existing.Name = patron.Name
+	existing.Email = patron.Email
+	existing.Phone = patron.Phone
+	m.patrons[patron.ID] = existing
+	return m.withBalance(existing), nil
+}
+
+// ListFees returns the fees charged to a patron, newest first
+func (m *Memory) ListFees(ctx context.Context, patronID int64) ([]Fee, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var fees []Fee
+	for _, fee := range m.fees {
+		if fee.PatronID == patronID {
+			fees = append(fees, fee)
+		}
+	}
+
+	sort.Slice(fees, func(i, j int) bool { return fees[i].ID > fees[j].ID })
+	return fees, nil
+}
+
+// withBalance fills in the total of a patron's fees. Callers must hold mu.
+func (m *Memory) withBalance(patron Patron) Patron {
+	patron.Balance = 0
+	for _, fee := range m.fees {
+		if fee.PatronID == patron.ID {
+			patron.Balance += fee.Amount
+		}
+	}
+	patron.Balance = math.Round(patron.Balance*100) / 100
+	return patron
+}
+
+// emailTaken reports whether a patron other than exceptID uses email. Callers must hold mu.
+func (m *Memory) emailTaken(email string, exceptID int64) bool {
+	for _, patron := range m.patrons {
+		if patron.Email == email && patron.ID != exceptID {
+			return true
+		}
+	}
+	return false
+}
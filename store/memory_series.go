@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ReserveSeries creates a series and reserves each of its occurrences
+func (m *Memory) ReserveSeries(ctx context.Context, series Series, occurrences []SeriesOccurrence, allOrNothing bool) (Series, []SeriesOccurrence, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[series.ISBN]; !ok {
+		return Series{}, nil, ErrBookNotFound
+	}
+	if _, ok := m.patrons[series.PatronID]; series.PatronID != 0 && !ok {
+		return Series{}, nil, ErrPatronNotFound
+	}
+
+	m.nextSeriesID++
+	series = Series{
+		ID:        m.nextSeriesID,
+		ISBN:      series.ISBN,
+		PatronID:  series.PatronID,
+		RRule:     series.RRule,
+		Timezone:  series.Timezone,
+		Start:     series.Start,
+		End:       series.End,
+		CreatedAt: time.Now(),
+	}
+
+	occurrences = append([]SeriesOccurrence(nil), occurrences...)
+	reserved := 0
+	for i := range occurrences {
+		if occurrences[i].Err != nil {
+			continue
+		}
+
+		occurrences[i].Err = ErrOverlap
+		for _, libraryID := range occurrences[i].LibraryIDs {
+			reservation, err := m.reserve(Reservation{
+				ISBN:      series.ISBN,
+				CopyID:    occurrences[i].CopyID,
+				LibraryID: libraryID,
+				PatronID:  series.PatronID,
+				Start:     occurrences[i].Start,
+				End:       occurrences[i].End,
+				SeriesID:  series.ID,
+			})
+			if err == ErrOverlap || err == ErrCopyNotFound {
+				continue
+			}
+			if err != nil {
+				m.dropSeriesReservations(occurrences)
+				return Series{}, nil, err
+			}
+
+			occurrences[i].Reservation, occurrences[i].Err = reservation, nil
+			reserved++
+			break
+		}
+	}
+
+	if reserved == 0 || (allOrNothing && reserved < len(occurrences)) {
+		m.dropSeriesReservations(occurrences)
+		return Series{}, occurrences, ErrSeriesConflict
+	}
+
+	m.series[series.ID] = series
+	return series, occurrences, nil
+}
+
+// dropSeriesReservations undoes the reservations made for occurrences, as rolling back
+// does in Postgres. Callers must hold mu.
+func (m *Memory) dropSeriesReservations(occurrences []SeriesOccurrence) {
+	for i := range occurrences {
+		if occurrences[i].Reservation.ID != 0 {
+			delete(m.reservations, occurrences[i].Reservation.ID)
+			occurrences[i].Reservation = Reservation{}
+		}
+	}
+}
+
+// GetSeries returns the reservation series with the matching ID
+func (m *Memory) GetSeries(ctx context.Context, id int64) (Series, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	series, ok := m.series[id]
+	if !ok {
+		return Series{}, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// CancelSeries cancels a series along with its reservations that haven't been checked out
+func (m *Memory) CancelSeries(ctx context.Context, id int64) (Series, []Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	series, ok := m.series[id]
+	if !ok {
+		return Series{}, nil, ErrSeriesNotFound
+	}
+	if !series.CancelledAt.IsZero() {
+		return Series{}, nil, ErrSeriesCancelled
+	}
+
+	var cancelled []Reservation
+	for reservationID, reservation := range m.reservations {
+		if reservation.SeriesID != id || reservation.Status != StatusReserved {
+			continue
+		}
+		reservation.Status = StatusCancelled
+		m.reservations[reservationID] = reservation
+		cancelled = append(cancelled, m.withBookState(reservation))
+	}
+	sort.Slice(cancelled, func(i, j int) bool { return cancelled[i].ID < cancelled[j].ID })
+
+	series.CancelledAt = time.Now()
+	m.series[id] = series
+	return series, cancelled, nil
+}
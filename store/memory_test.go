@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// The memory store stands in for Postgres, so these tests pin down the semantics both
+// must share: the constraints of the migrations and the errors of the Postgres queries.
+
+var day = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func at(hour int) time.Time {
+	return day.Add(time.Duration(hour) * time.Hour)
+}
+
+// newTestMemory returns a memory store holding one copy of book 111 at a library
+func newTestMemory(t *testing.T) (*Memory, Library) {
+	m := NewMemory()
+	library, err := m.CreateLibrary(context.Background(), Library{Name: "Central", Lat: 33.6846, Lng: -117.8265})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.AddBook(context.Background(), Book{ISBN: "111", LibraryID: library.ID}); err != nil {
+		t.Fatal(err)
+	}
+	return m, library
+}
+
+func TestReserveOverlapIsHalfOpen(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       error
+	}{
+		{"ends when it starts", at(8), at(10), nil},
+		{"starts when it ends", at(12), at(14), nil},
+		{"same window", at(10), at(12), ErrOverlap},
+		{"overlaps its start", at(9), at(11), ErrOverlap},
+		{"overlaps its end", at(11), at(13), ErrOverlap},
+		{"within it", at(10).Add(time.Minute), at(12).Add(-time.Minute), ErrOverlap},
+		{"around it", at(9), at(13), ErrOverlap},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each case runs against its own store so that successful reservations don't interfere
+			m, _ := newTestMemory(t)
+			if _, err := m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)}); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := m.Reserve(ctx, Reservation{ISBN: "111", Start: tt.start, End: tt.end})
+			if err != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCancelledAndReturnedReservationsFreeTheirSlot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cancelled", func(t *testing.T) {
+		m, _ := newTestMemory(t)
+		reservation, err := m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = m.CancelReservation(ctx, reservation.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)}); err != nil {
+			t.Errorf("reserving a cancelled reservation's slot: %v", err)
+		}
+	})
+
+	t.Run("returned", func(t *testing.T) {
+		m, _ := newTestMemory(t)
+		reservation, err := m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = m.Checkout(ctx, reservation.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)}); err != ErrOverlap {
+			t.Errorf("reserving a checked out reservation's slot: got %v, want %v", err, ErrOverlap)
+		}
+
+		if _, _, err = m.Return(ctx, "111", 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)}); err != nil {
+			t.Errorf("reserving a returned reservation's slot: %v", err)
+		}
+	})
+}
+
+func TestReserveErrorPrecedence(t *testing.T) {
+	ctx := context.Background()
+	m, library := newTestMemory(t)
+	other, err := m.CreateLibrary(ctx, Library{Name: "North"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.AddBook(ctx, Book{ISBN: "222"}); err != nil {
+		t.Fatal(err)
+	}
+	taken, err := m.Reserve(ctx, Reservation{ISBN: "111", Start: at(10), End: at(12)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As in noFreeCopyError, a missing book wins over a missing copy, which wins over an overlap
+	tests := []struct {
+		name        string
+		reservation Reservation
+		want        error
+	}{
+		{"unknown book", Reservation{ISBN: "999", Start: at(10), End: at(12)}, ErrBookNotFound},
+		{"unknown book and copy", Reservation{ISBN: "999", CopyID: 42, Start: at(10), End: at(12)}, ErrBookNotFound},
+		{"book without copies", Reservation{ISBN: "222", Start: at(10), End: at(12)}, ErrCopyNotFound},
+		{"unknown copy", Reservation{ISBN: "111", CopyID: 42, Start: at(10), End: at(12)}, ErrCopyNotFound},
+		{"no copy at the library", Reservation{ISBN: "111", LibraryID: other.ID, Start: at(10), End: at(12)}, ErrCopyNotFound},
+		{"taken copy", Reservation{ISBN: "111", CopyID: taken.CopyID, Start: at(10), End: at(12)}, ErrOverlap},
+		{"taken at the library", Reservation{ISBN: "111", LibraryID: library.ID, Start: at(11), End: at(13)}, ErrOverlap},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.Reserve(ctx, tt.reservation); err != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchBooksOrdersByDistance(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	if err := m.AddBook(ctx, Book{ISBN: "111"}); err != nil {
+		t.Fatal(err)
+	}
+	// Created out of order, and from the search point in Irvine: Costa Mesa is about 8km
+	// away, Newport Beach 12km and San Diego out of range
+	libraries := []Library{
+		{Name: "Newport Beach", Lat: 33.6189, Lng: -117.9298},
+		{Name: "Irvine", Lat: 33.6846, Lng: -117.8265},
+		{Name: "Costa Mesa", Lat: 33.6638, Lng: -117.9047},
+		{Name: "San Diego", Lat: 32.7157, Lng: -117.1611},
+	}
+	for _, library := range libraries {
+		created, err := m.CreateLibrary(ctx, library)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = m.AddCopy(ctx, Copy{ISBN: "111", LibraryID: created.ID, Barcode: created.Name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	books, err := m.SearchBooks(ctx, SearchQuery{
+		Lat:         33.6846,
+		Lng:         -117.8265,
+		RangeMeters: 20000,
+		Order:       SearchByDistance,
+		Limit:       10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Irvine", "Costa Mesa", "Newport Beach"}
+	if len(books) != len(want) {
+		t.Fatalf("got %d books, want %d: San Diego is out of range", len(books), len(want))
+	}
+	for i, book := range books {
+		if book.Library != want[i] {
+			t.Errorf("result %d: got %s, want %s", i, book.Library, want[i])
+		}
+		if i > 0 && book.DistanceMeters < books[i-1].DistanceMeters {
+			t.Errorf("result %d is nearer than result %d", i, i-1)
+		}
+	}
+	if books[0].DistanceMeters != 0 {
+		t.Errorf("the library at the search point is %vm away, want 0", books[0].DistanceMeters)
+	}
+}
+
+func TestImportDryRunMatchesRealRunAcrossBatches(t *testing.T) {
+	ctx := context.Background()
+	batches := [][]ImportRow{
+		{{Book: Book{ISBN: "222", Price: 5}}},
+		{{Book: Book{ISBN: "222", Price: 6}}, {Book: Book{ISBN: "333", Price: 7}}},
+	}
+
+	outcomes := func(dryRun bool) (*Memory, []ImportOutcome) {
+		m, library := newTestMemory(t)
+		imported := make(map[string]bool)
+		var outcomes []ImportOutcome
+		for _, batch := range batches {
+			for i := range batch {
+				batch[i].Book.LibraryID = library.ID
+			}
+			rows, err := m.ImportBooks(ctx, batch, imported, dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range rows {
+				outcomes = append(outcomes, row.Outcome)
+			}
+		}
+		return m, outcomes
+	}
+
+	_, real := outcomes(false)
+	m, dry := outcomes(true)
+	want := []ImportOutcome{ImportCreated, ImportUpdated, ImportCreated}
+	for i := range want {
+		if real[i] != want[i] || dry[i] != want[i] {
+			t.Errorf("row %d: got %s in a real run and %s in a dry run, want %s", i, real[i], dry[i], want[i])
+		}
+	}
+
+	for _, isbn := range []string{"222", "333"} {
+		if _, err := m.GetBook(ctx, isbn); err != ErrBookNotFound {
+			t.Errorf("the dry run kept book %s: %v", isbn, err)
+		}
+	}
+}
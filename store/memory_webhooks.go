@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// CreateWebhook adds a webhook subscription
+func (m *Memory) CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextWebhookID++
+	webhook.ID = m.nextWebhookID
+	webhook.Events = append([]string(nil), webhook.Events...)
+	webhook.CreatedAt = time.Now()
+	m.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+// GetWebhook returns the webhook with the matching ID
+func (m *Memory) GetWebhook(ctx context.Context, id int64) (Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	webhook, ok := m.webhooks[id]
+	if !ok {
+		return Webhook{}, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// ListWebhooks returns every webhook ordered by ID
+func (m *Memory) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var webhooks []Webhook
+	for _, webhook := range m.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+// UpdateWebhook replaces the URL, secret, events and active flag of a webhook
+func (m *Memory) UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.webhooks[webhook.ID]
+	if !ok {
+		return Webhook{}, ErrWebhookNotFound
+	}
+
+	existing.URL = webhook.URL
+	existing.Secret = webhook.Secret
+	existing.Events = append([]string(nil), webhook.Events...)
+	existing.Active = webhook.Active
+	m.webhooks[webhook.ID] = existing
+	return existing, nil
+}
+
+// DeleteWebhook deletes a webhook along with its deliveries
+func (m *Memory) DeleteWebhook(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.webhooks[id]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(m.webhooks, id)
+	for deliveryID, delivery := range m.deliveries {
+		if delivery.WebhookID == id {
+			delete(m.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues the payload for every active webhook subscribed to the event
+func (m *Memory) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	queued := 0
+	for _, webhook := range m.webhooks {
+		if !webhook.Subscribed(event) {
+			continue
+		}
+
+		m.nextDeliveryID++
+		m.deliveries[m.nextDeliveryID] = WebhookDelivery{
+			ID:            m.nextDeliveryID,
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       append([]byte(nil), payload...),
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// ClaimDeliveries returns up to limit due pending deliveries, postponing their next attempt by lease
+func (m *Memory) ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var due []WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.Status == DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i := range due {
+		due[i].NextAttemptAt = now.Add(lease)
+		m.deliveries[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (m *Memory) UpdateDelivery(ctx context.Context, delivery WebhookDelivery) (WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.deliveries[delivery.ID]
+	if !ok {
+		// The webhook was deleted while the delivery was in flight
+		return WebhookDelivery{}, ErrWebhookNotFound
+	}
+
+	existing.Status = delivery.Status
+	existing.Attempts = delivery.Attempts
+	existing.NextAttemptAt = delivery.NextAttemptAt
+	existing.LastAttemptAt = delivery.LastAttemptAt
+	existing.LastStatusCode = delivery.LastStatusCode
+	existing.LastError = delivery.LastError
+	existing.DeliveredAt = delivery.DeliveredAt
+	m.deliveries[delivery.ID] = existing
+	return existing, nil
+}
+
+// ListDeliveries returns up to limit deliveries to a webhook, newest first
+func (m *Memory) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.webhooks[webhookID]; !ok {
+		return nil, ErrWebhookNotFound
+	}
+
+	var deliveries []WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
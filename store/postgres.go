@@ -0,0 +1,389 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const timeFormat = time.RFC3339
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	dataException       pq.ErrorCode = "22000"
+	foreignKeyViolation pq.ErrorCode = "23503"
+	uniqueViolation     pq.ErrorCode = "23505"
+	exclusionViolation  pq.ErrorCode = "23P01"
+)
+
+// translateError maps Postgres errors with the given codes onto store errors and
+// passes every other error through untouched
+func translateError(err error, translations map[pq.ErrorCode]error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		if translated, ok := translations[pqErr.Code]; ok {
+			return translated
+		}
+	}
+	return err
+}
+
+// translateConstraintError maps Postgres errors raised by the named constraints
+// onto store errors and passes every other error through untouched
+func translateConstraintError(err error, translations map[string]error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		if translated, ok := translations[pqErr.Constraint]; ok {
+			return translated
+		}
+	}
+	return err
+}
+
+// Postgres is a Store backed by a PostGIS enabled Postgres database
+type Postgres struct {
+	DB *sql.DB
+}
+
+var _ Store = (*Postgres)(nil)
+
+// NewPostgres returns a Store that runs its queries against db
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{DB: db}
+}
+
+// bookSelect selects the columns read by scanBook, taking the library of each
+// book's first copy. Conditions can be appended on the alias b (books).
+const bookSelect = `
+	SELECT
+		b.isbn, b.price, COALESCE(l.id, 0), COALESCE(l.name, ''),
+		COALESCE(ST_Y(l.geog::geometry), 0) as lat, COALESCE(ST_X(l.geog::geometry), 0) as lng
+	FROM books b
+	LEFT JOIN LATERAL (
+		SELECT libraries.* FROM copies
+		JOIN libraries ON libraries.id = copies.library_id
+		WHERE copies.isbn = b.isbn
+		ORDER BY copies.id
+		LIMIT 1
+	) l ON true
+`
+
+// AllBooks returns a page of books from the Postgres DB ordered by ISBN
+func (p *Postgres) AllBooks(ctx context.Context, after string, limit int) ([]Book, error) {
+	getAllBooksSQL := bookSelect + `
+		WHERE b.isbn > $1
+		ORDER BY b.isbn
+		LIMIT $2
+	`
+	rows, err := p.DB.QueryContext(ctx, getAllBooksSQL, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBooks(rows)
+}
+
+// GetBook returns a book with the matching ISBN
+func (p *Postgres) GetBook(ctx context.Context, isbn string) (Book, error) {
+	getBookSQL := bookSelect + `
+		WHERE b.isbn = $1
+	`
+	book, err := scanBook(p.DB.QueryRowContext(ctx, getBookSQL, isbn))
+	if err == sql.ErrNoRows {
+		return Book{}, ErrBookNotFound
+	}
+	if err != nil {
+		return Book{}, err
+	}
+
+	return book, nil
+}
+
+// AddBook adds a book to the database, along with its first copy if the library ID is set
+func (p *Postgres) AddBook(ctx context.Context, book Book) error {
+	return p.inTx(ctx, func(tx *sql.Tx) error {
+		addBookSQL := `
+			INSERT INTO books (isbn, price)
+			VALUES ($1, $2)
+		`
+		_, err := tx.ExecContext(ctx, addBookSQL, book.ISBN, book.Price)
+		if err != nil {
+			return translateError(err, map[pq.ErrorCode]error{uniqueViolation: ErrBookExists})
+		}
+
+		if book.LibraryID == 0 {
+			return nil
+		}
+		// The first copy is barcoded with the ISBN, as books were before they had copies
+		_, err = addCopy(ctx, tx, Copy{ISBN: book.ISBN, LibraryID: book.LibraryID, Barcode: book.ISBN})
+		return err
+	})
+}
+
+// errDryRun rolls back the transaction of a dry run import
+var errDryRun = errors.New("dry run")
+
+// ImportBooks upserts the books of the rows, and copies at their libraries, in one transaction
+func (p *Postgres) ImportBooks(ctx context.Context, rows []ImportRow, imported map[string]bool, dryRun bool) ([]ImportRow, error) {
+	rows = append([]ImportRow(nil), rows...)
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		for i := range rows {
+			if rows[i].Err != nil {
+				rows[i].Outcome = ImportRejected
+				continue
+			}
+
+			// Each row runs in a savepoint so that a rejected row doesn't abort the rest
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT import_row`); err != nil {
+				return err
+			}
+			outcome, err := importBook(ctx, tx, rows[i].Book, imported[rows[i].Book.ISBN])
+			switch err {
+			case nil:
+				rows[i].Outcome = outcome
+				imported[rows[i].Book.ISBN] = true
+				_, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT import_row`)
+			case ErrLibraryNotFound, ErrCopyExists:
+				rows[i].Outcome, rows[i].Err = ImportRejected, err
+				_, err = tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT import_row`)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// importBook adds a book or updates its price, and adds a copy at its library if it has none
+// there. seen is whether an earlier row of the import, in this transaction or an earlier
+// one, already added or updated the book.
+func importBook(ctx context.Context, tx *sql.Tx, book Book, seen bool) (ImportOutcome, error) {
+	// xmax is only set on rows that were updated rather than inserted, but stays unset
+	// when the update is in the transaction that inserted the row
+	upsertBookSQL := `
+		INSERT INTO books (isbn, price)
+		VALUES ($1, $2)
+		ON CONFLICT (isbn) DO UPDATE SET price = EXCLUDED.price
+		RETURNING xmax = 0
+	`
+	var created bool
+	if err := tx.QueryRowContext(ctx, upsertBookSQL, book.ISBN, book.Price).Scan(&created); err != nil {
+		return "", err
+	}
+	created = created && !seen
+	outcome := ImportUpdated
+	if created {
+		outcome = ImportCreated
+	}
+
+	if book.LibraryID == 0 {
+		return outcome, nil
+	}
+	copyExistsSQL := `
+		SELECT EXISTS (SELECT 1 FROM copies WHERE isbn = $1 AND library_id = $2)
+	`
+	var copyExists bool
+	if err := tx.QueryRowContext(ctx, copyExistsSQL, book.ISBN, book.LibraryID).Scan(&copyExists); err != nil {
+		return "", err
+	}
+	if copyExists {
+		return outcome, nil
+	}
+
+	barcode := book.ISBN
+	if !created {
+		barcode = fmt.Sprintf("%s-%d", book.ISBN, book.LibraryID)
+	}
+	_, err := addCopy(ctx, tx, Copy{ISBN: book.ISBN, LibraryID: book.LibraryID, Barcode: barcode})
+	return outcome, err
+}
+
+// DeleteBook deletes a book and its copies from the DB
+func (p *Postgres) DeleteBook(ctx context.Context, isbn string) error {
+	var result sql.Result
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		deleteCopiesSQL := `
+			DELETE FROM copies
+			WHERE isbn = $1
+		`
+		_, err := tx.ExecContext(ctx, deleteCopiesSQL, isbn)
+		if err != nil {
+			return translateError(err, map[pq.ErrorCode]error{foreignKeyViolation: ErrBookInUse})
+		}
+
+		deleteBookSQL := `
+			DELETE FROM books
+			WHERE isbn = $1
+		`
+		result, err = tx.ExecContext(ctx, deleteBookSQL, isbn)
+		return translateError(err, map[pq.ErrorCode]error{foreignKeyViolation: ErrBookInUse})
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+// SearchBooks returns the books at libraries within range of the coordinates with copies free
+// over the window, counting the free and total copies at each library
+func (p *Postgres) SearchBooks(ctx context.Context, query SearchQuery) ([]Book, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	point := fmt.Sprintf("ST_MakePoint(%s, %s)::geography", arg(query.Lng), arg(query.Lat))
+	distance := "ST_Distance(l.geog, " + point + ")"
+	conditions = append(conditions, fmt.Sprintf("ST_DWithin(l.geog, %s, %s)", point, arg(query.RangeMeters)))
+
+	// Unbounded ends of the window are passed as NULL, which tstzrange treats as infinite.
+	// Without a window, copies are free if no reservation holds them right now.
+	window := "tstzrange(now(), now(), '[]')"
+	windowed := !query.Start.IsZero() || !query.End.IsZero()
+	if windowed {
+		window = fmt.Sprintf("tstzrange(%s, %s)", arg(nullTime(query.Start)), arg(nullTime(query.End)))
+	}
+	if query.MinPrice > 0 {
+		conditions = append(conditions, "b.price >= "+arg(query.MinPrice))
+	}
+	if query.MaxPrice > 0 {
+		conditions = append(conditions, "b.price <= "+arg(query.MaxPrice))
+	}
+	if len(query.LibraryIDs) > 0 {
+		conditions = append(conditions, "l.id = ANY("+arg(pq.Array(query.LibraryIDs))+")")
+	}
+	if len(query.ISBNs) > 0 {
+		conditions = append(conditions, "b.isbn = ANY("+arg(pq.Array(query.ISBNs))+")")
+	}
+
+	sortKeys := []string{distance, "b.isbn", "l.id"}
+	switch query.Order {
+	case SearchByPrice:
+		sortKeys = []string{"b.price", distance, "b.isbn", "l.id"}
+	case SearchByLibraryName:
+		sortKeys = []string{"l.name", "b.isbn", "l.id"}
+	}
+	if after := query.After; after != nil {
+		// Every sort key is the same for all the rows of a group, so the cursor filters rows before they are grouped
+		cursor := map[string]interface{}{
+			distance:  after.DistanceMeters,
+			"b.price": after.Price,
+			"l.name":  after.Library,
+			"b.isbn":  after.ISBN,
+			"l.id":    after.LibraryID,
+		}
+		var values []string
+		for _, key := range sortKeys {
+			values = append(values, arg(cursor[key]))
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s) > (%s)", strings.Join(sortKeys, ", "), strings.Join(values, ", ")))
+	}
+
+	// A windowed search only returns the books with a copy free over the window
+	having := ""
+	if windowed {
+		having = "HAVING COUNT(*) FILTER (WHERE free) > 0"
+	}
+
+	searchBooksSQL := fmt.Sprintf(`
+	SELECT
+		b.isbn, b.price, l.id, l.name, ST_Y(l.geog::geometry) as lat, ST_X(l.geog::geometry) as lng,
+		COUNT(*) FILTER (WHERE free), COUNT(*), %s as distance
+	FROM copies c
+	JOIN books b ON b.isbn = c.isbn
+	JOIN libraries l ON l.id = c.library_id
+	CROSS JOIN LATERAL (
+		SELECT NOT EXISTS (
+			SELECT 1 FROM reservations r
+			WHERE r.copy_id = c.id AND r.duration && %s AND r.status IN ('reserved', 'checked_out')
+		) AS free
+	) f
+	WHERE %s
+	GROUP BY b.isbn, l.id
+	%s
+	ORDER BY %s
+	LIMIT %s
+	`, distance, window, strings.Join(conditions, " AND "), having, strings.Join(sortKeys, ", "), arg(query.Limit))
+
+	rows, err := p.DB.QueryContext(ctx, searchBooksSQL, args...)
+	if err != nil {
+		return nil, translateError(err, map[pq.ErrorCode]error{dataException: ErrInvalidRange})
+	}
+	defer rows.Close()
+
+	var books []Book
+	for rows.Next() {
+		var book Book
+		err := rows.Scan(&book.ISBN, &book.Price, &book.LibraryID, &book.Library, &book.Lat, &book.Lng, &book.AvailableCopies, &book.TotalCopies, &book.DistanceMeters)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// inTx runs f in a transaction, committing if it succeeds and rolling back otherwise
+func (p *Postgres) inTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func scanBooks(rows *sql.Rows) ([]Book, error) {
+	defer rows.Close()
+
+	var books []Book
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// scanBook scans a row selected by bookSelect
+func scanBook(row scanner) (Book, error) {
+	var book Book
+	err := row.Scan(&book.ISBN, &book.Price, &book.LibraryID, &book.Library, &book.Lat, &book.Lng)
+	return book, err
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// copySelect selects the columns read by scanCopy. Conditions can be appended
+// on the aliases c (copies) and l (libraries).
+const copySelect = `
+	SELECT c.id, c.isbn, c.library_id, c.location, c.barcode, l.name, ST_Y(l.geog::geometry), ST_X(l.geog::geometry)
+	FROM copies c
+	JOIN libraries l ON l.id = c.library_id
+`
+
+// AddCopy adds a new copy of an existing book
+func (p *Postgres) AddCopy(ctx context.Context, copy Copy) (Copy, error) {
+	return addCopy(ctx, p.DB, copy)
+}
+
+// GetCopy returns the copy with the matching ID
+func (p *Postgres) GetCopy(ctx context.Context, id int64) (Copy, error) {
+	return getCopy(ctx, p.DB, id)
+}
+
+// ListCopies returns the copies of a book ordered by ID
+func (p *Postgres) ListCopies(ctx context.Context, isbn string) ([]Copy, error) {
+	listCopiesSQL := copySelect + `
+		WHERE c.isbn = $1
+		ORDER BY c.id
+	`
+	rows, err := p.DB.QueryContext(ctx, listCopiesSQL, isbn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var copies []Copy
+	for rows.Next() {
+		copy, err := scanCopy(rows)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, copy)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Tell a book without copies apart from a book that doesn't exist
+	if len(copies) == 0 {
+		if _, err = p.GetBook(ctx, isbn); err != nil {
+			return nil, err
+		}
+	}
+	return copies, nil
+}
+
+// DeleteCopy deletes a copy that has never been reserved
+func (p *Postgres) DeleteCopy(ctx context.Context, id int64) error {
+	deleteCopySQL := `
+		DELETE FROM copies
+		WHERE id = $1
+	`
+	result, err := p.DB.ExecContext(ctx, deleteCopySQL, id)
+	if err != nil {
+		return translateError(err, map[pq.ErrorCode]error{foreignKeyViolation: ErrCopyInUse})
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrCopyNotFound
+	}
+	return nil
+}
+
+func addCopy(ctx context.Context, q queryer, copy Copy) (Copy, error) {
+	addCopySQL := `
+		INSERT INTO copies (isbn, library_id, location, barcode)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	err := q.QueryRowContext(ctx, addCopySQL, copy.ISBN, copy.LibraryID, copy.Location, copy.Barcode).Scan(&copy.ID)
+	if err != nil {
+		return Copy{}, translateConstraintError(err, map[string]error{
+			"copies_isbn_fkey":       ErrBookNotFound,
+			"copies_library_id_fkey": ErrLibraryNotFound,
+			"copies_barcode_key":     ErrCopyExists,
+		})
+	}
+
+	return getCopy(ctx, q, copy.ID)
+}
+
+func getCopy(ctx context.Context, q queryer, id int64) (Copy, error) {
+	getCopySQL := copySelect + `
+		WHERE c.id = $1
+	`
+	copy, err := scanCopy(q.QueryRowContext(ctx, getCopySQL, id))
+	if err == sql.ErrNoRows {
+		return Copy{}, ErrCopyNotFound
+	}
+	return copy, err
+}
+
+func scanCopy(row scanner) (Copy, error) {
+	var copy Copy
+	err := row.Scan(&copy.ID, &copy.ISBN, &copy.LibraryID, &copy.Location, &copy.Barcode, &copy.Library, &copy.Lat, &copy.Lng)
+	return copy, err
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// holdSelect selects the columns read by scanHold, numbering waiting holds by their
+// place in the queue. Conditions can be appended on the alias h (holds).
+const holdSelect = `
+	SELECT
+		h.id, h.isbn, h.patron_id, COALESCE(h.library_id, 0), h.days, h.status,
+		COALESCE(h.reservation_id, 0), h.created_at,
+		CASE WHEN h.status = 'waiting' THEN (
+			SELECT COUNT(*) FROM holds q
+			WHERE q.isbn = h.isbn AND q.status = 'waiting' AND q.id <= h.id
+		) ELSE 0 END
+	FROM holds h
+`
+
+// PlaceHold adds a patron to the back of the hold queue of a book
+func (p *Postgres) PlaceHold(ctx context.Context, hold Hold) (Hold, error) {
+	placeHoldSQL := `
+		INSERT INTO holds (isbn, patron_id, library_id, days)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	err := p.DB.QueryRowContext(ctx, placeHoldSQL, hold.ISBN, hold.PatronID, nullID(hold.LibraryID), hold.Days).Scan(&hold.ID)
+	if err != nil {
+		return Hold{}, translateConstraintError(err, map[string]error{
+			"holds_isbn_fkey":       ErrBookNotFound,
+			"holds_patron_id_fkey":  ErrPatronNotFound,
+			"holds_library_id_fkey": ErrLibraryNotFound,
+			"holds_waiting_key":     ErrHoldExists,
+		})
+	}
+
+	return getHold(ctx, p.DB, hold.ID)
+}
+
+// GetHold returns the hold with the matching ID
+func (p *Postgres) GetHold(ctx context.Context, id int64) (Hold, error) {
+	return getHold(ctx, p.DB, id)
+}
+
+// ListHolds returns the waiting holds of a book in queue order
+func (p *Postgres) ListHolds(ctx context.Context, isbn string) ([]Hold, error) {
+	var bookExists bool
+	err := p.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM books WHERE isbn = $1)`, isbn).Scan(&bookExists)
+	if err != nil {
+		return nil, err
+	}
+	if !bookExists {
+		return nil, ErrBookNotFound
+	}
+
+	listHoldsSQL := holdSelect + `
+		WHERE h.isbn = $1 AND h.status = 'waiting'
+		ORDER BY h.id
+	`
+	rows, err := p.DB.QueryContext(ctx, listHoldsSQL, isbn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []Hold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// HeldBooks returns the ISBNs of the books with waiting holds, in order
+func (p *Postgres) HeldBooks(ctx context.Context) ([]string, error) {
+	heldBooksSQL := `
+		SELECT DISTINCT isbn FROM holds
+		WHERE status = 'waiting'
+		ORDER BY isbn
+	`
+	rows, err := p.DB.QueryContext(ctx, heldBooksSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var isbns []string
+	for rows.Next() {
+		var isbn string
+		if err = rows.Scan(&isbn); err != nil {
+			return nil, err
+		}
+		isbns = append(isbns, isbn)
+	}
+	return isbns, rows.Err()
+}
+
+// CancelHold takes a waiting hold out of its queue
+func (p *Postgres) CancelHold(ctx context.Context, id int64) (Hold, error) {
+	var hold Hold
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		if err := lockWaitingHold(ctx, tx, id); err != nil {
+			return err
+		}
+
+		if err := setHoldStatus(ctx, tx, id, HoldCancelled, 0); err != nil {
+			return err
+		}
+
+		var err error
+		hold, err = getHold(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return Hold{}, err
+	}
+
+	return hold, nil
+}
+
+// FulfillHold makes the reservation and marks the waiting hold as fulfilled by it
+func (p *Postgres) FulfillHold(ctx context.Context, id int64, reservation Reservation) (Hold, Reservation, error) {
+	var hold Hold
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		// Locking the hold keeps concurrent promotions from fulfilling it twice
+		if err := lockWaitingHold(ctx, tx, id); err != nil {
+			return err
+		}
+
+		var err error
+		reservation, err = reserve(ctx, tx, reservation)
+		if err != nil {
+			return err
+		}
+
+		if err = setHoldStatus(ctx, tx, id, HoldFulfilled, reservation.ID); err != nil {
+			return err
+		}
+
+		hold, err = getHold(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return Hold{}, Reservation{}, err
+	}
+
+	return hold, reservation, nil
+}
+
+// lockWaitingHold locks a hold for the rest of the transaction, failing unless it is waiting
+func lockWaitingHold(ctx context.Context, tx *sql.Tx, id int64) error {
+	lockHoldSQL := `
+		SELECT status FROM holds
+		WHERE id = $1
+		FOR UPDATE
+	`
+	var status HoldStatus
+	err := tx.QueryRowContext(ctx, lockHoldSQL, id).Scan(&status)
+	switch {
+	case err == sql.ErrNoRows:
+		return ErrHoldNotFound
+	case err != nil:
+		return err
+	case status != HoldWaiting:
+		return ErrHoldClosed
+	}
+	return nil
+}
+
+func setHoldStatus(ctx context.Context, tx *sql.Tx, id int64, status HoldStatus, reservationID int64) error {
+	setHoldStatusSQL := `
+		UPDATE holds
+		SET status = $2, reservation_id = $3
+		WHERE id = $1
+	`
+	_, err := tx.ExecContext(ctx, setHoldStatusSQL, id, status, nullID(reservationID))
+	return err
+}
+
+func getHold(ctx context.Context, q queryer, id int64) (Hold, error) {
+	getHoldSQL := holdSelect + `
+		WHERE h.id = $1
+	`
+	hold, err := scanHold(q.QueryRowContext(ctx, getHoldSQL, id))
+	if err == sql.ErrNoRows {
+		return Hold{}, ErrHoldNotFound
+	}
+	return hold, err
+}
+
+func scanHold(row scanner) (Hold, error) {
+	var hold Hold
+	err := row.Scan(&hold.ID, &hold.ISBN, &hold.PatronID, &hold.LibraryID, &hold.Days, &hold.Status,
+		&hold.ReservationID, &hold.CreatedAt, &hold.Position)
+	return hold, err
+}
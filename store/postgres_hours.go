@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// dateFormat is the format of Postgres DATE values
+const dateFormat = "2006-01-02"
+
+// GetOpeningHours returns the weekly opening hours of a library ordered by weekday and time
+func (p *Postgres) GetOpeningHours(ctx context.Context, libraryID int64) ([]OpeningHours, error) {
+	var hours []OpeningHours
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		if err := lockLibrary(ctx, tx, libraryID, false); err != nil {
+			return err
+		}
+
+		var err error
+		hours, err = getOpeningHours(ctx, tx, libraryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// SetOpeningHours replaces the weekly opening hours of a library
+func (p *Postgres) SetOpeningHours(ctx context.Context, libraryID int64, hours []OpeningHours) ([]OpeningHours, error) {
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		// Lock the library so that concurrent updates don't interleave their periods
+		if err := lockLibrary(ctx, tx, libraryID, true); err != nil {
+			return err
+		}
+
+		deleteOpeningHoursSQL := `
+			DELETE FROM opening_hours
+			WHERE library_id = $1
+		`
+		if _, err := tx.ExecContext(ctx, deleteOpeningHoursSQL, libraryID); err != nil {
+			return err
+		}
+
+		addOpeningHoursSQL := `
+			INSERT INTO opening_hours (library_id, weekday, opens, closes)
+			VALUES ($1, $2, $3, $4)
+		`
+		for _, period := range hours {
+			_, err := tx.ExecContext(ctx, addOpeningHoursSQL, libraryID, int(period.Weekday), period.Opens.String(), period.Closes.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		hours, err = getOpeningHours(ctx, tx, libraryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// ListHoursExceptions returns the opening hours exceptions of a library dated from from to to inclusive
+func (p *Postgres) ListHoursExceptions(ctx context.Context, libraryID int64, from, to time.Time) ([]HoursException, error) {
+	var exceptions []HoursException
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		if err := lockLibrary(ctx, tx, libraryID, false); err != nil {
+			return err
+		}
+
+		listHoursExceptionsSQL := `
+			SELECT id, library_id, date, opens IS NULL, COALESCE(to_char(opens, 'HH24:MI'), ''), COALESCE(to_char(closes, 'HH24:MI'), ''), reason
+			FROM hours_exceptions
+			WHERE
+				library_id = $1
+				AND ($2::date IS NULL OR date >= $2)
+				AND ($3::date IS NULL OR date <= $3)
+			ORDER BY date
+		`
+		rows, err := tx.QueryContext(ctx, listHoursExceptionsSQL, libraryID, nullDate(from), nullDate(to))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			exception, err := scanHoursException(rows)
+			if err != nil {
+				return err
+			}
+			exceptions = append(exceptions, exception)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return exceptions, nil
+}
+
+// AddHoursException adds an opening hours exception to a library
+func (p *Postgres) AddHoursException(ctx context.Context, exception HoursException) (HoursException, error) {
+	addHoursExceptionSQL := `
+		INSERT INTO hours_exceptions (library_id, date, opens, closes, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	var opens, closes interface{}
+	if !exception.Closed {
+		opens, closes = exception.Opens.String(), exception.Closes.String()
+	}
+	err := p.DB.QueryRowContext(ctx, addHoursExceptionSQL, exception.LibraryID, exception.Date.Format(dateFormat), opens, closes, exception.Reason).
+		Scan(&exception.ID)
+	if err != nil {
+		return HoursException{}, translateConstraintError(err, map[string]error{
+			"hours_exceptions_library_id_fkey":     ErrLibraryNotFound,
+			"hours_exceptions_library_id_date_key": ErrHoursExceptionExists,
+		})
+	}
+
+	return exception, nil
+}
+
+// DeleteHoursException deletes an opening hours exception of a library
+func (p *Postgres) DeleteHoursException(ctx context.Context, libraryID, id int64) error {
+	deleteHoursExceptionSQL := `
+		DELETE FROM hours_exceptions
+		WHERE id = $1 AND library_id = $2
+	`
+	result, err := p.DB.ExecContext(ctx, deleteHoursExceptionSQL, id, libraryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrHoursExceptionNotFound
+	}
+	return nil
+}
+
+// lockLibrary fails with ErrLibraryNotFound unless the library exists, locking
+// it for the rest of the transaction if forUpdate is set
+func lockLibrary(ctx context.Context, tx *sql.Tx, id int64, forUpdate bool) error {
+	lockLibrarySQL := `
+		SELECT id FROM libraries
+		WHERE id = $1
+	`
+	if forUpdate {
+		lockLibrarySQL += "FOR UPDATE"
+	}
+
+	err := tx.QueryRowContext(ctx, lockLibrarySQL, id).Scan(&id)
+	if err == sql.ErrNoRows {
+		return ErrLibraryNotFound
+	}
+	return err
+}
+
+func getOpeningHours(ctx context.Context, tx *sql.Tx, libraryID int64) ([]OpeningHours, error) {
+	getOpeningHoursSQL := `
+		SELECT weekday, to_char(opens, 'HH24:MI'), to_char(closes, 'HH24:MI')
+		FROM opening_hours
+		WHERE library_id = $1
+		ORDER BY weekday, opens
+	`
+	rows, err := tx.QueryContext(ctx, getOpeningHoursSQL, libraryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hours []OpeningHours
+	for rows.Next() {
+		var (
+			period        OpeningHours
+			opens, closes string
+		)
+		if err = rows.Scan(&period.Weekday, &opens, &closes); err != nil {
+			return nil, err
+		}
+		if period.Opens, err = ParseTimeOfDay(opens); err != nil {
+			return nil, err
+		}
+		if period.Closes, err = ParseTimeOfDay(closes); err != nil {
+			return nil, err
+		}
+		hours = append(hours, period)
+	}
+
+	return hours, rows.Err()
+}
+
+func scanHoursException(row scanner) (HoursException, error) {
+	var (
+		exception     HoursException
+		opens, closes string
+	)
+	err := row.Scan(&exception.ID, &exception.LibraryID, &exception.Date, &exception.Closed, &opens, &closes, &exception.Reason)
+	// lib/pq reads DATE columns in an unnamed zone rather than UTC, unlike the memory store
+	exception.Date = truncateDate(exception.Date)
+	if err != nil || exception.Closed {
+		return exception, err
+	}
+
+	if exception.Opens, err = ParseTimeOfDay(opens); err != nil {
+		return HoursException{}, err
+	}
+	exception.Closes, err = ParseTimeOfDay(closes)
+	return exception, err
+}
+
+// nullDate passes the zero time to Postgres as NULL
+func nullDate(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(dateFormat)
+}
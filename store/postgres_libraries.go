@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// librarySelect selects the columns read by scanLibrary
+const librarySelect = `
+	SELECT
+		id, name, address, ST_Y(geog::geometry), ST_X(geog::geometry), timezone, email, phone,
+		late_fee_per_day, late_fee_percent_per_day, late_fee_max, max_renewals, max_loan_days, created_at
+	FROM libraries
+`
+
+// CreateLibrary adds a new library
+func (p *Postgres) CreateLibrary(ctx context.Context, library Library) (Library, error) {
+	createLibrarySQL := `
+		INSERT INTO libraries (
+			name, address, geog, timezone, email, phone,
+			late_fee_per_day, late_fee_percent_per_day, late_fee_max, max_renewals, max_loan_days
+		)
+		VALUES ($1, $2, ST_MakePoint($3, $4), $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at
+	`
+	fees := library.LateFees
+	err := p.DB.QueryRowContext(ctx, createLibrarySQL, library.Name, library.Address, library.Lng, library.Lat, library.Timezone, library.Email, library.Phone,
+		fees.PerDay, fees.PercentPerDay, fees.Max, library.MaxRenewals, library.MaxLoanDays).
+		Scan(&library.ID, &library.CreatedAt)
+	if err != nil {
+		return Library{}, translateConstraintError(err, map[string]error{"libraries_name_key": ErrLibraryExists})
+	}
+
+	return library, nil
+}
+
+// GetLibrary returns the library with the matching ID
+func (p *Postgres) GetLibrary(ctx context.Context, id int64) (Library, error) {
+	getLibrarySQL := librarySelect + `
+		WHERE id = $1
+	`
+	library, err := scanLibrary(p.DB.QueryRowContext(ctx, getLibrarySQL, id))
+	if err == sql.ErrNoRows {
+		return Library{}, ErrLibraryNotFound
+	}
+	return library, err
+}
+
+// ListLibraries returns every library ordered by name
+func (p *Postgres) ListLibraries(ctx context.Context) ([]Library, error) {
+	listLibrariesSQL := librarySelect + `
+		ORDER BY name
+	`
+	rows, err := p.DB.QueryContext(ctx, listLibrariesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var libraries []Library
+	for rows.Next() {
+		library, err := scanLibrary(rows)
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, library)
+	}
+
+	return libraries, rows.Err()
+}
+
+// UpdateLibrary replaces every field of an existing library but its ID and creation time
+func (p *Postgres) UpdateLibrary(ctx context.Context, library Library) (Library, error) {
+	updateLibrarySQL := `
+		UPDATE libraries
+		SET
+			name = $2, address = $3, geog = ST_MakePoint($4, $5), timezone = $6, email = $7, phone = $8,
+			late_fee_per_day = $9, late_fee_percent_per_day = $10, late_fee_max = $11, max_renewals = $12, max_loan_days = $13
+		WHERE id = $1
+		RETURNING created_at
+	`
+	fees := library.LateFees
+	err := p.DB.QueryRowContext(ctx, updateLibrarySQL, library.ID, library.Name, library.Address, library.Lng, library.Lat, library.Timezone, library.Email, library.Phone,
+		fees.PerDay, fees.PercentPerDay, fees.Max, library.MaxRenewals, library.MaxLoanDays).
+		Scan(&library.CreatedAt)
+	if err == sql.ErrNoRows {
+		return Library{}, ErrLibraryNotFound
+	}
+	if err != nil {
+		return Library{}, translateConstraintError(err, map[string]error{"libraries_name_key": ErrLibraryExists})
+	}
+
+	return library, nil
+}
+
+// DeleteLibrary deletes a library that holds no copies
+func (p *Postgres) DeleteLibrary(ctx context.Context, id int64) error {
+	deleteLibrarySQL := `
+		DELETE FROM libraries
+		WHERE id = $1
+	`
+	result, err := p.DB.ExecContext(ctx, deleteLibrarySQL, id)
+	if err != nil {
+		return translateError(err, map[pq.ErrorCode]error{foreignKeyViolation: ErrLibraryInUse})
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrLibraryNotFound
+	}
+	return nil
+}
+
+func scanLibrary(row scanner) (Library, error) {
+	var library Library
+	err := row.Scan(
+		&library.ID, &library.Name, &library.Address, &library.Lat, &library.Lng, &library.Timezone, &library.Email, &library.Phone,
+		&library.LateFees.PerDay, &library.LateFees.PercentPerDay, &library.LateFees.Max,
+		&library.MaxRenewals, &library.MaxLoanDays, &library.CreatedAt,
+	)
+	return library, err
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// patronColumns are the columns read by scanPatron, including the balance of the patron's fees
+const patronColumns = `
+	id, name, email, phone, (SELECT COALESCE(SUM(amount), 0) FROM fees WHERE fees.patron_id = patrons.id), created_at
+`
+
+// CreatePatron adds a new patron
+func (p *Postgres) CreatePatron(ctx context.Context, patron Patron) (Patron, error) {
+	createPatronSQL := `
+		INSERT INTO patrons (name, email, phone)
+		VALUES ($1, $2, $3)
+		RETURNING` + patronColumns
+	created, err := scanPatron(p.DB.QueryRowContext(ctx, createPatronSQL, patron.Name, patron.Email, patron.Phone))
+	if err != nil {
+		return Patron{}, translateConstraintError(err, map[string]error{"patrons_email_key": ErrPatronExists})
+	}
+
+	return created, nil
+}
+
+// GetPatron returns the patron with the matching ID
+func (p *Postgres) GetPatron(ctx context.Context, id int64) (Patron, error) {
+	getPatronSQL := `SELECT` + patronColumns + `
+		FROM patrons
+		WHERE id = $1
+	`
+	patron, err := scanPatron(p.DB.QueryRowContext(ctx, getPatronSQL, id))
+	if err == sql.ErrNoRows {
+		return Patron{}, ErrPatronNotFound
+	}
+	return patron, err
+}
+
+// UpdatePatron replaces the name, email and phone of an existing patron
+func (p *Postgres) UpdatePatron(ctx context.Context, patron Patron) (Patron, error) {
+	updatePatronSQL := `
+		UPDATE patrons
+		SET name = $2, email = $3, phone = $4
+		WHERE id = $1
+		RETURNING` + patronColumns
+	updated, err := scanPatron(p.DB.QueryRowContext(ctx, updatePatronSQL, patron.ID, patron.Name, patron.Email, patron.Phone))
+	if err == sql.ErrNoRows {
+		return Patron{}, ErrPatronNotFound
+	}
+	if err != nil {
+		return Patron{}, translateConstraintError(err, map[string]error{"patrons_email_key": ErrPatronExists})
+	}
+
+	return updated, nil
+}
+
+// ListFees returns the fees charged to a patron, newest first
+func (p *Postgres) ListFees(ctx context.Context, patronID int64) ([]Fee, error) {
+	listFeesSQL := `
+		SELECT id, patron_id, COALESCE(reservation_id, 0), amount, days_late, created_at
+		FROM fees
+		WHERE patron_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := p.DB.QueryContext(ctx, listFeesSQL, patronID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fees []Fee
+	for rows.Next() {
+		var fee Fee
+		err = rows.Scan(&fee.ID, &fee.PatronID, &fee.ReservationID, &fee.Amount, &fee.DaysLate, &fee.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, rows.Err()
+}
+
+func scanPatron(row scanner) (Patron, error) {
+	var patron Patron
+	err := row.Scan(&patron.ID, &patron.Name, &patron.Email, &patron.Phone, &patron.Balance, &patron.CreatedAt)
+	return patron, err
+}
@@ -0,0 +1,625 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// reservationSelect selects the columns read by scanReservation. Conditions can be appended
+// on the aliases r (reservations), cp (copies), l (libraries) and c (checked_out).
+const reservationSelect = `
+	SELECT
+		r.id, r.isbn, r.copy_id, COALESCE(r.patron_id, 0), lower(r.duration), upper(r.duration), r.status, r.created_at,
+		cp.library_id, l.name, c.checked_out_at, COALESCE(c.patron_id, 0), c.overdue_at, r.returned_at,
+		r.renewals, COALESCE(r.series_id, 0)
+	FROM reservations r
+	JOIN copies cp ON cp.id = r.copy_id
+	JOIN libraries l ON l.id = cp.library_id
+	LEFT JOIN checked_out c ON c.reservation_id = r.id
+`
+
+// Reserve reserves a copy of a book for a specified amount of time
+func (p *Postgres) Reserve(ctx context.Context, reservation Reservation) (Reservation, error) {
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		reservation, err = reserve(ctx, tx, reservation)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// reserve makes a reservation within the transaction
+func reserve(ctx context.Context, tx *sql.Tx, reservation Reservation) (Reservation, error) {
+	isbn, start, end := reservation.ISBN, reservation.Start, reservation.End
+
+	// First find the requested copy, or the first copy, that is free over the window
+	findFreeCopySQL := `
+		SELECT c.id FROM copies c
+		WHERE
+			c.isbn = $1
+			AND ($4 = 0 OR c.id = $4)
+			AND ($5 = 0 OR c.library_id = $5)
+			AND NOT EXISTS (
+				SELECT 1 FROM reservations r
+				WHERE r.copy_id = c.id AND r.duration && tstzrange($2, $3) AND r.status IN ('reserved', 'checked_out')
+			)
+		ORDER BY c.id
+		LIMIT 1
+	`
+	var copyID int64
+	err := tx.QueryRowContext(ctx, findFreeCopySQL, isbn, start.Format(timeFormat), end.Format(timeFormat), reservation.CopyID, reservation.LibraryID).Scan(&copyID)
+	if err == sql.ErrNoRows {
+		return Reservation{}, noFreeCopyError(ctx, tx, isbn, reservation.CopyID, reservation.LibraryID)
+	}
+	if err != nil {
+		return Reservation{}, translateError(err, map[pq.ErrorCode]error{dataException: ErrInvalidRange})
+	}
+
+	// If the copy is free, make the reservation
+	reserveBookSQL := `
+		INSERT INTO reservations (isbn, copy_id, duration, patron_id, series_id)
+		VALUES ($1, $2, tstzrange($3, $4), $5, $6)
+		RETURNING id
+	`
+	var id int64
+	err = tx.QueryRowContext(ctx, reserveBookSQL, isbn, copyID, start.Format(timeFormat), end.Format(timeFormat),
+		nullID(reservation.PatronID), nullID(reservation.SeriesID)).Scan(&id)
+	if err != nil {
+		// The exclusion constraint catches reservations that raced past the check above
+		return Reservation{}, translateConstraintError(err, map[string]error{
+			"reservations_copy_id_duration_excl": ErrOverlap,
+			"reservations_patron_id_fkey":        ErrPatronNotFound,
+		})
+	}
+
+	return getReservation(ctx, tx, id)
+}
+
+// noFreeCopyError explains why no copy of a book was free: the book or the copy
+// doesn't exist, or every matching copy is reserved
+func noFreeCopyError(ctx context.Context, tx *sql.Tx, isbn string, copyID, libraryID int64) error {
+	countCopiesSQL := `
+		SELECT
+			EXISTS (SELECT 1 FROM books WHERE isbn = $1),
+			EXISTS (SELECT 1 FROM copies WHERE isbn = $1 AND ($2 = 0 OR id = $2) AND ($3 = 0 OR library_id = $3))
+	`
+	var bookExists, copyExists bool
+	err := tx.QueryRowContext(ctx, countCopiesSQL, isbn, copyID, libraryID).Scan(&bookExists, &copyExists)
+	switch {
+	case err != nil:
+		return err
+	case !bookExists:
+		return ErrBookNotFound
+	case !copyExists:
+		return ErrCopyNotFound
+	}
+	return ErrOverlap
+}
+
+// GetReservation returns the reservation with the matching ID
+func (p *Postgres) GetReservation(ctx context.Context, id int64) (Reservation, error) {
+	return getReservation(ctx, p.DB, id)
+}
+
+// FindReservation returns the reservation of a copy of a book over exactly the given window
+func (p *Postgres) FindReservation(ctx context.Context, isbn string, copyID int64, start, end time.Time) (Reservation, error) {
+	findReservationSQL := reservationSelect + `
+		WHERE
+			r.isbn = $1
+			AND ($4 = 0 OR r.copy_id = $4)
+			AND r.duration = tstzrange($2, $3)
+			AND r.status <> 'cancelled'
+		LIMIT 2
+	`
+	rows, err := p.DB.QueryContext(ctx, findReservationSQL, isbn, start.Format(timeFormat), end.Format(timeFormat), copyID)
+	if err != nil {
+		return Reservation{}, translateError(err, map[pq.ErrorCode]error{dataException: ErrInvalidRange})
+	}
+	defer rows.Close()
+
+	var reservations []Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return Reservation{}, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	if err = rows.Err(); err != nil {
+		return Reservation{}, err
+	}
+
+	switch len(reservations) {
+	case 0:
+		return Reservation{}, ErrReservationNotFound
+	case 1:
+		return reservations[0], nil
+	}
+	return Reservation{}, ErrCopyRequired
+}
+
+// ListReservations returns the reservations matching the query in the requested order
+func (p *Postgres) ListReservations(ctx context.Context, query ListReservationsQuery) ([]Reservation, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.ISBN != "" {
+		conditions = append(conditions, "r.isbn = "+arg(query.ISBN))
+	}
+	if query.Library != "" {
+		conditions = append(conditions, "l.name = "+arg(query.Library))
+	}
+	if query.LibraryID != 0 {
+		conditions = append(conditions, "cp.library_id = "+arg(query.LibraryID))
+	}
+	if query.PatronID != 0 {
+		conditions = append(conditions, "r.patron_id = "+arg(query.PatronID))
+	}
+	if query.SeriesID != 0 {
+		conditions = append(conditions, "r.series_id = "+arg(query.SeriesID))
+	}
+	if !query.Start.IsZero() || !query.End.IsZero() {
+		// Unbounded ends of the window are passed as NULL, which tstzrange treats as infinite
+		conditions = append(conditions, fmt.Sprintf("r.duration && tstzrange(%s, %s)", arg(nullTime(query.Start)), arg(nullTime(query.End))))
+	}
+	if len(query.Statuses) > 0 {
+		var statuses []string
+		for _, status := range query.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "r.status = ANY("+arg(pq.Array(statuses))+")")
+	}
+	if !query.EndBefore.IsZero() {
+		conditions = append(conditions, "upper(r.duration) < "+arg(query.EndBefore))
+	}
+
+	sortKey, direction, comparison := "lower(r.duration)", "ASC", ">"
+	switch query.Order {
+	case OrderByStartDesc:
+		direction, comparison = "DESC", "<"
+	case OrderByCreated:
+		sortKey = "r.created_at"
+	case OrderByCreatedDesc:
+		sortKey, direction, comparison = "r.created_at", "DESC", "<"
+	}
+	if query.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, r.id) %s (%s, %s)", sortKey, comparison, arg(query.After.Time), arg(query.After.ID)))
+	}
+
+	listReservationsSQL := reservationSelect
+	if len(conditions) > 0 {
+		listReservationsSQL += "WHERE " + strings.Join(conditions, " AND ")
+	}
+	listReservationsSQL += fmt.Sprintf(" ORDER BY %s %s, r.id %s LIMIT %s", sortKey, direction, direction, arg(query.Limit))
+
+	rows, err := p.DB.QueryContext(ctx, listReservationsSQL, args...)
+	if err != nil {
+		return nil, translateError(err, map[pq.ErrorCode]error{dataException: ErrInvalidRange})
+	}
+	defer rows.Close()
+
+	var reservations []Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+// Checkout populates the checked_out table to signify a reservation has been 'checked out'
+func (p *Postgres) Checkout(ctx context.Context, reservationID, patronID int64) (Reservation, error) {
+	var reservation Reservation
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		reservation, err = lockActiveReservation(ctx, tx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		if patronID == 0 {
+			patronID = reservation.PatronID
+		}
+
+		checkoutBookSQL := `
+			INSERT INTO checked_out (isbn, copy_id, reservation_id, patron_id)
+			VALUES ($1, $2, $3, $4)
+		`
+		// Will not allow checking out a copy that already exists in the table
+		_, err = tx.ExecContext(ctx, checkoutBookSQL, reservation.ISBN, reservation.CopyID, reservation.ID, nullID(patronID))
+		if err != nil {
+			return translateConstraintError(err, map[string]error{
+				"checked_out_copy_id_key":    ErrAlreadyCheckedOut,
+				"checked_out_patron_id_fkey": ErrPatronNotFound,
+			})
+		}
+
+		err = setReservationStatus(ctx, tx, reservation.ID, StatusCheckedOut)
+		if err != nil {
+			return err
+		}
+
+		reservation, err = getReservation(ctx, tx, reservation.ID)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// CancelReservation cancels a reservation that hasn't been checked out
+func (p *Postgres) CancelReservation(ctx context.Context, id int64) (Reservation, error) {
+	var reservation Reservation
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		reservation, err = lockActiveReservation(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		reservation.Status = StatusCancelled
+		return setReservationStatus(ctx, tx, reservation.ID, reservation.Status)
+	})
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// RescheduleReservation moves a reservation that hasn't been checked out to a new window
+func (p *Postgres) RescheduleReservation(ctx context.Context, id int64, start, end time.Time) (Reservation, error) {
+	var reservation Reservation
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := lockActiveReservation(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// The exclusion constraint rejects the new window if it overlaps any other
+		// reservation, in which case the transaction rolls back to the old window
+		rescheduleSQL := `
+			UPDATE reservations
+			SET duration = tstzrange($2, $3)
+			WHERE id = $1
+		`
+		_, err = tx.ExecContext(ctx, rescheduleSQL, id, start.Format(timeFormat), end.Format(timeFormat))
+		if err != nil {
+			return translateError(err, map[pq.ErrorCode]error{
+				exclusionViolation: ErrOverlap,
+				dataException:      ErrInvalidRange,
+			})
+		}
+
+		reservation, err = getReservation(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Return returns a previously checked out copy of a book, charging a late fee if it is overdue
+func (p *Postgres) Return(ctx context.Context, isbn string, copyID int64) (Reservation, Fee, error) {
+	var (
+		reservation Reservation
+		fee         Fee
+	)
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		findCheckoutSQL := `
+			SELECT copy_id, reservation_id FROM checked_out
+			WHERE isbn = $1 AND ($2 = 0 OR copy_id = $2)
+			LIMIT 2
+			FOR UPDATE
+		`
+		rows, err := tx.QueryContext(ctx, findCheckoutSQL, isbn, copyID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var copyIDs, reservationIDs []int64
+		for rows.Next() {
+			var copyID, reservationID int64
+			if err = rows.Scan(&copyID, &reservationID); err != nil {
+				return err
+			}
+			copyIDs = append(copyIDs, copyID)
+			reservationIDs = append(reservationIDs, reservationID)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		switch len(copyIDs) {
+		case 0:
+			return ErrNotCheckedOut
+		case 2:
+			return ErrCopyRequired
+		}
+
+		// Read the checkout before it's deleted to know who to charge
+		checkout, err := getReservation(ctx, tx, reservationIDs[0])
+		if err != nil {
+			return err
+		}
+
+		returnBookSQL := `
+			DELETE FROM checked_out
+			WHERE copy_id = $1
+		`
+		if _, err = tx.ExecContext(ctx, returnBookSQL, copyIDs[0]); err != nil {
+			return err
+		}
+
+		markReturnedSQL := `
+			UPDATE reservations
+			SET status = 'returned', returned_at = now()
+			WHERE id = $1
+		`
+		if _, err = tx.ExecContext(ctx, markReturnedSQL, checkout.ID); err != nil {
+			return err
+		}
+
+		reservation, err = getReservation(ctx, tx, checkout.ID)
+		if err != nil {
+			return err
+		}
+
+		fee, err = chargeLateFee(ctx, tx, checkout, reservation.ReturnedAt)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, Fee{}, err
+	}
+
+	return reservation, fee, nil
+}
+
+// chargeLateFee charges the patron who checked out a book returned at returned the late
+// fee of the copy's library, if any
+func chargeLateFee(ctx context.Context, tx *sql.Tx, checkout Reservation, returned time.Time) (Fee, error) {
+	patronID := checkout.CheckedOutBy
+	if patronID == 0 {
+		patronID = checkout.PatronID
+	}
+	if patronID == 0 {
+		return Fee{}, nil
+	}
+
+	lateFeeSQL := `
+		SELECT b.price, l.late_fee_per_day, l.late_fee_percent_per_day, l.late_fee_max
+		FROM copies cp
+		JOIN books b ON b.isbn = cp.isbn
+		JOIN libraries l ON l.id = cp.library_id
+		WHERE cp.id = $1
+	`
+	var (
+		price  float64
+		policy LateFeePolicy
+	)
+	err := tx.QueryRowContext(ctx, lateFeeSQL, checkout.CopyID).Scan(&price, &policy.PerDay, &policy.PercentPerDay, &policy.Max)
+	if err != nil {
+		return Fee{}, err
+	}
+
+	fee := Fee{PatronID: patronID, ReservationID: checkout.ID}
+	fee.Amount, fee.DaysLate = policy.Fee(price, checkout.End, returned)
+	if fee.Amount == 0 {
+		return Fee{}, nil
+	}
+
+	chargeFeeSQL := `
+		INSERT INTO fees (patron_id, reservation_id, amount, days_late)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowContext(ctx, chargeFeeSQL, fee.PatronID, fee.ReservationID, fee.Amount, fee.DaysLate).Scan(&fee.ID, &fee.CreatedAt)
+	return fee, err
+}
+
+// Renew extends a checked out reservation to end, if its library allows it
+func (p *Postgres) Renew(ctx context.Context, id int64, end time.Time) (Reservation, error) {
+	var reservation Reservation
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		lockReservationSQL := reservationSelect + `
+			WHERE r.id = $1
+			FOR UPDATE OF r
+		`
+		var err error
+		reservation, err = scanReservation(tx.QueryRowContext(ctx, lockReservationSQL, id))
+		if err == sql.ErrNoRows {
+			return ErrReservationNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		switch reservation.Status {
+		case StatusReserved:
+			return ErrNotCheckedOut
+		case StatusReturned, StatusCancelled:
+			return ErrReservationClosed
+		}
+
+		library, err := scanLibrary(tx.QueryRowContext(ctx, librarySelect+"WHERE id = $1", reservation.LibraryID))
+		if err != nil {
+			return err
+		}
+		if err = checkRenewal(library, reservation, end, time.Now()); err != nil {
+			return err
+		}
+
+		// The exclusion constraint rejects the extension if it overlaps a later reservation
+		renewSQL := `
+			UPDATE reservations
+			SET duration = tstzrange(lower(duration), $2), renewals = renewals + 1
+			WHERE id = $1
+		`
+		_, err = tx.ExecContext(ctx, renewSQL, id, end.Format(timeFormat))
+		if err != nil {
+			return translateError(err, map[pq.ErrorCode]error{
+				exclusionViolation: ErrOverlap,
+				dataException:      ErrInvalidRange,
+			})
+		}
+
+		reservation, err = getReservation(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// FlagOverdue flags the checkouts whose reservation ended before now as overdue
+func (p *Postgres) FlagOverdue(ctx context.Context, now time.Time) ([]Reservation, error) {
+	flagOverdueSQL := `
+		UPDATE checked_out c
+		SET overdue_at = $1
+		FROM reservations r
+		WHERE r.id = c.reservation_id AND c.overdue_at IS NULL AND upper(r.duration) < $1
+		RETURNING r.id
+	`
+	rows, err := p.DB.QueryContext(ctx, flagOverdueSQL, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var reservations []Reservation
+	for _, id := range ids {
+		reservation, err := getReservation(ctx, p.DB, id)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func getReservation(ctx context.Context, q queryer, id int64) (Reservation, error) {
+	getReservationSQL := reservationSelect + `
+		WHERE r.id = $1
+	`
+	reservation, err := scanReservation(q.QueryRowContext(ctx, getReservationSQL, id))
+	if err == sql.ErrNoRows {
+		return Reservation{}, ErrReservationNotFound
+	}
+	return reservation, err
+}
+
+// lockActiveReservation locks a reservation for the rest of the transaction,
+// failing unless it is reserved and not yet checked out
+func lockActiveReservation(ctx context.Context, tx *sql.Tx, id int64) (Reservation, error) {
+	lockReservationSQL := reservationSelect + `
+		WHERE r.id = $1
+		FOR UPDATE OF r
+	`
+	reservation, err := scanReservation(tx.QueryRowContext(ctx, lockReservationSQL, id))
+	if err == sql.ErrNoRows {
+		return Reservation{}, ErrReservationNotFound
+	}
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	switch reservation.Status {
+	case StatusCheckedOut:
+		return Reservation{}, ErrAlreadyCheckedOut
+	case StatusReturned, StatusCancelled:
+		return Reservation{}, ErrReservationClosed
+	}
+	return reservation, nil
+}
+
+func setReservationStatus(ctx context.Context, tx *sql.Tx, id int64, status ReservationStatus) error {
+	setReservationStatusSQL := `
+		UPDATE reservations
+		SET status = $2
+		WHERE id = $1
+	`
+	_, err := tx.ExecContext(ctx, setReservationStatusSQL, id, status)
+	return err
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReservation(row scanner) (Reservation, error) {
+	var (
+		reservation                         Reservation
+		checkedOutAt, overdueAt, returnedAt pq.NullTime
+	)
+	err := row.Scan(
+		&reservation.ID, &reservation.ISBN, &reservation.CopyID, &reservation.PatronID, &reservation.Start, &reservation.End, &reservation.Status, &reservation.CreatedAt,
+		&reservation.LibraryID, &reservation.Library, &checkedOutAt, &reservation.CheckedOutBy, &overdueAt, &returnedAt,
+		&reservation.Renewals, &reservation.SeriesID,
+	)
+	reservation.CheckedOutAt, reservation.OverdueAt, reservation.ReturnedAt = checkedOutAt.Time, overdueAt.Time, returnedAt.Time
+	return reservation, err
+}
+
+// nullID passes an unset ID to Postgres as NULL
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// nullTime passes the zero time to Postgres as NULL
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(timeFormat)
+}
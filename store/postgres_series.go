@@ -0,0 +1,209 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// seriesSelect selects the columns read by scanSeries
+const seriesSelect = `
+	SELECT
+		s.id, s.isbn, COALESCE(s.patron_id, 0), s.rrule, s.timezone, lower(s.duration), upper(s.duration),
+		s.created_at, s.cancelled_at
+	FROM reservation_series s
+`
+
+// ReserveSeries creates a series and reserves each of its occurrences
+func (p *Postgres) ReserveSeries(ctx context.Context, series Series, occurrences []SeriesOccurrence, allOrNothing bool) (Series, []SeriesOccurrence, error) {
+	occurrences = append([]SeriesOccurrence(nil), occurrences...)
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		createSeriesSQL := `
+			INSERT INTO reservation_series (isbn, patron_id, rrule, timezone, duration)
+			VALUES ($1, $2, $3, $4, tstzrange($5, $6))
+			RETURNING id
+		`
+		err := tx.QueryRowContext(ctx, createSeriesSQL, series.ISBN, nullID(series.PatronID), series.RRule, series.Timezone,
+			series.Start.Format(timeFormat), series.End.Format(timeFormat)).Scan(&series.ID)
+		if err != nil {
+			return translateConstraintError(err, map[string]error{
+				"reservation_series_isbn_fkey":      ErrBookNotFound,
+				"reservation_series_patron_id_fkey": ErrPatronNotFound,
+			})
+		}
+
+		reserved := 0
+		for i := range occurrences {
+			if occurrences[i].Err != nil {
+				continue
+			}
+			if err = reserveOccurrence(ctx, tx, series, &occurrences[i]); err != nil {
+				return err
+			}
+			if occurrences[i].Err == nil {
+				reserved++
+			}
+		}
+
+		if reserved == 0 || (allOrNothing && reserved < len(occurrences)) {
+			return ErrSeriesConflict
+		}
+
+		series, err = getSeries(ctx, tx, series.ID)
+		return err
+	})
+	if err == ErrSeriesConflict {
+		// The reservations were rolled back along with the series
+		for i := range occurrences {
+			occurrences[i].Reservation = Reservation{}
+		}
+		return Series{}, occurrences, err
+	}
+	if err != nil {
+		return Series{}, nil, err
+	}
+
+	return series, occurrences, nil
+}
+
+// reserveOccurrence reserves an occurrence at the first of its libraries with a free copy,
+// recording ErrOverlap on it if none has one. Each attempt runs in a savepoint so that a
+// taken slot doesn't abort the rest of the transaction.
+func reserveOccurrence(ctx context.Context, tx *sql.Tx, series Series, occurrence *SeriesOccurrence) error {
+	occurrence.Err = ErrOverlap
+	for _, libraryID := range occurrence.LibraryIDs {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT occurrence`); err != nil {
+			return err
+		}
+
+		reservation, err := reserve(ctx, tx, Reservation{
+			ISBN:      series.ISBN,
+			CopyID:    occurrence.CopyID,
+			LibraryID: libraryID,
+			PatronID:  series.PatronID,
+			Start:     occurrence.Start,
+			End:       occurrence.End,
+			SeriesID:  series.ID,
+		})
+		if err == ErrOverlap || err == ErrCopyNotFound {
+			if _, err = tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT occurrence`); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT occurrence`); err != nil {
+			return err
+		}
+		occurrence.Reservation, occurrence.Err = reservation, nil
+		return nil
+	}
+	return nil
+}
+
+// GetSeries returns the reservation series with the matching ID
+func (p *Postgres) GetSeries(ctx context.Context, id int64) (Series, error) {
+	return getSeries(ctx, p.DB, id)
+}
+
+// CancelSeries cancels a series along with its reservations that haven't been checked out
+func (p *Postgres) CancelSeries(ctx context.Context, id int64) (Series, []Reservation, error) {
+	var (
+		series       Series
+		reservations []Reservation
+	)
+
+	err := p.inTx(ctx, func(tx *sql.Tx) error {
+		lockSeriesSQL := `
+			SELECT cancelled_at IS NOT NULL FROM reservation_series
+			WHERE id = $1
+			FOR UPDATE
+		`
+		var cancelled bool
+		err := tx.QueryRowContext(ctx, lockSeriesSQL, id).Scan(&cancelled)
+		switch {
+		case err == sql.ErrNoRows:
+			return ErrSeriesNotFound
+		case err != nil:
+			return err
+		case cancelled:
+			return ErrSeriesCancelled
+		}
+
+		cancelReservationsSQL := `
+			UPDATE reservations
+			SET status = 'cancelled'
+			WHERE series_id = $1 AND status = 'reserved'
+			RETURNING id
+		`
+		rows, err := tx.QueryContext(ctx, cancelReservationsSQL, id)
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var reservationID int64
+			if err = rows.Scan(&reservationID); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, reservationID)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		for _, reservationID := range ids {
+			reservation, err := getReservation(ctx, tx, reservationID)
+			if err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		}
+
+		cancelSeriesSQL := `
+			UPDATE reservation_series
+			SET cancelled_at = now()
+			WHERE id = $1
+		`
+		if _, err = tx.ExecContext(ctx, cancelSeriesSQL, id); err != nil {
+			return err
+		}
+
+		series, err = getSeries(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return Series{}, nil, err
+	}
+
+	return series, reservations, nil
+}
+
+func getSeries(ctx context.Context, q queryer, id int64) (Series, error) {
+	getSeriesSQL := seriesSelect + `
+		WHERE s.id = $1
+	`
+	series, err := scanSeries(q.QueryRowContext(ctx, getSeriesSQL, id))
+	if err == sql.ErrNoRows {
+		return Series{}, ErrSeriesNotFound
+	}
+	return series, err
+}
+
+func scanSeries(row scanner) (Series, error) {
+	var (
+		series      Series
+		cancelledAt pq.NullTime
+	)
+	err := row.Scan(&series.ID, &series.ISBN, &series.PatronID, &series.RRule, &series.Timezone, &series.Start, &series.End,
+		&series.CreatedAt, &cancelledAt)
+	series.CancelledAt = cancelledAt.Time
+	return series, err
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeRow is a row of values in column order, scanned like database/sql converts them
+type fakeRow []interface{}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(r), len(dest))
+	}
+	for i, value := range r {
+		ok := false
+		switch d := dest[i].(type) {
+		case *string:
+			var v string
+			v, ok = value.(string)
+			*d = v
+		case *int64:
+			var v int64
+			v, ok = value.(int64)
+			*d = v
+		case *float64:
+			var v float64
+			v, ok = value.(float64)
+			*d = v
+		}
+		if !ok {
+			return fmt.Errorf("column %d: can't scan %T into %T", i, value, dest[i])
+		}
+	}
+	return nil
+}
+
+// selectColumns counts the columns of a SELECT, ignoring the commas within parentheses
+func selectColumns(query string) int {
+	list := query[strings.Index(query, "SELECT")+len("SELECT") : strings.Index(query, "FROM")]
+	columns, depth := 1, 0
+	for _, r := range list {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				columns++
+			}
+		}
+	}
+	return columns
+}
+
+func TestScanBookReadsBookSelectColumns(t *testing.T) {
+	// isbn, price, library ID, library name, lat and lng, in bookSelect's order
+	row := fakeRow{"111", 9.5, int64(3), "Central", 33.6846, -117.8265}
+	if got := selectColumns(bookSelect); got != len(row) {
+		t.Fatalf("bookSelect has %d columns, want %d", got, len(row))
+	}
+
+	book, err := scanBook(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Book{ISBN: "111", Price: 9.5, LibraryID: 3, Library: "Central", Lat: 33.6846, Lng: -117.8265}
+	if book != want {
+		t.Errorf("got %+v, want %+v", book, want)
+	}
+}
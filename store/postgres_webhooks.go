@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// webhookColumns are the columns read by scanWebhook
+const webhookColumns = `id, url, secret, events, active, created_at`
+
+// deliveryColumns are the columns read by scanDelivery
+const deliveryColumns = `
+	id, webhook_id, event, payload, status, attempts, next_attempt_at,
+	last_attempt_at, last_status_code, last_error, delivered_at, created_at
+`
+
+// CreateWebhook adds a webhook subscription
+func (p *Postgres) CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	createWebhookSQL := `
+		INSERT INTO webhooks (url, secret, events, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookColumns
+	return scanWebhook(p.DB.QueryRowContext(ctx, createWebhookSQL, webhook.URL, webhook.Secret, pq.Array(nonNil(webhook.Events)), webhook.Active))
+}
+
+// GetWebhook returns the webhook with the matching ID
+func (p *Postgres) GetWebhook(ctx context.Context, id int64) (Webhook, error) {
+	getWebhookSQL := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE id = $1
+	`
+	webhook, err := scanWebhook(p.DB.QueryRowContext(ctx, getWebhookSQL, id))
+	if err == sql.ErrNoRows {
+		return Webhook{}, ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+// ListWebhooks returns every webhook ordered by ID
+func (p *Postgres) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	listWebhooksSQL := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		ORDER BY id
+	`
+	rows, err := p.DB.QueryContext(ctx, listWebhooksSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// UpdateWebhook replaces the URL, secret, events and active flag of a webhook
+func (p *Postgres) UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	updateWebhookSQL := `
+		UPDATE webhooks
+		SET url = $2, secret = $3, events = $4, active = $5
+		WHERE id = $1
+		RETURNING ` + webhookColumns
+	updated, err := scanWebhook(p.DB.QueryRowContext(ctx, updateWebhookSQL, webhook.ID, webhook.URL, webhook.Secret, pq.Array(nonNil(webhook.Events)), webhook.Active))
+	if err == sql.ErrNoRows {
+		return Webhook{}, ErrWebhookNotFound
+	}
+	return updated, err
+}
+
+// DeleteWebhook deletes a webhook, its deliveries cascading with it
+func (p *Postgres) DeleteWebhook(ctx context.Context, id int64) error {
+	deleteWebhookSQL := `
+		DELETE FROM webhooks
+		WHERE id = $1
+	`
+	result, err := p.DB.ExecContext(ctx, deleteWebhookSQL, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues the payload for every active webhook subscribed to the event
+func (p *Postgres) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int, error) {
+	enqueueDeliveriesSQL := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT id, $1, $2 FROM webhooks
+		WHERE active AND (events = '{}' OR $1 = ANY (events))
+	`
+	result, err := p.DB.ExecContext(ctx, enqueueDeliveriesSQL, event, string(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	queued, err := result.RowsAffected()
+	return int(queued), err
+}
+
+// ClaimDeliveries returns up to limit due pending deliveries, postponing their next attempt by
+// lease. Deliveries locked by a concurrent claim are skipped rather than waited on.
+func (p *Postgres) ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]WebhookDelivery, error) {
+	claimDeliveriesSQL := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = now() + $1 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	rows, err := p.DB.QueryContext(ctx, claimDeliveriesSQL, lease.Milliseconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't keep the order of the subquery
+	sortDeliveries(deliveries)
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (p *Postgres) UpdateDelivery(ctx context.Context, delivery WebhookDelivery) (WebhookDelivery, error) {
+	updateDeliverySQL := `
+		UPDATE webhook_deliveries
+		SET
+			status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+			last_status_code = $6, last_error = $7, delivered_at = $8
+		WHERE id = $1
+		RETURNING ` + deliveryColumns
+	updated, err := scanDelivery(p.DB.QueryRowContext(ctx, updateDeliverySQL, delivery.ID, delivery.Status, delivery.Attempts,
+		delivery.NextAttemptAt.Format(timeFormat), nullTime(delivery.LastAttemptAt), delivery.LastStatusCode, delivery.LastError,
+		nullTime(delivery.DeliveredAt)))
+	if err == sql.ErrNoRows {
+		// The webhook was deleted while the delivery was in flight
+		return WebhookDelivery{}, ErrWebhookNotFound
+	}
+	return updated, err
+}
+
+// ListDeliveries returns up to limit deliveries to a webhook, newest first
+func (p *Postgres) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]WebhookDelivery, error) {
+	if _, err := p.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	listDeliveriesSQL := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+	rows, err := p.DB.QueryContext(ctx, listDeliveriesSQL, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeliveries(rows)
+}
+
+func scanWebhook(row scanner) (Webhook, error) {
+	var webhook Webhook
+	err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, pq.Array(&webhook.Events), &webhook.Active, &webhook.CreatedAt)
+	return webhook, err
+}
+
+func scanDeliveries(rows *sql.Rows) ([]WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func scanDelivery(row scanner) (WebhookDelivery, error) {
+	var (
+		delivery                   WebhookDelivery
+		lastAttemptAt, deliveredAt pq.NullTime
+	)
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &lastAttemptAt, &delivery.LastStatusCode, &delivery.LastError,
+		&deliveredAt, &delivery.CreatedAt)
+	delivery.LastAttemptAt = lastAttemptAt.Time
+	delivery.DeliveredAt = deliveredAt.Time
+	return delivery, err
+}
+
+func sortDeliveries(deliveries []WebhookDelivery) {
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+}
+
+// nonNil keeps an empty list from being written as NULL
+func nonNil(strs []string) []string {
+	if strs == nil {
+		return []string{}
+	}
+	return strs
+}
@@ -0,0 +1,669 @@
+// Package store abstracts the persistence of libraries, books, copies, reservations and checkouts
+// so that the Reservation service can run against Postgres or entirely in memory.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	// ErrLibraryNotFound is returned when no library matches the requested ID
+	ErrLibraryNotFound = errors.New("library not found")
+	// ErrLibraryExists is returned when a library's name is already taken
+	ErrLibraryExists = errors.New("a library with this name already exists")
+	// ErrLibraryInUse is returned when deleting a library that still holds copies
+	ErrLibraryInUse = errors.New("library still holds copies")
+	// ErrHoursExceptionNotFound is returned when no opening hours exception matches the requested ID
+	ErrHoursExceptionNotFound = errors.New("opening hours exception not found")
+	// ErrHoursExceptionExists is returned when a library already has an opening hours exception on the date
+	ErrHoursExceptionExists = errors.New("the library already has an opening hours exception on this date")
+	// ErrBookNotFound is returned when no book matches the requested ISBN
+	ErrBookNotFound = errors.New("book not found")
+	// ErrBookExists is returned when adding a book whose ISBN is already taken
+	ErrBookExists = errors.New("book already exists")
+	// ErrBookInUse is returned when deleting a book that is still referenced by reservations
+	ErrBookInUse = errors.New("book has existing reservations")
+	// ErrCopyNotFound is returned when no copy matches the requested ID, or a book has no copies
+	ErrCopyNotFound = errors.New("copy not found")
+	// ErrCopyExists is returned when a copy's barcode is already taken
+	ErrCopyExists = errors.New("a copy with this barcode already exists")
+	// ErrCopyInUse is returned when deleting a copy that is still referenced by reservations
+	ErrCopyInUse = errors.New("copy has existing reservations")
+	// ErrCopyRequired is returned when a request by ISBN matches several copies
+	ErrCopyRequired = errors.New("several copies of the book match, the copy must be specified")
+	// ErrPatronNotFound is returned when no patron matches the requested ID
+	ErrPatronNotFound = errors.New("patron not found")
+	// ErrPatronExists is returned when a patron's email address is already taken
+	ErrPatronExists = errors.New("a patron with this email already exists")
+	// ErrReservationNotFound is returned when no reservation matches the lookup
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrOverlap is returned when a reservation overlaps with an existing one for the same book
+	ErrOverlap = errors.New("reservation overlaps with an existing slot")
+	// ErrInvalidRange is returned when a reservation ends before it starts
+	ErrInvalidRange = errors.New("range lower bound must be less than or equal to upper bound")
+	// ErrReservationClosed is returned when acting on a reservation that has been returned or cancelled
+	ErrReservationClosed = errors.New("reservation is no longer active")
+	// ErrAlreadyCheckedOut is returned when checking out a book that is already checked out
+	ErrAlreadyCheckedOut = errors.New("book is already checked out")
+	// ErrNotCheckedOut is returned when returning a book that has not been checked out
+	ErrNotCheckedOut = errors.New("book has not been checked out")
+	// ErrHoldNotFound is returned when no hold matches the requested ID
+	ErrHoldNotFound = errors.New("hold not found")
+	// ErrHoldExists is returned when a patron is already waiting in the hold queue of a book
+	ErrHoldExists = errors.New("the patron is already in the hold queue of this book")
+	// ErrHoldClosed is returned when acting on a hold that has been fulfilled or cancelled
+	ErrHoldClosed = errors.New("hold is no longer waiting")
+	// ErrRenewalLimit is returned when a loan has been renewed as many times as its library allows
+	ErrRenewalLimit = errors.New("the loan has been renewed the maximum number of times")
+	// ErrLoanTooLong is returned when a renewal would make a loan longer than its library allows
+	ErrLoanTooLong = errors.New("the renewed loan would be longer than the library allows")
+	// ErrLoanOverdue is returned when renewing a loan that is already overdue
+	ErrLoanOverdue = errors.New("the loan is overdue and must be returned")
+	// ErrSeriesNotFound is returned when no reservation series matches the requested ID
+	ErrSeriesNotFound = errors.New("reservation series not found")
+	// ErrSeriesConflict is returned when the occurrences of a series can't be reserved as requested
+	ErrSeriesConflict = errors.New("the occurrences of the series overlap with existing slots")
+	// ErrSeriesCancelled is returned when cancelling a reservation series that is already cancelled
+	ErrSeriesCancelled = errors.New("reservation series is already cancelled")
+	// ErrWebhookNotFound is returned when no webhook matches the requested ID
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// Library is a branch that holds copies of books
+type Library struct {
+	ID      int64
+	Name    string
+	Address string
+	Lat     float64
+	Lng     float64
+	// Timezone is the IANA time zone the library is in
+	Timezone string
+	Email    string
+	Phone    string
+	LateFees LateFeePolicy
+	// MaxRenewals is how many times a loan can be renewed, and MaxLoanDays how
+	// long a renewed loan can last from the reservation's start. 0 means no limit.
+	MaxRenewals int
+	MaxLoanDays int
+	CreatedAt   time.Time
+}
+
+// LateFeePolicy is what a library charges for each day a book is returned late: a flat
+// PerDay fee plus PercentPerDay percent of the book's price. The total is capped at Max
+// unless Max is 0.
+type LateFeePolicy struct {
+	PerDay        float64
+	PercentPerDay float64
+	Max           float64
+}
+
+// Fee returns the late fee for returning a book of the given price at returned when it
+// was due at due, and how many days late it is. Every started day counts as a day late.
+func (policy LateFeePolicy) Fee(price float64, due, returned time.Time) (float64, int) {
+	if !returned.After(due) {
+		return 0, 0
+	}
+	daysLate := int(math.Ceil(returned.Sub(due).Hours() / 24))
+
+	fee := float64(daysLate) * (policy.PerDay + policy.PercentPerDay/100*price)
+	if policy.Max > 0 && fee > policy.Max {
+		fee = policy.Max
+	}
+	// Round to the cent
+	return math.Round(fee*100) / 100, daysLate
+}
+
+// TimeOfDay is a wall clock time in minutes since midnight, from 00:00 to 24:00
+type TimeOfDay int
+
+// ParseTimeOfDay parses an "HH:MM" time of day
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	invalid := fmt.Errorf("%q is not an HH:MM time of day", s)
+	if len(s) != 5 || s[2] != ':' {
+		return 0, invalid
+	}
+
+	var digits [4]int
+	for i, c := range s[:2] + s[3:] {
+		if c < '0' || c > '9' {
+			return 0, invalid
+		}
+		digits[i] = int(c - '0')
+	}
+
+	hours, minutes := digits[0]*10+digits[1], digits[2]*10+digits[3]
+	t := TimeOfDay(hours*60 + minutes)
+	if minutes >= 60 || t > 24*60 {
+		return 0, invalid
+	}
+	return t, nil
+}
+
+// TimeOfDayOf returns the wall clock time of t in its location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay(t.Hour()*60 + t.Minute())
+}
+
+// String formats the time of day as "HH:MM"
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t/60, t%60)
+}
+
+// OpeningHours is a period a library is open every week, in the library's time zone
+type OpeningHours struct {
+	Weekday time.Weekday
+	Opens   TimeOfDay
+	Closes  TimeOfDay
+}
+
+// HoursException replaces a library's weekly opening hours on a date, e.g. for a holiday
+type HoursException struct {
+	ID        int64
+	LibraryID int64
+	// Date is midnight UTC of the date, which is in the library's time zone
+	Date time.Time
+	// Closed is set if the library is closed all day, otherwise it opens from Opens to Closes
+	Closed bool
+	Opens  TimeOfDay
+	Closes TimeOfDay
+	Reason string
+}
+
+// Book is a title held by libraries as one or more copies
+type Book struct {
+	ISBN  string
+	Price float64
+
+	// LibraryID is the library holding the book's first copy. AddBook creates
+	// that copy when it is set. SearchBooks sets it to the library holding the
+	// available copies. Library, Lat and Lng are read from the library.
+	LibraryID int64
+	Library   string
+	Lat       float64
+	Lng       float64
+
+	// AvailableCopies is how many copies are free over the searched window, TotalCopies
+	// how many copies the library holds, and DistanceMeters how far the library is from
+	// the searched point. Only set by SearchBooks.
+	AvailableCopies int
+	TotalCopies     int
+	DistanceMeters  float64
+}
+
+// ImportOutcome is what importing a row did
+type ImportOutcome string
+
+// Import outcomes
+const (
+	ImportCreated  ImportOutcome = "created"
+	ImportUpdated  ImportOutcome = "updated"
+	ImportRejected ImportOutcome = "rejected"
+)
+
+// ImportRow is a book to import, with the copy to add at Book.LibraryID if set
+type ImportRow struct {
+	Book Book
+
+	Outcome ImportOutcome
+	// Err is why a rejected row wasn't imported. Rows that already have an error are
+	// rejected without being imported.
+	Err error
+}
+
+// Copy is a physical copy of a book held by a library
+type Copy struct {
+	ID        int64
+	ISBN      string
+	LibraryID int64
+	// Location is where the copy is kept within the library, e.g. a shelf
+	Location string
+	Barcode  string
+
+	// Library, Lat and Lng are read from the library
+	Library string
+	Lat     float64
+	Lng     float64
+}
+
+// ReservationStatus is where a reservation is in its lifecycle
+type ReservationStatus string
+
+// Reservation statuses, as stored in reservations.status
+const (
+	StatusReserved   ReservationStatus = "reserved"
+	StatusCheckedOut ReservationStatus = "checked_out"
+	StatusReturned   ReservationStatus = "returned"
+	StatusCancelled  ReservationStatus = "cancelled"
+)
+
+// holdsSlot reports whether a reservation in the status keeps its copy from being
+// reserved over its window. Cancelled and returned reservations free the copy.
+func (s ReservationStatus) holdsSlot() bool {
+	return s == StatusReserved || s == StatusCheckedOut
+}
+
+// HoldStatus is where a hold is in its lifecycle
+type HoldStatus string
+
+// Hold statuses, as stored in holds.status
+const (
+	HoldWaiting   HoldStatus = "waiting"
+	HoldFulfilled HoldStatus = "fulfilled"
+	HoldCancelled HoldStatus = "cancelled"
+)
+
+// Hold is a patron queued for the next window of Days days in which a copy of a book is free
+type Hold struct {
+	ID       int64
+	ISBN     string
+	PatronID int64
+	// LibraryID is the library to reserve at, or 0 for any library
+	LibraryID int64
+	Days      int
+	Status    HoldStatus
+	// ReservationID is the reservation the hold was promoted to, once fulfilled
+	ReservationID int64
+	CreatedAt     time.Time
+
+	// Position is the 1-based place of a waiting hold in its book's queue, 0 otherwise
+	Position int
+}
+
+// Series is a set of reservations of a book recurring by an RFC 5545 RRULE
+type Series struct {
+	ID       int64
+	ISBN     string
+	PatronID int64
+	RRule    string
+	// Timezone is the IANA time zone the occurrences keep their wall clock time in
+	Timezone string
+	// Start and End are the window of the first occurrence, which every occurrence repeats
+	Start     time.Time
+	End       time.Time
+	CreatedAt time.Time
+	// CancelledAt is when the series was cancelled, or the zero time if it hasn't been
+	CancelledAt time.Time
+}
+
+// SeriesOccurrence is one window of a series, reserved at the first of LibraryIDs with a free copy
+type SeriesOccurrence struct {
+	Start      time.Time
+	End        time.Time
+	LibraryIDs []int64
+	// CopyID is the copy to reserve, or 0 for any copy at the libraries
+	CopyID int64
+
+	// Reservation is the reservation made for the occurrence, if any
+	Reservation Reservation
+	// Err is why the occurrence wasn't reserved. Occurrences that already have an error
+	// aren't reserved at all.
+	Err error
+}
+
+// Webhook is a subscription of an HTTP endpoint to service events
+type Webhook struct {
+	ID  int64
+	URL string
+	// Secret is the key deliveries are signed with
+	Secret string
+	// Events are the events delivered to the webhook, or every event if empty
+	Events    []string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// Subscribed reports whether the webhook wants the event
+func (w Webhook) Subscribed(event string) bool {
+	if !w.Active {
+		return false
+	}
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus is where a webhook delivery is in its lifecycle
+type DeliveryStatus string
+
+// Delivery statuses, as stored in webhook_deliveries.status
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed deliveries have used up their attempts
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event queued for, or delivered to, a webhook
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int64
+	Event     string
+	// Payload is the JSON request body
+	Payload  []byte
+	Status   DeliveryStatus
+	Attempts int
+	// NextAttemptAt is when a pending delivery is next due
+	NextAttemptAt time.Time
+	// LastAttemptAt, LastStatusCode and LastError describe the latest attempt. They are
+	// zero before the first attempt, and LastStatusCode is 0 if no response came back.
+	LastAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    time.Time
+	CreatedAt      time.Time
+}
+
+// Patron is a library user who makes reservations and checks out books
+type Patron struct {
+	ID    int64
+	Name  string
+	Email string
+	Phone string
+	// Balance is the total of the fees charged to the patron
+	Balance   float64
+	CreatedAt time.Time
+}
+
+// Fee is a charge to a patron's account, such as a late fee for a reservation
+type Fee struct {
+	ID            int64
+	PatronID      int64
+	ReservationID int64
+	Amount        float64
+	DaysLate      int
+	CreatedAt     time.Time
+}
+
+// Reservation is a book reserved over a half-open [Start, End) window
+type Reservation struct {
+	ID     int64
+	ISBN   string
+	CopyID int64
+	// PatronID is the patron who made the reservation, or 0 if it was made anonymously
+	PatronID  int64
+	Start     time.Time
+	End       time.Time
+	Status    ReservationStatus
+	CreatedAt time.Time
+
+	// LibraryID and Library are the library holding the reserved copy
+	LibraryID int64
+	Library   string
+	// CheckedOutAt is when the book was checked out, or the zero time if it isn't checked out
+	CheckedOutAt time.Time
+	// CheckedOutBy is the patron who checked the book out, or 0 if unknown
+	CheckedOutBy int64
+	// OverdueAt is when a checkout was flagged as overdue, or the zero time if it hasn't been
+	OverdueAt time.Time
+	// ReturnedAt is when the book was returned, or the zero time if it hasn't been
+	ReturnedAt time.Time
+	// Renewals is how many times the loan has been renewed
+	Renewals int
+	// SeriesID is the series the reservation was made for, or 0 if it was made on its own
+	SeriesID int64
+}
+
+// ReservationOrder is the order ListReservations returns reservations in
+type ReservationOrder int
+
+// Reservation orders. Ties are broken by reservation ID in the same direction.
+const (
+	OrderByStart ReservationOrder = iota
+	OrderByStartDesc
+	OrderByCreated
+	OrderByCreatedDesc
+)
+
+// ReservationCursor is the sort key of the last reservation of a page
+type ReservationCursor struct {
+	// Time is the reservation's start or creation time, depending on the order
+	Time time.Time
+	ID   int64
+}
+
+// ListReservationsQuery filters and pages through reservations. Zero values don't filter.
+type ListReservationsQuery struct {
+	ISBN string
+	// Library filters by library name, LibraryID by library ID
+	Library   string
+	LibraryID int64
+	PatronID  int64
+	SeriesID  int64
+	// Start and End select reservations overlapping the window. Either may be
+	// the zero time to leave that end of the window unbounded.
+	Start    time.Time
+	End      time.Time
+	Statuses []ReservationStatus
+	// EndBefore selects reservations ending before it
+	EndBefore time.Time
+
+	Order ReservationOrder
+	// After skips every reservation up to and including the cursor
+	After *ReservationCursor
+	Limit int
+}
+
+// SearchOrder is the order SearchBooks returns books in
+type SearchOrder int
+
+// Search orders. Ties are broken by ISBN and then library ID, and ties in price by distance first.
+const (
+	SearchByDistance SearchOrder = iota
+	SearchByPrice
+	SearchByLibraryName
+)
+
+// SearchCursor is the sort key of the last book of a page of search results. Only the
+// fields of the query's order are compared.
+type SearchCursor struct {
+	DistanceMeters float64
+	Price          float64
+	Library        string
+	ISBN           string
+	LibraryID      int64
+}
+
+// SearchQuery describes a geographic search for books free over a time window.
+// Zero values of the optional filters don't filter.
+type SearchQuery struct {
+	Lat         float64
+	Lng         float64
+	RangeMeters float64
+	// Start and End bound the window copies must be free over. Either may be the zero
+	// time to leave that end unbounded. If both are, every book in range is returned
+	// with the copies free right now as its available copies.
+	Start time.Time
+	End   time.Time
+
+	MinPrice   float64
+	MaxPrice   float64
+	LibraryIDs []int64
+	ISBNs      []string
+
+	Order SearchOrder
+	// After skips every result up to and including the cursor
+	After *SearchCursor
+	Limit int
+}
+
+// Store persists libraries, books, copies, reservations and checkouts
+type Store interface {
+	// CreateLibrary adds a new library
+	CreateLibrary(ctx context.Context, library Library) (Library, error)
+	// GetLibrary returns the library with the matching ID
+	GetLibrary(ctx context.Context, id int64) (Library, error)
+	// ListLibraries returns every library ordered by name
+	ListLibraries(ctx context.Context) ([]Library, error)
+	// UpdateLibrary replaces every field of an existing library but its ID and creation time
+	UpdateLibrary(ctx context.Context, library Library) (Library, error)
+	// DeleteLibrary deletes a library that holds no copies, along with its opening hours
+	DeleteLibrary(ctx context.Context, id int64) error
+
+	// GetOpeningHours returns the weekly opening hours of a library ordered by weekday and time
+	GetOpeningHours(ctx context.Context, libraryID int64) ([]OpeningHours, error)
+	// SetOpeningHours replaces the weekly opening hours of a library
+	SetOpeningHours(ctx context.Context, libraryID int64, hours []OpeningHours) ([]OpeningHours, error)
+	// ListHoursExceptions returns the opening hours exceptions of a library dated from from
+	// to to inclusive, ordered by date. Either may be the zero time to leave that end unbounded.
+	ListHoursExceptions(ctx context.Context, libraryID int64, from, to time.Time) ([]HoursException, error)
+	// AddHoursException adds an opening hours exception to a library
+	AddHoursException(ctx context.Context, exception HoursException) (HoursException, error)
+	// DeleteHoursException deletes an opening hours exception of a library
+	DeleteHoursException(ctx context.Context, libraryID, id int64) error
+
+	// AllBooks returns up to limit books ordered by ISBN, starting after the ISBN after
+	AllBooks(ctx context.Context, after string, limit int) ([]Book, error)
+	// GetBook returns the book with the matching ISBN
+	GetBook(ctx context.Context, isbn string) (Book, error)
+	// AddBook adds a new book, along with its first copy if book.LibraryID is set
+	AddBook(ctx context.Context, book Book) error
+	// ImportBooks imports rows in one transaction. Each row adds its book, or updates the
+	// price of an existing one, and adds a copy at its library unless the book already has
+	// one there. The copy of a new book is barcoded with the ISBN, like AddBook's, and
+	// others with the ISBN and library ID. Rows that fail are rejected with their error
+	// without affecting the others. Nothing is kept if dryRun is set.
+	//
+	// imported holds the ISBNs imported by earlier calls of the same import, and gets those
+	// of rows. They count as existing books even when a dry run didn't keep them, so that
+	// a dry run in batches reports what a real one would.
+	ImportBooks(ctx context.Context, rows []ImportRow, imported map[string]bool, dryRun bool) ([]ImportRow, error)
+	// DeleteBook deletes the book with the matching ISBN and its copies
+	DeleteBook(ctx context.Context, isbn string) error
+	// SearchBooks returns up to query.Limit books at libraries within range with copies free
+	// for the whole window, one per book and library, in query.Order
+	SearchBooks(ctx context.Context, query SearchQuery) ([]Book, error)
+
+	// AddCopy adds a new copy of an existing book to an existing library
+	AddCopy(ctx context.Context, copy Copy) (Copy, error)
+	// GetCopy returns the copy with the matching ID
+	GetCopy(ctx context.Context, id int64) (Copy, error)
+	// ListCopies returns the copies of a book
+	ListCopies(ctx context.Context, isbn string) ([]Copy, error)
+	// DeleteCopy deletes a copy that has never been reserved
+	DeleteCopy(ctx context.Context, id int64) error
+
+	// CreatePatron adds a new patron
+	CreatePatron(ctx context.Context, patron Patron) (Patron, error)
+	// GetPatron returns the patron with the matching ID
+	GetPatron(ctx context.Context, id int64) (Patron, error)
+	// UpdatePatron replaces the name, email and phone of an existing patron
+	UpdatePatron(ctx context.Context, patron Patron) (Patron, error)
+	// ListFees returns the fees charged to a patron, newest first
+	ListFees(ctx context.Context, patronID int64) ([]Fee, error)
+
+	// Reserve reserves a copy of reservation.ISBN for reservation.PatronID over
+	// [reservation.Start, reservation.End). It takes reservation.CopyID, or any free
+	// copy at reservation.LibraryID, or any free copy if both are 0, and fails with
+	// ErrOverlap if no such copy is free.
+	Reserve(ctx context.Context, reservation Reservation) (Reservation, error)
+	// GetReservation returns the reservation with the matching ID
+	GetReservation(ctx context.Context, id int64) (Reservation, error)
+	// FindReservation returns the active reservation of a book over exactly [start, end).
+	// A copyID of 0 matches any copy, failing with ErrCopyRequired if several do.
+	FindReservation(ctx context.Context, isbn string, copyID int64, start, end time.Time) (Reservation, error)
+	// ListReservations returns up to query.Limit reservations matching the query
+	ListReservations(ctx context.Context, query ListReservationsQuery) ([]Reservation, error)
+	// CancelReservation cancels a reservation that hasn't been checked out, releasing its slot
+	CancelReservation(ctx context.Context, id int64) (Reservation, error)
+	// RescheduleReservation moves a reservation that hasn't been checked out to [start, end).
+	// The reservation keeps its old slot if the new one overlaps another reservation.
+	RescheduleReservation(ctx context.Context, id int64, start, end time.Time) (Reservation, error)
+
+	// Checkout marks a reservation as checked out by a patron. A patronID of 0
+	// means the book is checked out by the patron who made the reservation.
+	Checkout(ctx context.Context, reservationID, patronID int64) (Reservation, error)
+	// Return removes the checkout of a copy of a book and returns its reservation, marked as
+	// returned to free the rest of its window.
+	// A copyID of 0 matches any checked out copy, failing with ErrCopyRequired if several are.
+	// A book returned after the reservation's end is charged the late fee of the copy's
+	// library, which is recorded against the patron and returned. The fee is zero if the
+	// book is on time, or if nobody is known to have checked it out.
+	Return(ctx context.Context, isbn string, copyID int64) (Reservation, Fee, error)
+	// Renew extends a checked out reservation to end, counting a renewal. It fails with
+	// ErrOverlap if a later reservation of the copy overlaps the extension, and with
+	// ErrRenewalLimit, ErrLoanTooLong or ErrLoanOverdue if the library doesn't allow it.
+	Renew(ctx context.Context, id int64, end time.Time) (Reservation, error)
+	// FlagOverdue flags the checkouts whose reservation ended before now as overdue,
+	// returning the reservations of those that weren't flagged yet
+	FlagOverdue(ctx context.Context, now time.Time) ([]Reservation, error)
+
+	// ReserveSeries creates a series and reserves its occurrences for series.PatronID as
+	// Reserve does, at the first library of each with a free copy, recording why the
+	// others weren't reserved. If allOrNothing is set, nothing is kept unless every
+	// occurrence is reserved. Otherwise the occurrences that are free are kept unless
+	// none are. Both fail with ErrSeriesConflict along with the occurrences.
+	ReserveSeries(ctx context.Context, series Series, occurrences []SeriesOccurrence, allOrNothing bool) (Series, []SeriesOccurrence, error)
+	// GetSeries returns the reservation series with the matching ID
+	GetSeries(ctx context.Context, id int64) (Series, error)
+	// CancelSeries cancels a series along with its reservations that haven't been checked
+	// out, returning those reservations
+	CancelSeries(ctx context.Context, id int64) (Series, []Reservation, error)
+
+	// PlaceHold adds a patron to the back of the hold queue of a book
+	PlaceHold(ctx context.Context, hold Hold) (Hold, error)
+	// GetHold returns the hold with the matching ID
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	// ListHolds returns the waiting holds of a book in queue order
+	ListHolds(ctx context.Context, isbn string) ([]Hold, error)
+	// HeldBooks returns the ISBNs of the books with waiting holds, in order
+	HeldBooks(ctx context.Context) ([]string, error)
+	// CancelHold takes a waiting hold out of its queue
+	CancelHold(ctx context.Context, id int64) (Hold, error)
+	// FulfillHold makes reservation, as Reserve does, and marks the waiting hold as
+	// fulfilled by it. Neither happens if the hold isn't waiting or no copy is free.
+	FulfillHold(ctx context.Context, id int64, reservation Reservation) (Hold, Reservation, error)
+
+	// CreateWebhook adds a webhook subscription
+	CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	// GetWebhook returns the webhook with the matching ID
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
+	// ListWebhooks returns every webhook ordered by ID
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	// UpdateWebhook replaces the URL, secret, events and active flag of a webhook
+	UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	// DeleteWebhook deletes a webhook along with its deliveries
+	DeleteWebhook(ctx context.Context, id int64) error
+	// EnqueueDeliveries queues the payload for delivery to every active webhook
+	// subscribed to the event, returning how many deliveries were queued
+	EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int, error)
+	// ClaimDeliveries returns up to limit pending deliveries that are due, in the order they
+	// were queued, and postpones their next attempt by lease so that no one else claims them
+	ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]WebhookDelivery, error)
+	// UpdateDelivery records the outcome of an attempt: the status, attempts, next attempt
+	// and last attempt fields and the delivery time
+	UpdateDelivery(ctx context.Context, delivery WebhookDelivery) (WebhookDelivery, error)
+	// ListDeliveries returns up to limit deliveries to a webhook, newest first
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]WebhookDelivery, error)
+}
+
+// checkRenewal reports whether the library allows extending a checked out reservation
+// to end at now
+func checkRenewal(library Library, reservation Reservation, end, now time.Time) error {
+	switch {
+	case !end.After(reservation.End):
+		return ErrInvalidRange
+	case reservation.End.Before(now):
+		return ErrLoanOverdue
+	case library.MaxRenewals > 0 && reservation.Renewals >= library.MaxRenewals:
+		return ErrRenewalLimit
+	case library.MaxLoanDays > 0 && end.Sub(reservation.Start) > time.Duration(library.MaxLoanDays)*24*time.Hour:
+		return ErrLoanTooLong
+	}
+	return nil
+}
+
+// overlaps reports whether the half-open ranges [aStart, aEnd) and [bStart, bEnd) intersect,
+// following the semantics of the && operator on tstzrange
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/config"
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+const (
+	// batchSize is the most deliveries attempted by a single run, all at once
+	batchSize = 50
+	// minBackoff is the delay before the first retry, doubling with every retry after it
+	minBackoff = 10 * time.Second
+	// maxBackoff caps the delay between retries
+	maxBackoff = time.Hour
+	// maxErrorLength truncates the error recorded for an attempt, including response bodies
+	maxErrorLength = 512
+)
+
+// Deliverer sends due deliveries to their webhooks
+type Deliverer struct {
+	Store       store.Store
+	Client      *http.Client
+	MaxAttempts int
+}
+
+// NewDeliverer returns a Deliverer configured by cfg
+func NewDeliverer(st store.Store, cfg config.Webhooks) *Deliverer {
+	return &Deliverer{
+		Store:       st,
+		Client:      &http.Client{Timeout: time.Duration(cfg.Timeout)},
+		MaxAttempts: cfg.MaxAttempts,
+	}
+}
+
+// Run attempts every due delivery. It is meant to be run by a lifecycle.Worker.
+func (d *Deliverer) Run(ctx context.Context) error {
+	// Deliveries are claimed for longer than an attempt can take, so they are
+	// only picked up again if this process dies mid-attempt
+	lease := 2*d.Client.Timeout + time.Minute
+	deliveries, err := d.Store.ClaimDeliveries(ctx, lease, batchSize)
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[int64]store.Webhook)
+	for _, delivery := range deliveries {
+		if _, ok := webhooks[delivery.WebhookID]; ok {
+			continue
+		}
+		webhook, err := d.Store.GetWebhook(ctx, delivery.WebhookID)
+		if err == store.ErrWebhookNotFound {
+			// The webhook was deleted after its deliveries were claimed, deleting them too,
+			// which mustn't hold up the rest of the batch. The zero webhook marks it as gone.
+			webhooks[delivery.WebhookID] = store.Webhook{}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		webhooks[webhook.ID] = webhook
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		if webhooks[delivery.WebhookID].ID == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(delivery store.WebhookDelivery) {
+			defer wg.Done()
+			d.attempt(ctx, webhooks[delivery.WebhookID], delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// attempt sends a delivery once and records the outcome, scheduling a retry if it failed
+func (d *Deliverer) attempt(ctx context.Context, webhook store.Webhook, delivery store.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.LastStatusCode, delivery.LastError = d.send(ctx, webhook, delivery)
+
+	switch {
+	case delivery.LastError == "":
+		delivery.Status = store.DeliveryDelivered
+		delivery.DeliveredAt = now
+	case delivery.Attempts >= d.MaxAttempts:
+		delivery.Status = store.DeliveryFailed
+		fmt.Println(fmt.Sprintf("Gave up on webhook delivery %d after %d attempts: %s", delivery.ID, delivery.Attempts, delivery.LastError))
+	default:
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+
+	if _, err := d.Store.UpdateDelivery(ctx, delivery); err != nil && err != store.ErrWebhookNotFound {
+		fmt.Println(fmt.Sprintf("Failed to record webhook delivery %d: %v", delivery.ID, err))
+	}
+}
+
+// send POSTs a delivery to its webhook, returning the response status code and an
+// error message unless the webhook responded with a 2xx status
+func (d *Deliverer) send(ctx context.Context, webhook store.Webhook, delivery store.WebhookDelivery) (int, string) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, truncate(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "scheduling-rpc-webhooks")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, truncate(err.Error())
+	}
+	defer res.Body.Close()
+
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorLength))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, truncate(fmt.Sprintf("%s: %s", res.Status, body))
+	}
+	return res.StatusCode, ""
+}
+
+// backoff returns the delay before the retry following the given number of attempts
+func backoff(attempts int) time.Duration {
+	delay := minBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// deletingStore deletes a webhook as soon as its deliveries have been claimed, as a
+// concurrent DeleteWebhook can
+type deletingStore struct {
+	store.Store
+	deleteID int64
+}
+
+func (s deletingStore) ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]store.WebhookDelivery, error) {
+	deliveries, err := s.Store.ClaimDeliveries(ctx, lease, limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, s.Store.DeleteWebhook(ctx, s.deleteID)
+}
+
+func TestRunSkipsDeliveriesOfDeletedWebhooks(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	st := store.NewMemory()
+	deleted, err := st.CreateWebhook(ctx, store.Webhook{URL: server.URL, Secret: "a", Active: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, err := st.CreateWebhook(ctx, store.Webhook{URL: server.URL, Secret: "b", Active: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = st.EnqueueDeliveries(ctx, ReservationCreated, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Deliverer{Store: deletingStore{Store: st, deleteID: deleted.ID}, Client: server.Client(), MaxAttempts: 1}
+	if err = d.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	deliveries, err := st.ListDeliveries(ctx, kept.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != store.DeliveryDelivered {
+		t.Errorf("got %+v, want the kept webhook's delivery delivered", deliveries)
+	}
+}
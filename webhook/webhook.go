@@ -0,0 +1,120 @@
+// Package webhook notifies other systems of service events by POSTing them to
+// subscribed HTTP endpoints.
+//
+// Events are queued in the store by a Publisher as they happen and delivered
+// asynchronously by a Deliverer, which signs each request and retries failed
+// deliveries with exponential backoff.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pmaroli/scheduling-rpc/store"
+)
+
+// Events published by the service
+const (
+	ReservationCreated     = "reservation.created"
+	ReservationCancelled   = "reservation.cancelled"
+	ReservationRescheduled = "reservation.rescheduled"
+	BookCheckedOut         = "book.checked_out"
+	BookReturned           = "book.returned"
+	BookOverdue            = "book.overdue"
+	LoanRenewed            = "loan.renewed"
+	HoldPromoted           = "hold.promoted"
+)
+
+// Events lists every event a webhook can subscribe to
+var Events = []string{
+	ReservationCreated,
+	ReservationCancelled,
+	ReservationRescheduled,
+	BookCheckedOut,
+	BookReturned,
+	BookOverdue,
+	LoanRenewed,
+	HoldPromoted,
+}
+
+// Request headers set on every delivery
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Envelope is the JSON body of every delivery
+type Envelope struct {
+	// ID identifies the event, and is the same for every webhook it is delivered to
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Data is the resource the event is about, as the REST gateway renders it
+	Data json.RawMessage `json:"data"`
+}
+
+// Publisher queues events for delivery to the webhooks subscribed to them
+type Publisher struct {
+	Store store.Store
+}
+
+// NewPublisher returns a Publisher that queues deliveries in st
+func NewPublisher(st store.Store) *Publisher {
+	return &Publisher{Store: st}
+}
+
+// Publish queues an event for delivery. It is called once the change the event
+// describes has been made, so failures are logged rather than returned.
+func (p *Publisher) Publish(ctx context.Context, event string, data json.RawMessage) {
+	if p == nil {
+		return
+	}
+
+	id, err := RandomHex(16)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("Failed to publish %s: %v", event, err))
+		return
+	}
+	payload, err := json.Marshal(Envelope{ID: id, Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		fmt.Println(fmt.Sprintf("Failed to publish %s: %v", event, err))
+		return
+	}
+
+	if _, err = p.Store.EnqueueDeliveries(ctx, event, payload); err != nil {
+		fmt.Println(fmt.Sprintf("Failed to publish %s: %v", event, err))
+	}
+}
+
+// Sign returns the signature of a delivery, sent in the X-Webhook-Signature header
+// as "sha256=" followed by the hex encoded HMAC-SHA256 of "<timestamp>.<body>",
+// keyed with the webhook's secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of the body and timestamp
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// RandomHex returns n random bytes hex encoded, for event IDs and webhook secrets
+func RandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}